@@ -20,7 +20,29 @@ func NewAnteHandler(
 	sigGasConsumer ante.SignatureVerificationGasConsumer,
 	channelKeeper *ibckeeper.Keeper,
 ) sdk.AnteHandler {
-	return sdk.ChainAnteDecorators(
+	return sdk.ChainAnteDecorators(newAnteDecorators(
+		accountKeeper,
+		bankKeeper,
+		blobKeeper,
+		feegrantKeeper,
+		signModeHandler,
+		sigGasConsumer,
+		channelKeeper,
+	)...)
+}
+
+// newAnteDecorators returns the ordered list of decorators that make up the
+// ante handler.
+func newAnteDecorators(
+	accountKeeper ante.AccountKeeper,
+	bankKeeper authtypes.BankKeeper,
+	blobKeeper blob.Keeper,
+	feegrantKeeper ante.FeegrantKeeper,
+	signModeHandler signing.SignModeHandler,
+	sigGasConsumer ante.SignatureVerificationGasConsumer,
+	channelKeeper *ibckeeper.Keeper,
+) []sdk.AnteDecorator {
+	return []sdk.AnteDecorator{
 		// Wraps the panic with the string format of the transaction
 		NewHandlePanicDecorator(),
 		// Set up the context with a gas meter.
@@ -67,7 +89,7 @@ func NewAnteHandler(
 		ante.NewIncrementSequenceDecorator(accountKeeper),
 		// Ensure that the tx is not a IBC packet or update message that has already been processed.
 		ibcante.NewRedundantRelayDecorator(channelKeeper),
-	)
+	}
 }
 
 var DefaultSigVerificationGasConsumer = ante.DefaultSigVerificationGasConsumer
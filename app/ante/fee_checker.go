@@ -17,6 +17,10 @@ const (
 
 // CheckTxFeeWithGlobalMinGasPrices implements the default fee logic, where the minimum price per
 // unit of gas is fixed and set globally, and the tx priority is computed from the gas price.
+// From v3 onwards, txs that only contain governance votes and have a gas limit
+// of at most GovVoteMaxGas are exempt from the global minimum gas price. The
+// exemption is scoped to the v3 upgrade because the global minimum gas price
+// is enforced in consensus, so it has no effect on v1 and v2.
 func CheckTxFeeWithGlobalMinGasPrices(ctx sdk.Context, tx sdk.Tx) (sdk.Coins, int64, error) {
 	feeTx, ok := tx.(sdk.FeeTx)
 	if !ok {
@@ -28,7 +32,7 @@ func CheckTxFeeWithGlobalMinGasPrices(ctx sdk.Context, tx sdk.Tx) (sdk.Coins, in
 	appVersion := ctx.BlockHeader().Version.App
 
 	// global minimum fee only applies to app versions greater than one
-	if appVersion > v1.Version {
+	if appVersion > v1.Version && !isFeeExemptGovVote(tx, gas, appVersion) {
 		globalMinGasPrice := appconsts.GlobalMinGasPrice(appVersion)
 
 		// convert the global minimum gas price to a big.Int
@@ -49,6 +53,14 @@ func CheckTxFeeWithGlobalMinGasPrices(ctx sdk.Context, tx sdk.Tx) (sdk.Coins, in
 	return feeTx.GetFee(), priority, nil
 }
 
+// isFeeExemptGovVote returns true if the tx only contains governance votes and
+// its gas limit is small enough for it to be exempt from the global minimum
+// gas price. The gas limit bounds the resources a free tx can consume.
+func isFeeExemptGovVote(tx sdk.Tx, gas uint64, appVersion uint64) bool {
+	maxGas := appconsts.GovVoteMaxGas(appVersion)
+	return maxGas > 0 && gas <= maxGas && IsGovVoteTx(tx)
+}
+
 // getTxPriority returns a naive tx priority based on the amount of the smallest denomination of the gas price
 // provided in a transaction.
 // NOTE: This implementation should not be used for txs with multiple coins.
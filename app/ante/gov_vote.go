@@ -0,0 +1,127 @@
+package ante
+
+import (
+	"sync"
+
+	"cosmossdk.io/errors"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	govv1beta1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1beta1"
+)
+
+const (
+	// DefaultGovVoteRateLimit is the default number of governance vote txs a
+	// single voter can submit to the mempool within a rate limit window.
+	DefaultGovVoteRateLimit = 5
+	// DefaultGovVoteRateLimitWindow is the default number of blocks that make
+	// up a rate limit window.
+	DefaultGovVoteRateLimitWindow = 10
+)
+
+// IsGovVoteTx returns true if the tx contains at least one message and all of
+// its messages are governance votes (MsgVote or MsgVoteWeighted).
+func IsGovVoteTx(tx sdk.Tx) bool {
+	_, ok := govVoters(tx)
+	return ok
+}
+
+// govVoters returns the voters of a tx that only contains governance votes.
+// It returns false if the tx contains any other message.
+func govVoters(tx sdk.Tx) ([]string, bool) {
+	msgs := tx.GetMsgs()
+	if len(msgs) == 0 {
+		return nil, false
+	}
+	voters := make([]string, 0, len(msgs))
+	for _, msg := range msgs {
+		switch m := msg.(type) {
+		case *govv1.MsgVote:
+			voters = append(voters, m.Voter)
+		case *govv1.MsgVoteWeighted:
+			voters = append(voters, m.Voter)
+		case *govv1beta1.MsgVote:
+			voters = append(voters, m.Voter)
+		case *govv1beta1.MsgVoteWeighted:
+			voters = append(voters, m.Voter)
+		default:
+			return nil, false
+		}
+	}
+	return voters, true
+}
+
+// GovVoteRateLimitDecorator limits the number of governance vote txs that
+// each voter can submit to the mempool within a window of blocks. Vote txs
+// are exempt from the global min gas price, so without a limit they could be
+// used to spam the mempool for free.
+//
+// The limit is only enforced on new txs in CheckTx. The state is held in memory
+// and is therefore local to the node, which is safe because it never affects
+// the validity of a block. Copies of the decorator share their state but
+// separately constructed decorators don't, so a node must construct a single
+// decorator for its CheckTx ante handler (see Wrap).
+type GovVoteRateLimitDecorator struct {
+	limit  int
+	window int64
+	state  *voteWindow
+}
+
+// voteWindow tracks the number of vote txs per voter in the current window.
+type voteWindow struct {
+	mtx sync.Mutex
+	// start is the height of the first block in the window.
+	start  int64
+	counts map[string]int
+}
+
+func NewGovVoteRateLimitDecorator(limit int, window int64) GovVoteRateLimitDecorator {
+	return GovVoteRateLimitDecorator{
+		limit:  limit,
+		window: window,
+		state:  &voteWindow{counts: make(map[string]int)},
+	}
+}
+
+// Wrap returns an ante handler that enforces the rate limit before calling
+// next. The vote of a tx is only counted if next accepts the tx.
+func (d GovVoteRateLimitDecorator) Wrap(next sdk.AnteHandler) sdk.AnteHandler {
+	return func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, error) {
+		return d.AnteHandle(ctx, tx, simulate, next)
+	}
+}
+
+// AnteHandle implements the AnteHandler interface. It rejects vote txs from
+// voters that have exceeded their limit in the current window.
+func (d GovVoteRateLimitDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (sdk.Context, error) {
+	if !ctx.IsCheckTx() || ctx.IsReCheckTx() || simulate {
+		return next(ctx, tx, simulate)
+	}
+	voters, ok := govVoters(tx)
+	if !ok {
+		return next(ctx, tx, simulate)
+	}
+
+	d.state.mtx.Lock()
+	defer d.state.mtx.Unlock()
+
+	if height := ctx.BlockHeight(); height < d.state.start || height >= d.state.start+d.window {
+		d.state.start = height
+		d.state.counts = make(map[string]int)
+	}
+
+	for _, voter := range voters {
+		if d.state.counts[voter] >= d.limit {
+			return ctx, errors.Wrapf(sdkerrors.ErrInvalidRequest, "voter %s exceeded the limit of %d vote txs per %d blocks", voter, d.limit, d.window)
+		}
+	}
+
+	newCtx, err := next(ctx, tx, simulate)
+	if err != nil {
+		return newCtx, err
+	}
+	for _, voter := range voters {
+		d.state.counts[voter]++
+	}
+	return newCtx, nil
+}
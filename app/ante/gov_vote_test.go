@@ -0,0 +1,120 @@
+package ante_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/celestiaorg/celestia-app/app"
+	"github.com/celestiaorg/celestia-app/app/ante"
+	"github.com/celestiaorg/celestia-app/app/encoding"
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	"github.com/celestiaorg/celestia-app/test/util/testnode"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGovVoteRateLimitDecorator(t *testing.T) {
+	encCfg := encoding.MakeConfig(app.ModuleEncodingRegisters...)
+	voter := testnode.RandomAddress().(sdk.AccAddress)
+	otherVoter := testnode.RandomAddress().(sdk.AccAddress)
+
+	newTx := func(msgs ...sdk.Msg) sdk.Tx {
+		builder := encCfg.TxConfig.NewTxBuilder()
+		require.NoError(t, builder.SetMsgs(msgs...))
+		return builder.GetTx()
+	}
+	voteTx := newTx(govtypes.NewMsgVote(voter, 1, govtypes.OptionYes, ""))
+	otherVoteTx := newTx(govtypes.NewMsgVote(otherVoter, 1, govtypes.OptionNo, ""))
+	sendTx := newTx(banktypes.NewMsgSend(voter, otherVoter, sdk.NewCoins(sdk.NewInt64Coin(appconsts.BondDenom, 10))))
+
+	anteHandler := sdk.ChainAnteDecorators(ante.NewGovVoteRateLimitDecorator(2, 10))
+	checkTxCtx := sdk.Context{}.WithIsCheckTx(true).WithBlockHeight(1)
+
+	// the first two votes of a voter are accepted
+	for i := 0; i < 2; i++ {
+		_, err := anteHandler(checkTxCtx, voteTx, false)
+		require.NoError(t, err)
+	}
+	// the third vote within the window is rejected
+	_, err := anteHandler(checkTxCtx, voteTx, false)
+	require.Error(t, err)
+
+	// other voters and non vote txs are unaffected
+	_, err = anteHandler(checkTxCtx, otherVoteTx, false)
+	require.NoError(t, err)
+	_, err = anteHandler(checkTxCtx, sendTx, false)
+	require.NoError(t, err)
+
+	// the limit is not enforced outside of CheckTx, during recheck or simulation
+	_, err = anteHandler(sdk.Context{}.WithBlockHeight(1), voteTx, false)
+	require.NoError(t, err)
+	_, err = anteHandler(checkTxCtx.WithIsReCheckTx(true), voteTx, false)
+	require.NoError(t, err)
+	_, err = anteHandler(checkTxCtx, voteTx, true)
+	require.NoError(t, err)
+
+	// the limit resets in the next window
+	_, err = anteHandler(checkTxCtx.WithBlockHeight(11), voteTx, false)
+	require.NoError(t, err)
+}
+
+func TestGovVoteRateLimitDecoratorWrap(t *testing.T) {
+	encCfg := encoding.MakeConfig(app.ModuleEncodingRegisters...)
+	voter := testnode.RandomAddress().(sdk.AccAddress)
+	builder := encCfg.TxConfig.NewTxBuilder()
+	require.NoError(t, builder.SetMsgs(govtypes.NewMsgVote(voter, 1, govtypes.OptionYes, "")))
+	voteTx := builder.GetTx()
+
+	limiter := ante.NewGovVoteRateLimitDecorator(1, 10)
+	checkTxCtx := sdk.Context{}.WithIsCheckTx(true).WithBlockHeight(1)
+	rejectErr := errors.New("rejected")
+	rejecting := limiter.Wrap(func(ctx sdk.Context, _ sdk.Tx, _ bool) (sdk.Context, error) {
+		return ctx, rejectErr
+	})
+	accepting := limiter.Wrap(func(ctx sdk.Context, _ sdk.Tx, _ bool) (sdk.Context, error) {
+		return ctx, nil
+	})
+
+	// votes rejected by the wrapped handler are not counted
+	_, err := rejecting(checkTxCtx, voteTx, false)
+	require.ErrorIs(t, err, rejectErr)
+	_, err = accepting(checkTxCtx, voteTx, false)
+	require.NoError(t, err)
+
+	// handlers wrapped by the same decorator share the limit
+	_, err = accepting(checkTxCtx, voteTx, false)
+	require.Error(t, err)
+	_, err = rejecting(checkTxCtx, voteTx, false)
+	require.Error(t, err)
+	require.NotErrorIs(t, err, rejectErr)
+}
+
+func TestIsGovVoteTx(t *testing.T) {
+	encCfg := encoding.MakeConfig(app.ModuleEncodingRegisters...)
+	voter := testnode.RandomAddress().(sdk.AccAddress)
+	msgVote := govtypes.NewMsgVote(voter, 1, govtypes.OptionYes, "")
+	msgVoteWeighted := govtypes.NewMsgVoteWeighted(voter, 1, govtypes.NewNonSplitVoteOption(govtypes.OptionAbstain), "")
+	msgSend := banktypes.NewMsgSend(voter, voter, sdk.NewCoins(sdk.NewInt64Coin(appconsts.BondDenom, 10)))
+
+	testCases := []struct {
+		name string
+		msgs []sdk.Msg
+		want bool
+	}{
+		{"vote", []sdk.Msg{msgVote}, true},
+		{"weighted vote", []sdk.Msg{msgVoteWeighted}, true},
+		{"multiple votes", []sdk.Msg{msgVote, msgVoteWeighted}, true},
+		{"vote and send", []sdk.Msg{msgVote, msgSend}, false},
+		{"no messages", []sdk.Msg{}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			builder := encCfg.TxConfig.NewTxBuilder()
+			require.NoError(t, builder.SetMsgs(tc.msgs...))
+			require.Equal(t, tc.want, ante.IsGovVoteTx(builder.GetTx()))
+		})
+	}
+}
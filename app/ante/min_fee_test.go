@@ -8,9 +8,12 @@ import (
 	"github.com/celestiaorg/celestia-app/app/ante"
 	"github.com/celestiaorg/celestia-app/app/encoding"
 	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	v2 "github.com/celestiaorg/celestia-app/pkg/appconsts/v2"
+	v3 "github.com/celestiaorg/celestia-app/pkg/appconsts/v3"
 	"github.com/celestiaorg/celestia-app/test/util/testnode"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
 	"github.com/stretchr/testify/require"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	version "github.com/tendermint/tendermint/proto/tendermint/version"
@@ -45,21 +48,21 @@ func TestCheckTxFeeWithGlobalMinGasPrices(t *testing.T) {
 			name:       "bad tx; fee below required minimum",
 			fee:        sdk.NewCoins(sdk.NewInt64Coin(appconsts.BondDenom, feeAmount-1)),
 			gasLimit:   uint64(float64(feeAmount) / globalMinGasPrice),
-			appVersion: uint64(2),
+			appVersion: v2.Version,
 			expErr:     true,
 		},
 		{
 			name:       "good tx; fee equal to required minimum",
 			fee:        sdk.NewCoins(sdk.NewInt64Coin(appconsts.BondDenom, feeAmount)),
 			gasLimit:   uint64(float64(feeAmount) / globalMinGasPrice),
-			appVersion: uint64(2),
+			appVersion: v2.Version,
 			expErr:     false,
 		},
 		{
 			name:       "good tx; fee above required minimum",
 			fee:        sdk.NewCoins(sdk.NewInt64Coin(appconsts.BondDenom, feeAmount+1)),
 			gasLimit:   uint64(float64(feeAmount) / globalMinGasPrice),
-			appVersion: uint64(2),
+			appVersion: v2.Version,
 			expErr:     false,
 		},
 		{
@@ -73,21 +76,21 @@ func TestCheckTxFeeWithGlobalMinGasPrices(t *testing.T) {
 			name:       "good tx; gas limit and fee are maximum values",
 			fee:        sdk.NewCoins(sdk.NewInt64Coin(appconsts.BondDenom, math.MaxInt64)),
 			gasLimit:   math.MaxUint64,
-			appVersion: uint64(2),
+			appVersion: v2.Version,
 			expErr:     false,
 		},
 		{
 			name:       "bad tx; gas limit and fee are 0",
 			fee:        sdk.NewCoins(sdk.NewInt64Coin(appconsts.BondDenom, 0)),
 			gasLimit:   0,
-			appVersion: uint64(2),
+			appVersion: v2.Version,
 			expErr:     false,
 		},
 		{
 			name:       "good tx; minFee = 0.8, rounds up to 1",
 			fee:        sdk.NewCoins(sdk.NewInt64Coin(appconsts.BondDenom, feeAmount)),
 			gasLimit:   400,
-			appVersion: uint64(2),
+			appVersion: v2.Version,
 			expErr:     false,
 		},
 	}
@@ -112,3 +115,69 @@ func TestCheckTxFeeWithGlobalMinGasPrices(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckTxFeeWithGlobalMinGasPricesGovVote(t *testing.T) {
+	encCfg := encoding.MakeConfig(app.ModuleEncodingRegisters...)
+	voter := testnode.RandomAddress().(sdk.AccAddress)
+	msgVote := govtypes.NewMsgVote(voter, 1, govtypes.OptionYes, "")
+	msgSend := banktypes.NewMsgSend(voter, testnode.RandomAddress().(sdk.AccAddress), sdk.NewCoins(sdk.NewInt64Coin(appconsts.BondDenom, 10)))
+
+	maxGas := appconsts.GovVoteMaxGas(v3.Version)
+
+	testCases := []struct {
+		name       string
+		msgs       []sdk.Msg
+		gasLimit   uint64
+		appVersion uint64
+		expErr     bool
+	}{
+		{
+			name:       "good tx; vote without fee",
+			msgs:       []sdk.Msg{msgVote},
+			gasLimit:   maxGas,
+			appVersion: v3.Version,
+			expErr:     false,
+		},
+		{
+			name:       "bad tx; vote without fee exceeding the max gas",
+			msgs:       []sdk.Msg{msgVote},
+			gasLimit:   maxGas + 1,
+			appVersion: v3.Version,
+			expErr:     true,
+		},
+		{
+			name:       "bad tx; vote and send without fee",
+			msgs:       []sdk.Msg{msgVote, msgSend},
+			gasLimit:   maxGas,
+			appVersion: v3.Version,
+			expErr:     true,
+		},
+		{
+			name:       "bad tx; vote without fee before v3",
+			msgs:       []sdk.Msg{msgVote},
+			gasLimit:   maxGas,
+			appVersion: v2.Version,
+			expErr:     true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			builder := encCfg.TxConfig.NewTxBuilder()
+			require.NoError(t, builder.SetMsgs(tc.msgs...))
+			builder.SetGasLimit(tc.gasLimit)
+
+			ctx := sdk.Context{}.WithBlockHeader(tmproto.Header{
+				Version: version.Consensus{
+					App: tc.appVersion,
+				},
+			})
+			_, _, err := ante.CheckTxFeeWithGlobalMinGasPrices(ctx, builder.GetTx())
+			if tc.expErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package ante
+
+import (
+	"fmt"
+
+	blob "github.com/celestiaorg/celestia-app/x/blob/keeper"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth/ante"
+	"github.com/cosmos/cosmos-sdk/x/auth/signing"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	ibckeeper "github.com/cosmos/ibc-go/v6/modules/core/keeper"
+)
+
+// TraceStep records the state of the ante handler after a single decorator
+// has been applied to a transaction.
+type TraceStep struct {
+	// Decorator is the type name of the decorator.
+	Decorator string `json:"decorator"`
+	// GasConsumed is the gas consumed by the context's gas meter once the
+	// decorator has been applied.
+	GasConsumed uint64 `json:"gas_consumed"`
+	// Priority is the priority of the context once the decorator has been
+	// applied.
+	Priority int64 `json:"priority"`
+	// Error is set if the decorator rejected the transaction.
+	Error string `json:"error,omitempty"`
+}
+
+// Trace records every decorator that was applied to a transaction by a
+// tracing ante handler. If the transaction was rejected, the last step is the
+// decorator that rejected it.
+type Trace struct {
+	Steps []TraceStep `json:"steps"`
+}
+
+// RejectedBy returns the step of the decorator that rejected the transaction
+// and false if the transaction was accepted by all decorators.
+func (t *Trace) RejectedBy() (TraceStep, bool) {
+	if len(t.Steps) == 0 {
+		return TraceStep{}, false
+	}
+	last := t.Steps[len(t.Steps)-1]
+	return last, last.Error != ""
+}
+
+// NewTracingAnteHandler returns the same ante handler as NewAnteHandler but
+// records the result of each decorator in the provided trace. It is intended
+// for debugging rejected transactions and should not be used by the state
+// machine.
+func NewTracingAnteHandler(
+	trace *Trace,
+	accountKeeper ante.AccountKeeper,
+	bankKeeper authtypes.BankKeeper,
+	blobKeeper blob.Keeper,
+	feegrantKeeper ante.FeegrantKeeper,
+	signModeHandler signing.SignModeHandler,
+	sigGasConsumer ante.SignatureVerificationGasConsumer,
+	channelKeeper *ibckeeper.Keeper,
+) sdk.AnteHandler {
+	return TraceAnteDecorators(trace, newAnteDecorators(
+		accountKeeper,
+		bankKeeper,
+		blobKeeper,
+		feegrantKeeper,
+		signModeHandler,
+		sigGasConsumer,
+		channelKeeper,
+	)...)
+}
+
+// TraceAnteDecorators chains the decorators together like
+// sdk.ChainAnteDecorators, recording the result of each decorator in the
+// provided trace.
+func TraceAnteDecorators(trace *Trace, decorators ...sdk.AnteDecorator) sdk.AnteHandler {
+	traced := make([]sdk.AnteDecorator, len(decorators))
+	for i, decorator := range decorators {
+		traced[i] = tracingDecorator{
+			name:  fmt.Sprintf("%T", decorator),
+			inner: decorator,
+			trace: trace,
+		}
+	}
+	return sdk.ChainAnteDecorators(traced...)
+}
+
+// tracingDecorator wraps an ante decorator and records whether it passed the
+// transaction on to the next decorator in the chain.
+type tracingDecorator struct {
+	name  string
+	inner sdk.AnteDecorator
+	trace *Trace
+}
+
+func (d tracingDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (sdk.Context, error) {
+	passed := false
+	defer func() {
+		// record the decorator that panicked before propagating the panic to
+		// the decorators that may recover from it (e.g. out of gas panics)
+		if r := recover(); r != nil {
+			if !passed {
+				d.trace.Steps = append(d.trace.Steps, newTraceStep(d.name, ctx, fmt.Errorf("panic: %v", r)))
+			}
+			panic(r)
+		}
+	}()
+	newCtx, err := d.inner.AnteHandle(ctx, tx, simulate, func(nextCtx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, error) {
+		passed = true
+		d.trace.Steps = append(d.trace.Steps, newTraceStep(d.name, nextCtx, nil))
+		return next(nextCtx, tx, simulate)
+	})
+	// only the decorator that returned the error without calling the rest of
+	// the chain is recorded as having rejected the transaction
+	if err != nil && !passed {
+		d.trace.Steps = append(d.trace.Steps, newTraceStep(d.name, ctx, err))
+	}
+	return newCtx, err
+}
+
+func newTraceStep(name string, ctx sdk.Context, err error) TraceStep {
+	step := TraceStep{
+		Decorator: name,
+		Priority:  ctx.Priority(),
+	}
+	if ctx.GasMeter() != nil {
+		step.GasConsumed = ctx.GasMeter().GasConsumed()
+	}
+	if err != nil {
+		step.Error = err.Error()
+	}
+	return step
+}
@@ -0,0 +1,102 @@
+package ante_test
+
+import (
+	"testing"
+
+	"github.com/celestiaorg/celestia-app/app"
+	"github.com/celestiaorg/celestia-app/app/ante"
+	"github.com/celestiaorg/celestia-app/app/encoding"
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	"github.com/celestiaorg/celestia-app/test/util/testfactory"
+	"github.com/celestiaorg/celestia-app/test/util/testnode"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTraceAnteDecorators(t *testing.T) {
+	encCfg := encoding.MakeConfig(app.ModuleEncodingRegisters...)
+	accounts := testfactory.GenerateAccounts(1)
+	coins := sdk.NewCoins(sdk.NewCoin(appconsts.BondDenom, sdk.NewInt(10)))
+
+	msgSend := banktypes.NewMsgSend(
+		testnode.RandomAddress().(sdk.AccAddress),
+		testnode.RandomAddress().(sdk.AccAddress),
+		coins,
+	)
+	msgEmptyProposal, err := govtypes.NewMsgSubmitProposal([]sdk.Msg{}, coins, accounts[0], "do nothing")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		msgs          []sdk.Msg
+		expSteps      int
+		expRejectedBy string
+	}{
+		{
+			name:     "accepted tx records every decorator",
+			msgs:     []sdk.Msg{msgSend},
+			expSteps: 3,
+		},
+		{
+			name:          "rejected tx records the rejecting decorator last",
+			msgs:          []sdk.Msg{msgEmptyProposal},
+			expSteps:      2,
+			expRejectedBy: "ante.GovProposalDecorator",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			trace := &ante.Trace{}
+			anteHandler := ante.TraceAnteDecorators(
+				trace,
+				mockPriorityDecorator{priority: 10},
+				ante.NewGovProposalDecorator(),
+				mockPriorityDecorator{priority: 20},
+			)
+			builder := encCfg.TxConfig.NewTxBuilder()
+			require.NoError(t, builder.SetMsgs(tc.msgs...))
+
+			_, err := anteHandler(sdk.Context{}, builder.GetTx(), false)
+			require.Len(t, trace.Steps, tc.expSteps)
+			require.Equal(t, int64(10), trace.Steps[0].Priority)
+
+			step, rejected := trace.RejectedBy()
+			if tc.expRejectedBy == "" {
+				require.NoError(t, err)
+				require.False(t, rejected)
+				require.Equal(t, int64(20), step.Priority)
+				return
+			}
+			require.Error(t, err)
+			require.True(t, rejected)
+			require.Equal(t, tc.expRejectedBy, step.Decorator)
+			require.Equal(t, err.Error(), step.Error)
+		})
+	}
+}
+
+func TestTraceAnteDecoratorsPanic(t *testing.T) {
+	trace := &ante.Trace{}
+	anteHandler := ante.TraceAnteDecorators(trace, mockPriorityDecorator{priority: 1}, mockPanicDecorator{})
+	encCfg := encoding.MakeConfig(app.ModuleEncodingRegisters...)
+	tx := encCfg.TxConfig.NewTxBuilder().GetTx()
+
+	require.Panics(t, func() {
+		_, _ = anteHandler(sdk.Context{}, tx, false)
+	})
+	step, rejected := trace.RejectedBy()
+	require.True(t, rejected)
+	require.Equal(t, "ante_test.mockPanicDecorator", step.Decorator)
+	require.Equal(t, "panic: mock panic", step.Error)
+}
+
+type mockPriorityDecorator struct {
+	priority int64
+}
+
+func (d mockPriorityDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (sdk.Context, error) {
+	return next(ctx.WithPriority(d.priority), tx, simulate)
+}
@@ -1,8 +1,10 @@
 package app
 
 import (
+	"context"
 	"io"
 
+	"github.com/celestiaorg/celestia-app/app/grpc/tx"
 	"github.com/celestiaorg/celestia-app/app/posthandler"
 	"github.com/celestiaorg/celestia-app/x/mint"
 	mintkeeper "github.com/celestiaorg/celestia-app/x/mint/keeper"
@@ -155,6 +157,18 @@ type App struct {
 	txConfig          client.TxConfig
 
 	invCheckPeriod uint
+	// govVoteLaneBytes is the number of bytes in a proposed block that are
+	// reserved for governance vote txs.
+	govVoteLaneBytes int
+	// txReplacements tracks the txs in the mempool so that they can be
+	// replaced by txs with a higher fee.
+	txReplacements *txReplacements
+	// squareSizeIndex is the database of the index of the square size upper
+	// bound of each height. See SetSquareSizeIndexDB.
+	squareSizeIndex dbm.DB
+	// pendingSquareSize is the square size upper bound of the block being
+	// executed, which is indexed when the block is committed.
+	pendingSquareSize *squareSizeIndexEntry
 
 	// keys to access the substores
 	keys    map[string]*storetypes.KVStoreKey
@@ -236,6 +250,9 @@ func New(
 		interfaceRegistry: interfaceRegistry,
 		txConfig:          encodingConfig.TxConfig,
 		invCheckPeriod:    invCheckPeriod,
+		govVoteLaneBytes:  cast.ToInt(appOpts.Get(FlagGovVoteLaneBytes)),
+		txReplacements:    newTxReplacements(),
+		squareSizeIndex:   dbm.NewMemDB(),
 		keys:              keys,
 		tkeys:             tkeys,
 		memKeys:           memKeys,
@@ -470,8 +487,15 @@ func New(
 		upgradetypes.ModuleName,
 	)
 
-	app.QueryRouter().AddRoute(proof.TxInclusionQueryPath, proof.QueryTxInclusionProof)
-	app.QueryRouter().AddRoute(proof.ShareInclusionQueryPath, proof.QueryShareInclusionProof)
+	// the proof queries reconstruct the data squares with the square size
+	// upper bound of the height of the block
+	proofQuerier := proof.NewQuerier(app.SquareSizeUpperBoundAt)
+	app.QueryRouter().AddRoute(proof.TxInclusionQueryPath, proofQuerier.QueryTxInclusionProof)
+	app.QueryRouter().AddRoute(proof.ShareInclusionQueryPath, proofQuerier.QueryShareInclusionProof)
+	app.QueryRouter().AddRoute(proof.ShareRangeQueryPath, proofQuerier.QueryShareRangeProof)
+	app.QueryRouter().AddRoute(proof.NamespaceAbsenceQueryPath, proofQuerier.QueryNamespaceAbsenceProof)
+	app.QueryRouter().AddRoute(proof.CommitmentQueryPath, proofQuerier.QueryCommitmentProof)
+	app.QueryRouter().AddRoute(TraceTxQueryPath, app.QueryTraceTx)
 
 	app.mm.RegisterInvariants(&app.CrisisKeeper)
 	app.mm.RegisterRoutes(app.Router(), app.QueryRouter(), encodingConfig.Amino)
@@ -487,7 +511,11 @@ func New(
 	app.SetInitChainer(app.InitChainer)
 	app.SetBeginBlocker(app.BeginBlocker)
 	app.SetEndBlocker(app.EndBlocker)
-	app.SetAnteHandler(ante.NewAnteHandler(
+	// the governance vote rate limit is held in memory, so a single instance
+	// wraps the ante handler used by CheckTx instead of being part of every
+	// ante handler built from the decorators (e.g. in PrepareProposal).
+	govVoteRateLimiter := ante.NewGovVoteRateLimitDecorator(ante.DefaultGovVoteRateLimit, ante.DefaultGovVoteRateLimitWindow)
+	app.SetAnteHandler(govVoteRateLimiter.Wrap(ante.NewAnteHandler(
 		app.AccountKeeper,
 		app.BankKeeper,
 		app.BlobKeeper,
@@ -495,7 +523,7 @@ func New(
 		encodingConfig.TxConfig.SignModeHandler(),
 		ante.DefaultSigVerificationGasConsumer,
 		app.IBCKeeper,
-	))
+	)))
 	app.SetPostHandler(posthandler.New())
 
 	if loadLatest {
@@ -512,9 +540,19 @@ func (app *App) Name() string { return app.BaseApp.Name() }
 
 // BeginBlocker application updates every begin block
 func (app *App) BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock) abci.ResponseBeginBlock {
+	app.indexSquareSizeUpperBound(ctx)
 	return app.mm.BeginBlock(ctx, req)
 }
 
+// Commit commits the state of the block, indexes its square size upper bound
+// and forgets the txs tracked for replacement that were committed.
+func (app *App) Commit() abci.ResponseCommit {
+	res := app.BaseApp.Commit()
+	app.writeSquareSizeIndex()
+	app.forgetCommittedTxs()
+	return res
+}
+
 // EndBlocker application updates every end block
 func (app *App) EndBlocker(ctx sdk.Context, req abci.RequestEndBlock) abci.ResponseEndBlock {
 	res := app.mm.EndBlock(ctx, req)
@@ -639,6 +677,10 @@ func (app *App) RegisterAPIRoutes(apiSvr *api.Server, _ config.APIConfig) {
 	clientCtx := apiSvr.ClientCtx
 	// Register new tx routes from grpc-gateway.
 	authtx.RegisterGRPCGatewayRoutes(clientCtx, apiSvr.GRPCGatewayRouter)
+	// Register the trace tx route from grpc-gateway.
+	if err := tx.RegisterTraceHandlerClient(context.Background(), apiSvr.GRPCGatewayRouter, tx.NewTraceClient(clientCtx)); err != nil {
+		panic(err)
+	}
 	// Register new tendermint queries routes from grpc-gateway.
 	tmservice.RegisterGRPCGatewayRoutes(clientCtx, apiSvr.GRPCGatewayRouter)
 
@@ -652,6 +694,7 @@ func (app *App) RegisterAPIRoutes(apiSvr *api.Server, _ config.APIConfig) {
 // RegisterTxService implements the Application.RegisterTxService method.
 func (app *App) RegisterTxService(clientCtx client.Context) {
 	authtx.RegisterTxService(app.BaseApp.GRPCQueryRouter(), clientCtx, app.BaseApp.Simulate, app.interfaceRegistry)
+	tx.RegisterTraceServer(app.BaseApp.GRPCQueryRouter(), traceServer{app: app})
 }
 
 // RegisterTendermintService implements the Application.RegisterTendermintService method.
@@ -12,7 +12,8 @@ import (
 
 // CheckTx implements the ABCI interface and executes a tx in CheckTx mode. This
 // method wraps the default Baseapp's method so that it can parse and check
-// transactions that contain blobs.
+// transactions that contain blobs and replace transactions in the mempool with
+// ones paying a higher fee.
 func (app *App) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
 	tx := req.Tx
 	// check if the transaction contains blobs
@@ -32,7 +33,7 @@ func (app *App) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
 			return sdkerrors.ResponseCheckTxWithEvents(blobtypes.ErrNoBlobs, 0, 0, []abci.Event{}, false)
 		}
 		// don't do anything special if we have a normal transaction
-		return app.BaseApp.CheckTx(req)
+		return app.checkTxWithReplacement(req, tx, sdkTx)
 	}
 
 	switch req.Type {
@@ -49,6 +50,11 @@ func (app *App) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
 		panic(fmt.Sprintf("unknown RequestCheckTx type: %s", req.Type))
 	}
 
+	sdkTx, err := app.txConfig.TxDecoder()(btx.Tx)
+	if err != nil {
+		return sdkerrors.ResponseCheckTxWithEvents(err, 0, 0, []abci.Event{}, false)
+	}
+
 	req.Tx = btx.Tx
-	return app.BaseApp.CheckTx(req)
+	return app.checkTxWithReplacement(req, tx, sdkTx)
 }
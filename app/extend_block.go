@@ -10,12 +10,21 @@ import (
 )
 
 // ExtendBlock extends the given block data into a data square for a given app
-// version.
+// version. The data square is constructed with the upper bound square size of
+// the app version, so it only matches the one of the block if governance
+// didn't lower the max square size. See ExtendBlockWithSquareSize.
 func ExtendBlock(data coretypes.Data, appVersion uint64) (*rsmt2d.ExtendedDataSquare, error) {
+	return ExtendBlockWithSquareSize(data, appconsts.SquareSizeUpperBound(appVersion), appVersion)
+}
+
+// ExtendBlockWithSquareSize extends the given block data into a data square
+// constructed with the given square size upper bound, which must be the one the
+// block was proposed with.
+func ExtendBlockWithSquareSize(data coretypes.Data, maxSquareSize int, appVersion uint64) (*rsmt2d.ExtendedDataSquare, error) {
 	// Construct the data square from the block's transactions
 	dataSquare, err := square.Construct(
 		data.Txs.ToSliceOfBytes(),
-		appconsts.SquareSizeUpperBound(appVersion),
+		maxSquareSize,
 		appconsts.SubtreeRootThreshold(appVersion),
 	)
 	if err != nil {
@@ -25,6 +34,13 @@ func ExtendBlock(data coretypes.Data, appVersion uint64) (*rsmt2d.ExtendedDataSq
 	return da.ExtendShares(shares.ToBytes(dataSquare))
 }
 
+// ExtendBlockAt extends the given block data of the block at the height into
+// a data square constructed with the square size upper bound the block was
+// proposed with, as indexed by the application.
+func (app *App) ExtendBlockAt(data coretypes.Data, height int64, appVersion uint64) (*rsmt2d.ExtendedDataSquare, error) {
+	return ExtendBlockWithSquareSize(data, app.SquareSizeUpperBoundAt(height, appVersion), appVersion)
+}
+
 // EmptyBlock returns true if the given block data is considered empty by the
 // application at a given version.
 func IsEmptyBlock(data coretypes.Data, _ uint64) bool {
@@ -0,0 +1,88 @@
+package app
+
+import (
+	"github.com/celestiaorg/celestia-app/app/ante"
+	"github.com/celestiaorg/go-square/blob"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// FlagGovVoteLaneBytes is the flag used to configure the number of bytes in
+// each proposed block that are reserved for governance vote txs.
+const FlagGovVoteLaneBytes = "gov-vote-lane-bytes"
+
+// reserveGovVoteLane reserves up to budget bytes of the proposed block for
+// governance vote txs. The square is filled in the order of txs and txs that
+// don't fit are skipped, so the votes in the lane are moved to the front of
+// txs where they are guaranteed to be included before the rest of the block
+// is filled by the other txs in their original order.
+//
+// A vote only enters the lane if none of its signers have an earlier tx that
+// is outside of the lane. This keeps the txs of every signer in sequence
+// order, so moving a vote never makes it or a preceding tx of the same signer
+// invalid.
+//
+// This must be called before the txs are filtered by the ante handler so that
+// the sequences are checked in the order in which the txs are proposed.
+func reserveGovVoteLane(dec sdk.TxDecoder, txs [][]byte, budget int) [][]byte {
+	if budget <= 0 {
+		return txs
+	}
+
+	lane := make([][]byte, 0)
+	others := make([][]byte, 0, len(txs))
+	// blocked contains the signers that have a tx outside of the lane.
+	blocked := make(map[string]bool)
+	for _, tx := range txs {
+		sdkTx, isVote := decodeTx(dec, tx)
+		signers := txSigners(sdkTx)
+		if isVote && len(tx) <= budget && !anyBlocked(blocked, signers) {
+			lane = append(lane, tx)
+			budget -= len(tx)
+			continue
+		}
+		others = append(others, tx)
+		for _, signer := range signers {
+			blocked[signer] = true
+		}
+	}
+
+	return append(lane, others...)
+}
+
+// decodeTx decodes the raw tx, unwrapping blob txs, and returns whether it
+// only contains governance votes. The returned tx is nil if the raw tx can't
+// be decoded.
+func decodeTx(dec sdk.TxDecoder, tx []byte) (sdk.Tx, bool) {
+	btx, isBlob := blob.UnmarshalBlobTx(tx)
+	if isBlob {
+		tx = btx.Tx
+	}
+	sdkTx, err := dec(tx)
+	if err != nil {
+		return nil, false
+	}
+	return sdkTx, !isBlob && ante.IsGovVoteTx(sdkTx)
+}
+
+// txSigners returns the addresses of the signers of all messages in the tx.
+func txSigners(tx sdk.Tx) []string {
+	if tx == nil {
+		return nil
+	}
+	signers := make([]string, 0)
+	for _, msg := range tx.GetMsgs() {
+		for _, signer := range msg.GetSigners() {
+			signers = append(signers, signer.String())
+		}
+	}
+	return signers
+}
+
+func anyBlocked(blocked map[string]bool, signers []string) bool {
+	for _, signer := range signers {
+		if blocked[signer] {
+			return true
+		}
+	}
+	return false
+}
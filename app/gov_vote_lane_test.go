@@ -0,0 +1,79 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/celestiaorg/celestia-app/app/encoding"
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	"github.com/stretchr/testify/require"
+	tmrand "github.com/tendermint/tendermint/libs/rand"
+)
+
+func TestReserveGovVoteLane(t *testing.T) {
+	encCfg := encoding.MakeConfig(ModuleEncodingRegisters...)
+	sender := sdk.AccAddress(tmrand.Bytes(20))
+	voter1 := sdk.AccAddress(tmrand.Bytes(20))
+	voter2 := sdk.AccAddress(tmrand.Bytes(20))
+
+	encodeTx := func(msg sdk.Msg) []byte {
+		builder := encCfg.TxConfig.NewTxBuilder()
+		require.NoError(t, builder.SetMsgs(msg))
+		rawTx, err := encCfg.TxConfig.TxEncoder()(builder.GetTx())
+		require.NoError(t, err)
+		return rawTx
+	}
+	send := encodeTx(banktypes.NewMsgSend(sender, sender, sdk.NewCoins(sdk.NewInt64Coin(appconsts.BondDenom, 10))))
+	vote1 := encodeTx(govtypes.NewMsgVote(voter1, 1, govtypes.OptionYes, ""))
+	vote2 := encodeTx(govtypes.NewMsgVote(voter2, 2, govtypes.OptionNo, ""))
+	senderVote := encodeTx(govtypes.NewMsgVote(sender, 1, govtypes.OptionYes, ""))
+	largeVote1 := encodeTx(govtypes.NewMsgVote(voter1, 2, govtypes.OptionYes, tmrand.Str(200)))
+
+	testCases := []struct {
+		name   string
+		txs    [][]byte
+		budget int
+		want   [][]byte
+	}{
+		{
+			name:   "disabled lane keeps the order",
+			txs:    [][]byte{send, vote1, send, vote2},
+			budget: 0,
+			want:   [][]byte{send, vote1, send, vote2},
+		},
+		{
+			name:   "budget for a single vote",
+			txs:    [][]byte{send, vote1, send, vote2},
+			budget: len(vote1),
+			want:   [][]byte{vote1, send, send, vote2},
+		},
+		{
+			name:   "budget for all votes",
+			txs:    [][]byte{send, vote1, send, vote2},
+			budget: len(vote1) + len(vote2),
+			want:   [][]byte{vote1, vote2, send, send},
+		},
+		{
+			name:   "vote after a tx of the same signer keeps its position",
+			txs:    [][]byte{send, senderVote, vote1},
+			budget: len(senderVote) + len(vote1),
+			want:   [][]byte{vote1, send, senderVote},
+		},
+		{
+			name:   "later votes of a voter outside of the lane keep their position",
+			txs:    [][]byte{largeVote1, vote1, vote2},
+			budget: len(vote1) + len(vote2),
+			want:   [][]byte{vote2, largeVote1, vote1},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := append([][]byte{}, tc.txs...)
+			got := reserveGovVoteLane(encCfg.TxConfig.TxDecoder(), input, tc.budget)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
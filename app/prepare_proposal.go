@@ -6,7 +6,6 @@ import (
 	"github.com/celestiaorg/celestia-app/app/ante"
 	"github.com/celestiaorg/celestia-app/pkg/appconsts"
 	"github.com/celestiaorg/celestia-app/pkg/da"
-	"github.com/celestiaorg/go-square/shares"
 	"github.com/celestiaorg/go-square/square"
 	"github.com/cosmos/cosmos-sdk/telemetry"
 	abci "github.com/tendermint/tendermint/abci/types"
@@ -58,7 +57,10 @@ func (app *App) PrepareProposal(req abci.RequestPrepareProposal) abci.ResponsePr
 	if app.LastBlockHeight() == 0 {
 		txs = make([][]byte, 0)
 	} else {
-		txs = FilterTxs(app.Logger(), sdkCtx, handler, app.txConfig, req.BlockData.Txs)
+		// reserve space for governance votes by moving them to the front
+		// of the list before they are filtered and added to the square.
+		txs = reserveGovVoteLane(app.txConfig.TxDecoder(), req.BlockData.Txs, app.govVoteLaneBytes)
+		txs = FilterTxs(app.Logger(), sdkCtx, handler, app.txConfig, txs)
 	}
 
 	// build the square from the set of valid and prioritised transactions.
@@ -71,22 +73,13 @@ func (app *App) PrepareProposal(req abci.RequestPrepareProposal) abci.ResponsePr
 		panic(err)
 	}
 
-	// erasure the data square which we use to create the data root.
-	// Note: uses the nmt wrapper to construct the tree.
-	// checkout pkg/wrapper/nmt_wrapper.go for more information.
-	eds, err := da.ExtendShares(shares.ToBytes(dataSquare))
-	if err != nil {
-		app.Logger().Error(
-			"failure to erasure the data square while creating a proposal block",
-			"error",
-			err.Error(),
-		)
-		panic(err)
-	}
-
 	// create the new data root by creating the data availability header (merkle
-	// roots of each row and col of the erasure data).
-	dah, err := da.NewDataAvailabilityHeader(eds)
+	// roots of each row and col of the erasure data). The roots are computed
+	// while erasure coding the data square row by row, so only a quarter of
+	// the extended data square is held in memory besides the data square.
+	// Note: the trees are namespaced like the nmt wrapper.
+	// checkout pkg/wrapper/nmt_wrapper.go for more information.
+	dah, err := da.NewDataAvailabilityHeaderFromSquare(dataSquare)
 	if err != nil {
 		app.Logger().Error(
 			"failure to create new data availability header",
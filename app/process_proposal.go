@@ -11,7 +11,6 @@ import (
 	"github.com/celestiaorg/celestia-app/pkg/da"
 	blobtypes "github.com/celestiaorg/celestia-app/x/blob/types"
 	"github.com/celestiaorg/go-square/blob"
-	"github.com/celestiaorg/go-square/shares"
 	"github.com/celestiaorg/go-square/square"
 	"github.com/cosmos/cosmos-sdk/telemetry"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -133,13 +132,7 @@ func (app *App) ProcessProposal(req abci.RequestProcessProposal) (resp abci.Resp
 		return reject()
 	}
 
-	eds, err := da.ExtendShares(shares.ToBytes(dataSquare))
-	if err != nil {
-		logInvalidPropBlockError(app.Logger(), req.Header, "failure to erasure the data square", err)
-		return reject()
-	}
-
-	dah, err := da.NewDataAvailabilityHeader(eds)
+	dah, err := da.NewDataAvailabilityHeaderFromSquare(dataSquare)
 	if err != nil {
 		logInvalidPropBlockError(app.Logger(), req.Header, "failure to create new data availability header", err)
 		return reject()
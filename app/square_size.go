@@ -3,6 +3,7 @@ package app
 import (
 	"github.com/celestiaorg/celestia-app/pkg/appconsts"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	dbm "github.com/tendermint/tm-db"
 )
 
 // GovSquareSizeUpperBound returns the maximum square size that can be used for a block
@@ -22,3 +23,81 @@ func (app *App) GovSquareSizeUpperBound(ctx sdk.Context) int {
 	hardMax := appconsts.SquareSizeUpperBound(app.AppVersion(ctx))
 	return min(gmax, hardMax)
 }
+
+// SquareSizeIndexDBName is the name of the database, in the data directory of
+// the node, of the index of the square size upper bound of each height. The
+// index is not part of the state, so it doesn't affect the app hash, and it is
+// only kept by the nodes that executed the blocks. It is used to reconstruct
+// the data squares of past blocks with the exact square size upper bound that
+// their proposer used.
+const SquareSizeIndexDBName = "squaresizeindex"
+
+func squareSizeIndexKey(height int64) []byte {
+	return sdk.Uint64ToBigEndian(uint64(height))
+}
+
+// squareSizeIndexEntry is the square size upper bound of a height.
+type squareSizeIndexEntry struct {
+	height     int64
+	squareSize int
+}
+
+// SetSquareSizeIndexDB sets the database the square size upper bound of each
+// committed height is indexed in. It defaults to an in-memory database.
+func (app *App) SetSquareSizeIndexDB(db dbm.DB) {
+	app.squareSizeIndex = db
+}
+
+// indexSquareSizeUpperBound records the square size upper bound of the block
+// being executed, which is written to the index when the block is committed.
+// It must be called at the beginning of the block, where the state is the one
+// the block was proposed on.
+func (app *App) indexSquareSizeUpperBound(ctx sdk.Context) {
+	app.pendingSquareSize = &squareSizeIndexEntry{
+		height:     ctx.BlockHeight(),
+		squareSize: app.GovSquareSizeUpperBound(ctx),
+	}
+}
+
+// writeSquareSizeIndex writes the square size upper bound of the committed
+// block to the index.
+func (app *App) writeSquareSizeIndex() {
+	entry := app.pendingSquareSize
+	if entry == nil {
+		return
+	}
+	app.pendingSquareSize = nil
+	value := sdk.Uint64ToBigEndian(uint64(entry.squareSize))
+	if err := app.squareSizeIndex.Set(squareSizeIndexKey(entry.height), value); err != nil {
+		// the index is only used to serve proofs, so failing to write it
+		// must not halt the node
+		app.Logger().Error("failed to index the square size upper bound", "height", entry.height, "err", err)
+	}
+}
+
+// SquareSizeUpperBoundAt returns the square size upper bound that the data
+// square of the block at the height was constructed with. See
+// SquareSizeUpperBoundFromDB.
+func (app *App) SquareSizeUpperBoundAt(height int64, appVersion uint64) int {
+	return SquareSizeUpperBoundFromDB(app.squareSizeIndex, height, appVersion)
+}
+
+// SquareSizeIndexReader reads the index of the square size upper bound. It is
+// implemented by both the tm-db databases of the application and the
+// cometbft-db databases of the node.
+type SquareSizeIndexReader interface {
+	Get(key []byte) ([]byte, error)
+}
+
+// SquareSizeUpperBoundFromDB returns the square size upper bound that the data
+// square of the block at the height was constructed with, as recorded in the
+// index database. It falls back to the upper bound of the app version for
+// the heights that weren't recorded, such as the heights before the node was
+// state synced.
+func SquareSizeUpperBoundFromDB(db SquareSizeIndexReader, height int64, appVersion uint64) int {
+	value, err := db.Get(squareSizeIndexKey(height))
+	if err != nil || len(value) != 8 {
+		return appconsts.SquareSizeUpperBound(appVersion)
+	}
+	return int(sdk.BigEndianToUint64(value))
+}
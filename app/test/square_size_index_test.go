@@ -0,0 +1,62 @@
+package app_test
+
+import (
+	"testing"
+
+	"github.com/celestiaorg/celestia-app/app"
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	testutil "github.com/celestiaorg/celestia-app/test/util"
+	"github.com/celestiaorg/celestia-app/test/util/blobfactory"
+	"github.com/celestiaorg/go-square/blob"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmrand "github.com/tendermint/tendermint/libs/rand"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	tmversion "github.com/tendermint/tendermint/proto/tendermint/version"
+	coretypes "github.com/tendermint/tendermint/types"
+)
+
+// TestSquareSizeUpperBoundIndex checks that the square size upper bound of each
+// committed height is indexed and that unindexed heights fall back to the upper
+// bound of the app version.
+func TestSquareSizeUpperBoundIndex(t *testing.T) {
+	testApp, _ := testutil.SetupTestAppWithGenesisValSet(app.DefaultConsensusParams())
+	appVersion := app.DefaultConsensusParams().Version.AppVersion
+
+	// the test app has executed the genesis height and begun the next one.
+	// Lower the governance max square size in that block so that it applies
+	// from the block after it on.
+	height := testApp.LastBlockHeight() + 1
+	header := tmproto.Header{Height: height}
+	ctx := testApp.NewContext(false, header)
+	params := testApp.BlobKeeper.GetParams(ctx)
+	params.GovMaxSquareSize = 8
+	testApp.BlobKeeper.SetParams(ctx, params)
+	testApp.EndBlock(abci.RequestEndBlock{Height: header.Height})
+	testApp.Commit()
+
+	testApp.BeginBlock(abci.RequestBeginBlock{Header: tmproto.Header{
+		ChainID: testutil.ChainID,
+		Height:  testApp.LastBlockHeight() + 1,
+		Version: tmversion.Consensus{App: appVersion},
+	}})
+	testApp.EndBlock(abci.RequestEndBlock{Height: testApp.LastBlockHeight() + 1})
+	// the height is only indexed once it is committed
+	require.Equal(t, appconsts.SquareSizeUpperBound(appVersion), testApp.SquareSizeUpperBoundAt(height+1, appVersion))
+	testApp.Commit()
+
+	require.Equal(t, int(appconsts.DefaultGovMaxSquareSize), testApp.SquareSizeUpperBoundAt(height, appVersion))
+	require.Equal(t, 8, testApp.SquareSizeUpperBoundAt(height+1, appVersion))
+	require.Equal(t, appconsts.SquareSizeUpperBound(appVersion), testApp.SquareSizeUpperBoundAt(height+2, appVersion))
+
+	// a blob that doesn't fit in the lowered governance square size can't be
+	// in a block proposed with it
+	blobTx, err := blob.MarshalBlobTx(tmrand.Bytes(200), blobfactory.ManyRandBlobs(tmrand.NewRand(), 100_000)...)
+	require.NoError(t, err)
+	data := coretypes.Data{Txs: coretypes.Txs{blobTx}}
+	_, err = testApp.ExtendBlockAt(data, height+1, appVersion)
+	require.Error(t, err)
+	eds, err := testApp.ExtendBlockAt(data, height+2, appVersion)
+	require.NoError(t, err)
+	require.Greater(t, eds.Width(), uint(16))
+}
@@ -0,0 +1,68 @@
+package app_test
+
+import (
+	"testing"
+
+	"github.com/celestiaorg/celestia-app/app"
+	"github.com/celestiaorg/celestia-app/app/encoding"
+	"github.com/celestiaorg/celestia-app/app/grpc/tx"
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	"github.com/celestiaorg/celestia-app/pkg/user"
+	testutil "github.com/celestiaorg/celestia-app/test/util"
+	"github.com/celestiaorg/celestia-app/test/util/testfactory"
+	"github.com/cosmos/cosmos-sdk/client"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+func TestTraceTx(t *testing.T) {
+	encCfg := encoding.MakeConfig(app.ModuleEncodingRegisters...)
+	accs := []string{"a", "b"}
+
+	testApp, kr := testutil.SetupTestAppWithGenesisValSet(app.DefaultConsensusParams(), accs...)
+	testApp.Commit()
+	testApp.RegisterTxService(client.Context{})
+
+	signer := createSigner(t, kr, accs[0], encCfg.TxConfig, 1)
+	recipient := testfactory.GetAddress(kr, accs[1])
+	msg := banktypes.NewMsgSend(signer.Address(), recipient, sdk.NewCoins(sdk.NewInt64Coin(appconsts.BondDenom, 10)))
+
+	traceTx := func(rawTx []byte) *tx.TraceTxResponse {
+		req, err := (&tx.TraceTxRequest{TxBytes: rawTx}).Marshal()
+		require.NoError(t, err)
+		resp := testApp.Query(abci.RequestQuery{Path: "/celestia.core.v1.tx.Trace/TraceTx", Data: req})
+		require.Equal(t, abci.CodeTypeOK, resp.Code, resp.Log)
+		var traceResp tx.TraceTxResponse
+		require.NoError(t, traceResp.Unmarshal(resp.Value))
+		return &traceResp
+	}
+
+	t.Run("accepted tx", func(t *testing.T) {
+		rawTx, err := signer.CreateTx([]sdk.Msg{msg}, user.SetGasLimit(100_000), user.SetFee(2000))
+		require.NoError(t, err)
+
+		resp := traceTx(rawTx)
+		require.Empty(t, resp.Error)
+		require.NotEmpty(t, resp.Steps)
+		for _, step := range resp.Steps {
+			require.Empty(t, step.Error, step.Decorator)
+		}
+		// no state changes are persisted so the same tx can be traced again
+		require.Empty(t, traceTx(rawTx).Error)
+	})
+
+	t.Run("rejected tx", func(t *testing.T) {
+		// the account is still at sequence zero in the committed state
+		signer.ForceSetSequence(1)
+		rawTx, err := signer.CreateTx([]sdk.Msg{msg}, user.SetGasLimit(100_000), user.SetFee(2000))
+		require.NoError(t, err)
+
+		resp := traceTx(rawTx)
+		require.NotEmpty(t, resp.Error)
+		last := resp.Steps[len(resp.Steps)-1]
+		require.Equal(t, "ante.SigVerificationDecorator", last.Decorator)
+		require.Equal(t, resp.Error, last.Error)
+	})
+}
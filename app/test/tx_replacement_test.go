@@ -0,0 +1,131 @@
+package app_test
+
+import (
+	"testing"
+
+	"github.com/celestiaorg/celestia-app/app"
+	"github.com/celestiaorg/celestia-app/app/encoding"
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	"github.com/celestiaorg/celestia-app/pkg/user"
+	testutil "github.com/celestiaorg/celestia-app/test/util"
+	"github.com/celestiaorg/celestia-app/test/util/testfactory"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	tmversion "github.com/tendermint/tendermint/proto/tendermint/version"
+)
+
+func TestCheckTxReplacement(t *testing.T) {
+	encCfg := encoding.MakeConfig(app.ModuleEncodingRegisters...)
+	accs := []string{"a", "b"}
+
+	testApp, kr := testutil.SetupTestAppWithGenesisValSet(app.DefaultConsensusParams(), accs...)
+	testApp.Commit()
+
+	signer := createSigner(t, kr, accs[0], encCfg.TxConfig, 1)
+	recipient := testfactory.GetAddress(kr, accs[1])
+	msg := banktypes.NewMsgSend(signer.Address(), recipient, sdk.NewCoins(sdk.NewInt64Coin(appconsts.BondDenom, 10)))
+
+	// newTx signs a send tx with sequence zero and the provided fee
+	newTx := func(fee uint64, memo string) []byte {
+		signer.ForceSetSequence(0)
+		tx, err := signer.CreateTx([]sdk.Msg{msg}, user.SetGasLimit(100_000), user.SetFee(fee), user.SetMemo(memo))
+		require.NoError(t, err)
+		return tx
+	}
+	checkTx := func(checkType abci.CheckTxType, tx []byte) abci.ResponseCheckTx {
+		return testApp.CheckTx(abci.RequestCheckTx{Type: checkType, Tx: tx})
+	}
+
+	// newTxWithSequence signs a send tx with the provided sequence and fee
+	newTxWithSequence := func(sequence, fee uint64) []byte {
+		signer.ForceSetSequence(sequence)
+		tx, err := signer.CreateTx([]sdk.Msg{msg}, user.SetGasLimit(100_000), user.SetFee(fee))
+		require.NoError(t, err)
+		return tx
+	}
+	balance := func() sdk.Int {
+		ctx := testApp.NewContext(true, tmproto.Header{})
+		return testApp.BankKeeper.GetBalance(ctx, signer.Address(), appconsts.BondDenom).Amount
+	}
+	initialBalance := balance()
+
+	original := newTx(1000, "original")
+	resp := checkTx(abci.CheckTxType_New, original)
+	require.Equal(t, abci.CodeTypeOK, resp.Code, resp.Log)
+
+	// a later tx of the signer is in the mempool when the original is replaced
+	resp = checkTx(abci.CheckTxType_New, newTxWithSequence(1, 1000))
+	require.Equal(t, abci.CodeTypeOK, resp.Code, resp.Log)
+
+	// a tx with the same sequence and the same or a lower fee is rejected
+	resp = checkTx(abci.CheckTxType_New, newTx(1000, "same fee"))
+	require.Equal(t, sdkerrors.ErrInsufficientFee.ABCICode(), resp.Code, resp.Log)
+	resp = checkTx(abci.CheckTxType_New, newTx(500, "lower fee"))
+	require.Equal(t, sdkerrors.ErrInsufficientFee.ABCICode(), resp.Code, resp.Log)
+
+	// a tx with the same sequence and a higher fee replaces the original
+	replacement := newTx(2000, "replacement")
+	resp = checkTx(abci.CheckTxType_New, replacement)
+	require.Equal(t, abci.CodeTypeOK, resp.Code, resp.Log)
+
+	// only the fee of the replacement is charged
+	require.Equal(t, initialBalance.SubRaw(2000+1000), balance())
+
+	// the original is removed from the mempool on recheck
+	resp = checkTx(abci.CheckTxType_Recheck, original)
+	require.Equal(t, app.ErrTxReplaced.ABCICode(), resp.Code, resp.Log)
+
+	// the sequence following the later tx is still accepted
+	resp = checkTx(abci.CheckTxType_New, newTxWithSequence(2, 1000))
+	require.Equal(t, abci.CodeTypeOK, resp.Code, resp.Log)
+}
+
+// TestCheckTxReplacementOfCommittedTx checks that a tx can't replace a tx that
+// was included in a block.
+func TestCheckTxReplacementOfCommittedTx(t *testing.T) {
+	encCfg := encoding.MakeConfig(app.ModuleEncodingRegisters...)
+	accs := []string{"a", "b"}
+
+	testApp, kr := testutil.SetupTestAppWithGenesisValSet(app.DefaultConsensusParams(), accs...)
+	testApp.Commit()
+
+	signer := createSigner(t, kr, accs[0], encCfg.TxConfig, 1)
+	recipient := testfactory.GetAddress(kr, accs[1])
+	msg := banktypes.NewMsgSend(signer.Address(), recipient, sdk.NewCoins(sdk.NewInt64Coin(appconsts.BondDenom, 10)))
+	newTx := func(fee uint64) []byte {
+		signer.ForceSetSequence(0)
+		tx, err := signer.CreateTx([]sdk.Msg{msg}, user.SetGasLimit(100_000), user.SetFee(fee))
+		require.NoError(t, err)
+		return tx
+	}
+	balance := func() sdk.Int {
+		ctx := testApp.NewContext(true, tmproto.Header{})
+		return testApp.BankKeeper.GetBalance(ctx, signer.Address(), appconsts.BondDenom).Amount
+	}
+
+	original := newTx(1000)
+	resp := testApp.CheckTx(abci.RequestCheckTx{Type: abci.CheckTxType_New, Tx: original})
+	require.Equal(t, abci.CodeTypeOK, resp.Code, resp.Log)
+
+	// include the original in a block
+	testApp.BeginBlock(abci.RequestBeginBlock{Header: tmproto.Header{
+		ChainID: testutil.ChainID,
+		Height:  testApp.LastBlockHeight() + 1,
+		Version: tmversion.Consensus{App: appconsts.LatestVersion},
+	}})
+	deliverResp := testApp.DeliverTx(abci.RequestDeliverTx{Tx: original})
+	require.Equal(t, abci.CodeTypeOK, deliverResp.Code, deliverResp.Log)
+	testApp.EndBlock(abci.RequestEndBlock{Height: testApp.LastBlockHeight() + 1})
+	testApp.Commit()
+	committedBalance := balance()
+
+	// the tx with the same sequence and a higher fee can't be included, so it
+	// is rejected and the fee of the original isn't refunded
+	resp = testApp.CheckTx(abci.RequestCheckTx{Type: abci.CheckTxType_New, Tx: newTx(2000)})
+	require.Equal(t, sdkerrors.ErrWrongSequence.ABCICode(), resp.Code, resp.Log)
+	require.Equal(t, committedBalance, balance())
+}
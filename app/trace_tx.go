@@ -0,0 +1,103 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/celestiaorg/celestia-app/app/ante"
+	"github.com/celestiaorg/celestia-app/app/grpc/tx"
+	"github.com/celestiaorg/go-square/blob"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+const (
+	// TraceTxQueryPath is the custom query path used to trace the ante
+	// handler for a transaction.
+	TraceTxQueryPath = "traceTx"
+	// traceTxSimulate is an optional query path argument that runs the ante
+	// handler in simulation mode, skipping signature verification.
+	traceTxSimulate = "simulate"
+)
+
+var _ tx.TraceServer = traceServer{}
+
+// traceServer implements the tx.TraceServer gRPC service.
+type traceServer struct {
+	app *App
+}
+
+// TraceTx implements the tx.TraceServer interface. It is registered on the
+// gRPC query router, so the context carries the latest committed state.
+func (s traceServer) TraceTx(ctx context.Context, req *tx.TraceTxRequest) (*tx.TraceTxResponse, error) {
+	if req == nil {
+		return nil, fmt.Errorf("request cannot be nil")
+	}
+	return s.app.TraceTx(sdk.UnwrapSDKContext(ctx), req.TxBytes, req.Simulate)
+}
+
+// TraceTx runs the raw transaction through a tracing version of the ante
+// handler and returns the result of each ante decorator. It is used to debug
+// why a transaction was rejected. No state changes are persisted. Blob
+// transactions are unwrapped before being traced.
+func (app *App) TraceTx(ctx sdk.Context, rawTx []byte, simulate bool) (*tx.TraceTxResponse, error) {
+	if btx, isBlob := blob.UnmarshalBlobTx(rawTx); isBlob {
+		rawTx = btx.Tx
+	}
+	sdkTx, err := app.txConfig.TxDecoder()(rawTx)
+	if err != nil {
+		return nil, err
+	}
+
+	trace := &ante.Trace{}
+	handler := ante.NewTracingAnteHandler(
+		trace,
+		app.AccountKeeper,
+		app.BankKeeper,
+		app.BlobKeeper,
+		app.FeeGrantKeeper,
+		app.GetTxConfig().SignModeHandler(),
+		ante.DefaultSigVerificationGasConsumer,
+		app.IBCKeeper,
+	)
+
+	cacheCtx, _ := ctx.CacheContext()
+	resp := &tx.TraceTxResponse{}
+	_, err = handler(cacheCtx, sdkTx, simulate)
+	if err != nil {
+		resp.Error = err.Error()
+	}
+	for _, step := range trace.Steps {
+		resp.Steps = append(resp.Steps, &tx.TraceStep{
+			Decorator:   step.Decorator,
+			GasConsumed: step.GasConsumed,
+			Priority:    step.Priority,
+			Error:       step.Error,
+		})
+	}
+	return resp, nil
+}
+
+// QueryTraceTx traces the raw transaction passed in req.Data and returns the
+// JSON encoded tx.TraceTxResponse. It is the custom query equivalent of the
+// Trace/TraceTx gRPC method.
+//
+// example paths:
+// custom/traceTx
+// custom/traceTx/simulate
+func (app *App) QueryTraceTx(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, error) {
+	simulate := false
+	switch {
+	case len(path) == 0:
+	case len(path) == 1 && path[0] == traceTxSimulate:
+		simulate = true
+	default:
+		return nil, fmt.Errorf("unexpected query path arguments: %v", path)
+	}
+
+	resp, err := app.TraceTx(ctx, req.Data, simulate)
+	if err != nil {
+		return nil, err
+	}
+	return app.appCodec.MarshalJSON(resp)
+}
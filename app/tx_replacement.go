@@ -0,0 +1,232 @@
+package app
+
+import (
+	"fmt"
+	"sync"
+
+	"cosmossdk.io/errors"
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	coretypes "github.com/tendermint/tendermint/types"
+)
+
+// txReplacementTTLBlocks is the number of blocks after which a tx accepted
+// into the mempool is no longer considered for replacement. It is larger than
+// the default mempool TTL so that txs are not forgotten while they may still
+// be in the mempool.
+const txReplacementTTLBlocks = 10
+
+// ErrTxReplaced is returned when rechecking a tx that was replaced by a tx from
+// the same signer with the same sequence and a higher fee.
+var ErrTxReplaced = errors.Register(Name, 2, "tx was replaced by a tx with a higher fee")
+
+// txReplacements tracks the txs accepted into the mempool by signer and
+// sequence so that a tx can be replaced by another tx with the same signer and
+// sequence that pays a strictly higher fee. Only single signer txs that pay
+// their own fee are tracked. The state is local to the node and only used in
+// CheckTx.
+type txReplacements struct {
+	mtx sync.Mutex
+	// pending maps a signer and sequence to the tx that was last accepted
+	// for it.
+	pending map[string]pendingTx
+	// replaced contains the txs that have been replaced and must be removed
+	// from the mempool on recheck. The height of a replaced tx is the height
+	// at which it was replaced.
+	replaced map[coretypes.TxKey]pendingTx
+}
+
+type pendingTx struct {
+	key      coretypes.TxKey
+	signer   sdk.AccAddress
+	sequence uint64
+	// fee is the amount of the fee paid in the bond denom and fees are all
+	// the fees paid by the tx.
+	fee    sdk.Int
+	fees   sdk.Coins
+	height int64
+}
+
+func newTxReplacements() *txReplacements {
+	return &txReplacements{
+		pending:  make(map[string]pendingTx),
+		replaced: make(map[coretypes.TxKey]pendingTx),
+	}
+}
+
+// checkTxWithReplacement runs CheckTx for the tx and keeps track of the txs
+// accepted into the mempool. A new tx with the same signer and sequence as a
+// tx already in the mempool is accepted if it pays a strictly higher fee. The
+// sequence of the signer in the check state is temporarily rewound so that
+// the replacement passes the ante handler. Once the replacement is accepted,
+// the sequence is restored so that later txs of the signer remain valid, and
+// the fee of the replaced tx is refunded so that only the replacement is paid
+// for. The replaced tx is rejected on the next recheck. A tx whose sequence
+// was already committed can't replace anything, as the tx it would replace
+// may have been included in a block. rawTx is the tx as it
+// is stored in the mempool and sdkTx the decoded tx sent in req.
+func (app *App) checkTxWithReplacement(req abci.RequestCheckTx, rawTx []byte, sdkTx sdk.Tx) abci.ResponseCheckTx {
+	txKey := coretypes.Tx(rawTx).Key()
+	signer, sequence, fees, ok := replacementInfo(sdkTx)
+	if !ok {
+		return app.BaseApp.CheckTx(req)
+	}
+	r := app.txReplacements
+	height := app.LastBlockHeight()
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.prune(height)
+
+	if req.Type == abci.CheckTxType_Recheck {
+		if _, replaced := r.replaced[txKey]; replaced {
+			return sdkerrors.ResponseCheckTxWithEvents(ErrTxReplaced, 0, 0, []abci.Event{}, false)
+		}
+		return app.BaseApp.CheckTx(req)
+	}
+
+	id := fmt.Sprintf("%s/%d", signer, sequence)
+	fee := fees.AmountOf(appconsts.BondDenom)
+	existing, isReplacement := r.pending[id]
+	if !isReplacement || existing.key == txKey {
+		resp := app.BaseApp.CheckTx(req)
+		if resp.IsOK() {
+			r.pending[id] = pendingTx{key: txKey, signer: signer, sequence: sequence, fee: fee, fees: fees, height: height}
+		}
+		return resp
+	}
+
+	// the check state may be ahead of the committed state, so only the
+	// committed sequence tells whether the tx to replace was included.
+	if committed := app.committedSequence(signer); sequence < committed {
+		err := errors.Wrapf(sdkerrors.ErrWrongSequence, "account sequence %d was already committed, expected %d", sequence, committed)
+		return sdkerrors.ResponseCheckTxWithEvents(err, 0, 0, []abci.Event{}, false)
+	}
+
+	if !fee.GT(existing.fee) {
+		err := errors.Wrapf(sdkerrors.ErrInsufficientFee, "replacement tx must pay a higher fee than %s%s", existing.fee, appconsts.BondDenom)
+		return sdkerrors.ResponseCheckTxWithEvents(err, 0, 0, []abci.Event{}, false)
+	}
+
+	// rewind the signer's sequence in the check state so that the
+	// replacement tx passes the signature verification.
+	ctx := app.NewContext(true, tmproto.Header{})
+	acc := app.AccountKeeper.GetAccount(ctx, signer)
+	if acc == nil {
+		return app.BaseApp.CheckTx(req)
+	}
+	originalSequence := acc.GetSequence()
+	if err := acc.SetSequence(sequence); err != nil {
+		return sdkerrors.ResponseCheckTxWithEvents(err, 0, 0, []abci.Event{}, false)
+	}
+	app.AccountKeeper.SetAccount(ctx, acc)
+
+	resp := app.BaseApp.CheckTx(req)
+	if !resp.IsOK() {
+		// the state changes of a failed CheckTx are discarded so only the
+		// sequence needs to be restored.
+		acc = app.AccountKeeper.GetAccount(ctx, signer)
+		_ = acc.SetSequence(originalSequence)
+		app.AccountKeeper.SetAccount(ctx, acc)
+		return resp
+	}
+
+	// the replacement incremented the sequence past its own, which is behind
+	// the sequence of any later tx of the signer that is in the mempool.
+	acc = app.AccountKeeper.GetAccount(ctx, signer)
+	_ = acc.SetSequence(max(originalSequence, sequence+1))
+	app.AccountKeeper.SetAccount(ctx, acc)
+	// both the replaced tx and its replacement have been charged in the
+	// check state, but only the replacement can be included in a block.
+	if err := app.BankKeeper.SendCoinsFromModuleToAccount(ctx, authtypes.FeeCollectorName, signer, existing.fees); err != nil {
+		app.Logger().Error("refunding the fee of a replaced tx", "signer", signer, "err", err)
+	}
+
+	existing.height = height
+	r.replaced[existing.key] = existing
+	r.pending[id] = pendingTx{key: txKey, signer: signer, sequence: sequence, fee: fee, fees: fees, height: height}
+	return resp
+}
+
+// committedSequence returns the sequence of the account in the last committed
+// state, or zero if the account doesn't exist.
+func (app *App) committedSequence(address sdk.AccAddress) uint64 {
+	ctx := sdk.NewContext(app.CommitMultiStore().CacheMultiStore(), tmproto.Header{}, true, app.Logger())
+	acc := app.AccountKeeper.GetAccount(ctx, address)
+	if acc == nil {
+		return 0
+	}
+	return acc.GetSequence()
+}
+
+// forgetCommittedTxs removes the txs whose sequence was committed, because
+// they were included in a block or can no longer be, so that they aren't
+// considered for replacement. It must be called after the block is committed.
+func (app *App) forgetCommittedTxs() {
+	r := app.txReplacements
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	sequences := make(map[string]uint64)
+	committed := func(tx pendingTx) bool {
+		sequence, ok := sequences[tx.signer.String()]
+		if !ok {
+			sequence = app.committedSequence(tx.signer)
+			sequences[tx.signer.String()] = sequence
+		}
+		return tx.sequence < sequence
+	}
+	for id, tx := range r.pending {
+		if committed(tx) {
+			delete(r.pending, id)
+		}
+	}
+	for key, tx := range r.replaced {
+		if committed(tx) {
+			delete(r.replaced, key)
+		}
+	}
+}
+
+// prune removes txs that were accepted or replaced more than
+// txReplacementTTLBlocks ago.
+func (r *txReplacements) prune(height int64) {
+	for id, tx := range r.pending {
+		if tx.height+txReplacementTTLBlocks < height {
+			delete(r.pending, id)
+		}
+	}
+	for key, tx := range r.replaced {
+		if tx.height+txReplacementTTLBlocks < height {
+			delete(r.replaced, key)
+		}
+	}
+}
+
+// replacementInfo returns the signer, sequence and fees of a single signer tx
+// that pays its own fee. Txs whose fee is paid by a fee granter can't be
+// replaced as the grant can't be restored when the replaced tx is refunded.
+func replacementInfo(sdkTx sdk.Tx) (sdk.AccAddress, uint64, sdk.Coins, bool) {
+	sigTx, ok := sdkTx.(authsigning.SigVerifiableTx)
+	if !ok {
+		return nil, 0, nil, false
+	}
+	feeTx, ok := sdkTx.(sdk.FeeTx)
+	if !ok || feeTx.FeeGranter() != nil {
+		return nil, 0, nil, false
+	}
+	sigs, err := sigTx.GetSignaturesV2()
+	if err != nil || len(sigs) != 1 {
+		return nil, 0, nil, false
+	}
+	signers := sigTx.GetSigners()
+	if len(signers) != 1 || !feeTx.FeePayer().Equals(signers[0]) {
+		return nil, 0, nil, false
+	}
+	return signers[0], sigs[0].Sequence, feeTx.GetFee(), true
+}
@@ -0,0 +1,237 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/celestiaorg/celestia-app/app"
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	"github.com/celestiaorg/celestia-app/pkg/proof"
+	"github.com/celestiaorg/go-square/shares"
+	"github.com/celestiaorg/go-square/square"
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/cosmos/cosmos-sdk/server"
+	"github.com/gogo/protobuf/proto"
+	"github.com/spf13/cobra"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	tmcfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/store"
+	coretypes "github.com/tendermint/tendermint/types"
+)
+
+const (
+	flagProofOutput = "output"
+
+	proofOutputJSON  = "json"
+	proofOutputProto = "proto"
+)
+
+func proofCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "proof",
+		Short: "Create inclusion proofs from the block store of the node",
+		Long: "Create inclusion proofs of transactions, shares and blobs of the blocks in the block store of the node.\n" +
+			"The block store and the square size index are opened with the db_backend of config.toml and the app-db-backend of app.toml, read-only if the backend is goleveldb, so the commands don't need a running node, but the node must be stopped while they run.\n" +
+			"The data squares are constructed with the square size upper bound indexed by the application for the height of the block when it was committed.\n" +
+			"The proofs are printed as JSON or, with --output proto, as the protobuf encoding of the ShareProof message.\n",
+	}
+	cmd.PersistentFlags().String(flagProofOutput, proofOutputJSON, "The output format of the proof: json or proto")
+	cmd.AddCommand(
+		proofTxCommand(),
+		proofSharesCommand(),
+		proofBlobCommand(),
+	)
+	return cmd
+}
+
+func proofTxCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "tx <height> <tx_index>",
+		Short: "Create the proof of the transaction at the index of the block at the height",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			height, txIndex, err := parseProofArgs(args[0], args[1])
+			if err != nil {
+				return err
+			}
+			return runProof(cmd, height, func(block *coretypes.Block, maxSquareSize int) (proof.ShareProof, error) {
+				return txProof(block, maxSquareSize, txIndex[0])
+			})
+		},
+	}
+}
+
+func proofSharesCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "shares <height> <start_share> <end_share>",
+		Short: "Create the proof of a range of shares of a single namespace of the block at the height. The range should be end exclusive.",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			height, shareRange, err := parseProofArgs(args[0], args[1:]...)
+			if err != nil {
+				return err
+			}
+			return runProof(cmd, height, func(block *coretypes.Block, maxSquareSize int) (proof.ShareProof, error) {
+				return sharesProof(block, maxSquareSize, shares.NewRange(int(shareRange[0]), int(shareRange[1])))
+			})
+		},
+	}
+}
+
+func proofBlobCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "blob <height> <tx_index> <blob_index>",
+		Short: "Create the proof of the shares of a blob of the PayForBlobs transaction at the index of the block at the height",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			height, indexes, err := parseProofArgs(args[0], args[1:]...)
+			if err != nil {
+				return err
+			}
+			return runProof(cmd, height, func(block *coretypes.Block, maxSquareSize int) (proof.ShareProof, error) {
+				return blobProof(block, maxSquareSize, indexes[0], indexes[1])
+			})
+		},
+	}
+}
+
+// parseProofArgs parses the height and the indexes of the arguments of a proof
+// command.
+func parseProofArgs(rawHeight string, rawIndexes ...string) (int64, []uint64, error) {
+	height, err := strconv.ParseInt(rawHeight, 10, 64)
+	if err != nil {
+		return 0, nil, fmt.Errorf("invalid height %q: %w", rawHeight, err)
+	}
+	indexes := make([]uint64, len(rawIndexes))
+	for i, raw := range rawIndexes {
+		indexes[i], err = strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			return 0, nil, fmt.Errorf("invalid index %q: %w", raw, err)
+		}
+	}
+	return height, indexes, nil
+}
+
+// runProof creates the proof of the block at the height of the block store of
+// the node and prints it in the output format of the command.
+func runProof(cmd *cobra.Command, height int64, create func(block *coretypes.Block, maxSquareSize int) (proof.ShareProof, error)) error {
+	output, err := cmd.Flags().GetString(flagProofOutput)
+	if err != nil {
+		return err
+	}
+	if output != proofOutputJSON && output != proofOutputProto {
+		return fmt.Errorf("unknown output format %q, must be %s or %s", output, proofOutputJSON, proofOutputProto)
+	}
+
+	serverCtx := server.GetServerContextFromCmd(cmd)
+	cfg := serverCtx.Config
+	db, err := openBlockStoreDB(cfg)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	block, err := loadBlock(store.NewBlockStore(db), height)
+	if err != nil {
+		return err
+	}
+	maxSquareSize, err := squareSizeUpperBound(cfg.DBDir(), dbm.BackendType(server.GetAppDBBackend(serverCtx.Viper)), height, block.Header.Version.App)
+	if err != nil {
+		return err
+	}
+	shareProof, err := create(block, maxSquareSize)
+	if err != nil {
+		return err
+	}
+
+	var bz []byte
+	if output == proofOutputProto {
+		bz, err = proto.Marshal(&shareProof)
+	} else {
+		bz, err = json.MarshalIndent(shareProof, "", "  ")
+		bz = append(bz, '\n')
+	}
+	if err != nil {
+		return err
+	}
+	_, err = cmd.OutOrStdout().Write(bz)
+	return err
+}
+
+// openBlockStoreDB opens the block store database of the node.
+func openBlockStoreDB(cfg *tmcfg.Config) (dbm.DB, error) {
+	return OpenNodeDB("blockstore", dbm.BackendType(cfg.DBBackend), cfg.DBDir())
+}
+
+// squareSizeUpperBound returns the square size upper bound that the data square
+// of the block at the height was constructed with, as indexed in the square
+// size index database of the node in the directory.
+func squareSizeUpperBound(dir string, backend dbm.BackendType, height int64, appVersion uint64) (int, error) {
+	db, err := OpenNodeDB(app.SquareSizeIndexDBName, backend, dir)
+	if err != nil {
+		return 0, err
+	}
+	defer db.Close()
+	return app.SquareSizeUpperBoundFromDB(db, height, appVersion), nil
+}
+
+// OpenNodeDB opens the database of the node in the directory with the backend
+// for reading. goleveldb databases, the default, are opened read-only and must
+// already exist, so they can be read while the node is stopped.
+func OpenNodeDB(name string, backend dbm.BackendType, dir string) (dbm.DB, error) {
+	var (
+		db  dbm.DB
+		err error
+	)
+	if backend == dbm.GoLevelDBBackend {
+		db, err = dbm.NewGoLevelDBWithOpts(name, dir, &opt.Options{ReadOnly: true, ErrorIfMissing: true})
+	} else {
+		db, err = dbm.NewDB(name, backend, dir)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error opening the %s database in %s with the %s backend, make sure the node is stopped: %w", name, dir, backend, err)
+	}
+	return db, nil
+}
+
+// loadBlock loads the block at the height from the block store.
+func loadBlock(blockStore *store.BlockStore, height int64) (*coretypes.Block, error) {
+	block := blockStore.LoadBlock(height)
+	if block == nil {
+		return nil, fmt.Errorf("block %d is not in the block store, which has the blocks %d to %d", height, blockStore.Base(), blockStore.Height())
+	}
+	return block, nil
+}
+
+// txProof creates the proof of the transaction at the index of the block, whose
+// data square was constructed with the square size upper bound.
+func txProof(block *coretypes.Block, maxSquareSize int, txIndex uint64) (proof.ShareProof, error) {
+	return proof.NewTxInclusionProofWithSquareSize(block.Txs.ToSliceOfBytes(), txIndex, maxSquareSize, block.Header.Version.App)
+}
+
+// sharesProof creates the proof of the range of shares of the block, which must
+// all be of the same namespace.
+func sharesProof(block *coretypes.Block, maxSquareSize int, shareRange shares.Range) (proof.ShareProof, error) {
+	appVersion := block.Header.Version.App
+	dataSquare, err := square.Construct(block.Txs.ToSliceOfBytes(), maxSquareSize, appconsts.SubtreeRootThreshold(appVersion))
+	if err != nil {
+		return proof.ShareProof{}, err
+	}
+	namespace, err := proof.ParseNamespace(dataSquare, shareRange.Start, shareRange.End)
+	if err != nil {
+		return proof.ShareProof{}, err
+	}
+	return proof.NewShareInclusionProof(dataSquare, namespace, shareRange)
+}
+
+// blobProof creates the proof of the shares of the blob at the index of the
+// PayForBlobs transaction at the index of the block.
+func blobProof(block *coretypes.Block, maxSquareSize int, txIndex, blobIndex uint64) (proof.ShareProof, error) {
+	appVersion := block.Header.Version.App
+	shareRange, err := square.BlobShareRange(block.Txs.ToSliceOfBytes(), int(txIndex), int(blobIndex), maxSquareSize, appconsts.SubtreeRootThreshold(appVersion))
+	if err != nil {
+		return proof.ShareProof{}, err
+	}
+	return sharesProof(block, maxSquareSize, shareRange)
+}
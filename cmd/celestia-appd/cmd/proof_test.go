@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/celestiaorg/celestia-app/app"
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	"github.com/celestiaorg/celestia-app/pkg/da"
+	"github.com/celestiaorg/celestia-app/test/util/blobfactory"
+	"github.com/celestiaorg/celestia-app/test/util/testfactory"
+	"github.com/celestiaorg/go-square/blob"
+	"github.com/celestiaorg/go-square/shares"
+	dbm "github.com/cometbft/cometbft-db"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	tmcfg "github.com/tendermint/tendermint/config"
+	tmrand "github.com/tendermint/tendermint/libs/rand"
+	"github.com/tendermint/tendermint/store"
+	coretypes "github.com/tendermint/tendermint/types"
+)
+
+func TestProofFromBlockStore(t *testing.T) {
+	cfg := tmcfg.DefaultConfig()
+	cfg.SetRoot(t.TempDir())
+
+	// the square is constructed without decoding the PayForBlobs tx, so the
+	// blob tx doesn't need to be signed
+	txs := testfactory.GenerateRandomTxs(10, 200)
+	blobTx, err := blob.MarshalBlobTx(tmrand.Bytes(200), blobfactory.ManyRandBlobs(tmrand.NewRand(), 2000)...)
+	require.NoError(t, err)
+	txs = append(txs, blobTx)
+
+	block := coretypes.MakeBlock(1, coretypes.Data{Txs: txs}, &coretypes.Commit{}, nil)
+	block.Header.Version.App = appconsts.LatestVersion
+	block.Header.ProposerAddress = tmrand.Bytes(20)
+	eds, err := app.ExtendBlock(block.Data, appconsts.LatestVersion)
+	require.NoError(t, err)
+	dah, err := da.NewDataAvailabilityHeader(eds)
+	require.NoError(t, err)
+	dataRoot := dah.Hash()
+
+	// write the block to the block store of the node
+	db, err := dbm.NewGoLevelDB("blockstore", cfg.DBDir())
+	require.NoError(t, err)
+	parts := block.MakePartSet(coretypes.BlockPartSizeBytes)
+	store.NewBlockStore(db).SaveBlock(block, parts, &coretypes.Commit{Height: 1})
+	require.NoError(t, db.Close())
+
+	readOnlyDB, err := openBlockStoreDB(cfg)
+	require.NoError(t, err)
+	defer readOnlyDB.Close()
+	blockStore := store.NewBlockStore(readOnlyDB)
+	loaded, err := loadBlock(blockStore, 1)
+	require.NoError(t, err)
+	_, err = loadBlock(blockStore, 2)
+	require.Error(t, err)
+	require.Error(t, readOnlyDB.Set([]byte("key"), []byte("value")))
+
+	// the square size index holds the square size upper bound of the height
+	indexDB, err := dbm.NewGoLevelDB(app.SquareSizeIndexDBName, cfg.DBDir())
+	require.NoError(t, err)
+	require.NoError(t, indexDB.Set(sdk.Uint64ToBigEndian(1), sdk.Uint64ToBigEndian(16)))
+	require.NoError(t, indexDB.Close())
+	maxSquareSize, err := squareSizeUpperBound(cfg.DBDir(), dbm.GoLevelDBBackend, 1, appconsts.LatestVersion)
+	require.NoError(t, err)
+	require.Equal(t, 16, maxSquareSize)
+	maxSquareSize, err = squareSizeUpperBound(cfg.DBDir(), dbm.GoLevelDBBackend, 2, appconsts.LatestVersion)
+	require.NoError(t, err)
+	require.Equal(t, appconsts.SquareSizeUpperBound(appconsts.LatestVersion), maxSquareSize)
+
+	txShareProof, err := txProof(loaded, 16, 0)
+	require.NoError(t, err)
+	require.NoError(t, txShareProof.Validate(dataRoot))
+
+	blobShareProof, err := blobProof(loaded, 16, uint64(len(txs)-1), 0)
+	require.NoError(t, err)
+	require.NoError(t, blobShareProof.Validate(dataRoot))
+
+	rangeProof, err := sharesProof(loaded, 16, shares.NewRange(0, 1))
+	require.NoError(t, err)
+	require.NoError(t, rangeProof.Validate(dataRoot))
+
+	_, err = blobProof(loaded, 16, 0, 0)
+	require.Error(t, err)
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/celestiaorg/celestia-app/app"
 	"github.com/celestiaorg/celestia-app/app/encoding"
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
 	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/cosmos/cosmos-sdk/simapp/simd/cmd"
 	"github.com/cosmos/cosmos-sdk/x/crisis"
@@ -140,6 +141,7 @@ func initRootCmd(rootCmd *cobra.Command, encodingConfig encoding.Config) {
 		commands.CompactGoLevelDBCmd,
 		addrbookCommand(),
 		downloadGenesisCommand(),
+		proofCommand(),
 	)
 
 	server.AddCommands(rootCmd, app.DefaultNodeHome, NewAppServer, createAppAndExport, addModuleInitFlags)
@@ -151,6 +153,7 @@ func initRootCmd(rootCmd *cobra.Command, encodingConfig encoding.Config) {
 		txCommand(),
 		keys.Commands(app.DefaultNodeHome),
 		bscmd.VerifyCmd(),
+		bscmd.CalldataCmd(),
 	)
 	rootCmd.AddCommand(
 		snapshot.Cmd(NewAppServer),
@@ -160,6 +163,7 @@ func initRootCmd(rootCmd *cobra.Command, encodingConfig encoding.Config) {
 func addModuleInitFlags(startCmd *cobra.Command) {
 	crisis.AddModuleInitFlags(startCmd)
 	startCmd.Flags().Int64(UpgradeHeightFlag, 0, "Upgrade height to switch from v1 to v2. Must be coordinated amongst all validators")
+	startCmd.Flags().Int(app.FlagGovVoteLaneBytes, appconsts.DefaultGovVoteLaneBytes, "Number of bytes in each proposed block reserved for governance vote txs")
 }
 
 func queryCommand() *cobra.Command {
@@ -236,7 +240,12 @@ func NewAppServer(logger log.Logger, db dbm.DB, traceStore io.Writer, appOpts se
 		panic(err)
 	}
 
-	return app.New(
+	squareSizeIndexDB, err := dbm.NewDB(app.SquareSizeIndexDBName, server.GetAppDBBackend(appOpts), filepath.Join(cast.ToString(appOpts.Get(flags.FlagHome)), "data"))
+	if err != nil {
+		panic(err)
+	}
+
+	capp := app.New(
 		logger, db, traceStore, true,
 		cast.ToUint(appOpts.Get(server.FlagInvCheckPeriod)),
 		encoding.MakeConfig(app.ModuleEncodingRegisters...), // Ideally, we would reuse the one created by NewRootCmd.
@@ -253,6 +262,8 @@ func NewAppServer(logger log.Logger, db dbm.DB, traceStore io.Writer, appOpts se
 		baseapp.SetIndexEvents(cast.ToStringSlice(appOpts.Get(server.FlagIndexEvents))),
 		baseapp.SetSnapshot(snapshotStore, snapshottypes.NewSnapshotOptions(cast.ToUint64(appOpts.Get(server.FlagStateSyncSnapshotInterval)), cast.ToUint32(appOpts.Get(server.FlagStateSyncSnapshotKeepRecent)))),
 	)
+	capp.SetSquareSizeIndexDB(squareSizeIndexDB)
+	return capp
 }
 
 func createAppAndExport(
@@ -22,6 +22,11 @@ const (
 	// a nodes `CheckTx` and thus not be proposed by that node.
 	DefaultMinGasPrice = 0.002
 
+	// DefaultGovVoteLaneBytes is the default number of bytes in each proposed
+	// block that are reserved for governance vote txs. It is set in the nodes
+	// local config.
+	DefaultGovVoteLaneBytes = 32 * ContinuationCompactShareContentSize
+
 	// DefaultUnbondingTime is the default time a validator must wait
 	// to unbond in a proof of stake system. Any validator within this
 	// time can be subject to slashing under conditions of misbehavior.
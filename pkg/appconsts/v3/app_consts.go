@@ -0,0 +1,11 @@
+// Package v3 holds the constants introduced by the v3 state machine, which
+// this binary doesn't support yet. It only holds the constants that change
+// from v2; the others keep their v2 values.
+package v3
+
+const (
+	Version uint64 = 3
+	// GovVoteMaxGas is the maximum gas limit of a tx that only contains
+	// governance votes for it to be exempt from the global min gas price.
+	GovVoteMaxGas uint64 = 200_000
+)
@@ -4,6 +4,7 @@ import (
 	"github.com/celestiaorg/celestia-app/pkg/appconsts/testground"
 	v1 "github.com/celestiaorg/celestia-app/pkg/appconsts/v1"
 	v2 "github.com/celestiaorg/celestia-app/pkg/appconsts/v2"
+	v3 "github.com/celestiaorg/celestia-app/pkg/appconsts/v3"
 )
 
 const (
@@ -28,6 +29,20 @@ func GlobalMinGasPrice(_ uint64) float64 {
 	return v2.GlobalMinGasPrice
 }
 
+// GovVoteMaxGas is the maximum gas limit of a tx that only contains
+// governance votes for it to be exempt from the global min gas price. The
+// exemption changes which txs are valid in a block, so it is part of the v3
+// state machine and takes effect once the chain upgrades to v3, which this
+// binary doesn't support yet. Zero means that no tx is exempt.
+func GovVoteMaxGas(v uint64) uint64 {
+	switch v {
+	case v1.Version, v2.Version, testground.Version:
+		return 0
+	default:
+		return v3.GovVoteMaxGas
+	}
+}
+
 // SquareSizeUpperBound is the maximum original square width possible
 // for a version of the state machine. The maximum is decided through
 // governance. See `DefaultGovMaxSquareSize`.
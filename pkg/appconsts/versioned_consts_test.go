@@ -10,6 +10,7 @@ import (
 	"github.com/celestiaorg/celestia-app/pkg/appconsts/testground"
 	v1 "github.com/celestiaorg/celestia-app/pkg/appconsts/v1"
 	v2 "github.com/celestiaorg/celestia-app/pkg/appconsts/v2"
+	v3 "github.com/celestiaorg/celestia-app/pkg/appconsts/v3"
 )
 
 func TestSubtreeRootThreshold(t *testing.T) {
@@ -95,3 +96,35 @@ func TestSquareSizeUpperBound(t *testing.T) {
 		})
 	}
 }
+
+func TestGovVoteMaxGas(t *testing.T) {
+	testCases := []struct {
+		version  uint64
+		expected uint64
+	}{
+		{
+			version:  v1.Version,
+			expected: 0,
+		},
+		{
+			version:  v2.Version,
+			expected: 0,
+		},
+		{
+			version:  v3.Version,
+			expected: v3.GovVoteMaxGas,
+		},
+		{
+			version:  testground.Version,
+			expected: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		name := fmt.Sprintf("version %v", tc.version)
+		t.Run(name, func(t *testing.T) {
+			got := appconsts.GovVoteMaxGas(tc.version)
+			require.Equal(t, tc.expected, got)
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package da
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	"github.com/celestiaorg/celestia-app/pkg/wrapper"
+	"github.com/celestiaorg/rsmt2d"
+)
+
+// ErrBadEncoding is returned by Repair when a row or column of the extended data
+// square isn't the Reed-Solomon encoding of its first half. It contains
+// everything needed to generate a bad encoding fraud proof of the axis.
+type ErrBadEncoding struct {
+	// Axis is the kind of axis, row or column, that is badly encoded.
+	Axis rsmt2d.Axis
+	// Index is the index of the badly encoded row or column.
+	Index uint
+	// Shares are the shares of the axis that can be proven to the roots of
+	// the orthogonal axes. Missing shares are empty.
+	Shares [][]byte
+	// EDS is the square as far as it was repaired. The shares of the axis
+	// are proven with the orthogonal axes in it.
+	EDS *rsmt2d.ExtendedDataSquare
+}
+
+func (e *ErrBadEncoding) Error() string {
+	return fmt.Sprintf("bad encoding of %s %d", e.Axis, e.Index)
+}
+
+// Repair reconstructs the extended data square of the data availability header
+// from some of its shares. The partial shares are the shares of the extended
+// data square in row-major order with nil for the missing ones. It returns an
+// *ErrBadEncoding if a row or column isn't correctly erasure coded and
+// rsmt2d.ErrUnrepairableDataSquare if there are too few shares to reconstruct
+// the square.
+func Repair(dah DataAvailabilityHeader, partialShares [][]byte) (*rsmt2d.ExtendedDataSquare, error) {
+	if err := dah.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	width := len(dah.RowRoots)
+	if len(partialShares) != width*width {
+		return nil, fmt.Errorf("the number of shares %d must equal the %d shares of the extended data square", len(partialShares), width*width)
+	}
+	present := 0
+	for i, share := range partialShares {
+		if share == nil {
+			continue
+		}
+		if len(share) != appconsts.ShareSize {
+			return nil, fmt.Errorf("share %d has size %d, expected %d", i, len(share), appconsts.ShareSize)
+		}
+		present++
+	}
+	if present == 0 {
+		return nil, rsmt2d.ErrUnrepairableDataSquare
+	}
+
+	// copy the shares so that the repair doesn't modify the caller's slice
+	data := make([][]byte, len(partialShares))
+	copy(data, partialShares)
+	eds, err := rsmt2d.ImportExtendedDataSquare(data, appconsts.DefaultCodec(), wrapper.NewConstructor(uint64(width/2)))
+	if err != nil {
+		return nil, err
+	}
+
+	err = eds.Repair(dah.RowRoots, dah.ColumnRoots)
+	var byzErr *rsmt2d.ErrByzantineData
+	if errors.As(err, &byzErr) {
+		badEncoding := &ErrBadEncoding{
+			Axis:   byzErr.Axis,
+			Index:  byzErr.Index,
+			Shares: byzErr.Shares,
+			EDS:    eds,
+		}
+		// the axes that are complete before the repair are checked without
+		// collecting their shares
+		if badEncoding.Shares == nil {
+			if byzErr.Axis == rsmt2d.Row {
+				badEncoding.Shares = eds.Row(byzErr.Index)
+			} else {
+				badEncoding.Shares = eds.Col(byzErr.Index)
+			}
+		}
+		return nil, badEncoding
+	}
+	if err != nil {
+		return nil, err
+	}
+	return eds, nil
+}
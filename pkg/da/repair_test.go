@@ -0,0 +1,85 @@
+package da
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	"github.com/celestiaorg/celestia-app/pkg/wrapper"
+	"github.com/celestiaorg/rsmt2d"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepair(t *testing.T) {
+	eds, err := ExtendShares(generateShares(8 * 8))
+	require.NoError(t, err)
+	dah, err := NewDataAvailabilityHeader(eds)
+	require.NoError(t, err)
+	width := int(eds.Width())
+
+	// removes the shares of the quadrants other than the first one
+	firstQuadrant := func(shares [][]byte) [][]byte {
+		partial := make([][]byte, len(shares))
+		for i := range shares {
+			if i/width < width/2 && i%width < width/2 {
+				partial[i] = shares[i]
+			}
+		}
+		return partial
+	}
+
+	t.Run("repairs the square from the first quadrant", func(t *testing.T) {
+		partial := firstQuadrant(eds.Flattened())
+		repaired, err := Repair(dah, partial)
+		require.NoError(t, err)
+		assert.True(t, repaired.Equals(eds))
+		assert.Nil(t, partial[len(partial)-1])
+	})
+
+	t.Run("fails to repair from too few shares", func(t *testing.T) {
+		partial := firstQuadrant(eds.Flattened())
+		partial[0] = nil
+		_, err := Repair(dah, partial)
+		assert.ErrorIs(t, err, rsmt2d.ErrUnrepairableDataSquare)
+
+		_, err = Repair(dah, make([][]byte, width*width))
+		assert.ErrorIs(t, err, rsmt2d.ErrUnrepairableDataSquare)
+	})
+
+	t.Run("rejects shares that don't match the header", func(t *testing.T) {
+		_, err := Repair(dah, eds.Flattened()[:width])
+		assert.Error(t, err)
+
+		partial := eds.Flattened()
+		partial[0] = partial[0][:appconsts.ShareSize-1]
+		_, err = Repair(dah, partial)
+		assert.Error(t, err)
+	})
+
+	t.Run("returns the badly encoded axis", func(t *testing.T) {
+		// corrupt a parity share of the first row and commit to the corrupted
+		// square
+		corrupted := eds.Flattened()
+		badShare := append([]byte{}, corrupted[width-1]...)
+		badShare[len(badShare)-1] ^= 0xFF
+		corrupted[width-1] = badShare
+		badEDS, err := rsmt2d.ImportExtendedDataSquare(corrupted, appconsts.DefaultCodec(), wrapper.NewConstructor(uint64(width/2)))
+		require.NoError(t, err)
+		badDAH, err := NewDataAvailabilityHeader(badEDS)
+		require.NoError(t, err)
+
+		partial := badEDS.Flattened()
+		partial[0] = nil
+		_, err = Repair(badDAH, partial)
+		var badEncoding *ErrBadEncoding
+		require.True(t, errors.As(err, &badEncoding))
+		if badEncoding.Axis == rsmt2d.Row {
+			assert.Equal(t, uint(0), badEncoding.Index)
+		} else {
+			assert.Equal(t, uint(width-1), badEncoding.Index)
+		}
+		assert.Len(t, badEncoding.Shares, width)
+		assert.NotNil(t, badEncoding.EDS)
+	})
+}
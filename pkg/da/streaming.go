@@ -0,0 +1,207 @@
+package da
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	"github.com/celestiaorg/go-square/shares"
+	"github.com/celestiaorg/go-square/square"
+	"github.com/celestiaorg/nmt"
+	"github.com/celestiaorg/rsmt2d"
+	"github.com/klauspost/reedsolomon"
+
+	appns "github.com/celestiaorg/go-square/namespace"
+)
+
+// The shares are erasure coded in place with the Reed-Solomon encoders
+// rsmt2d.LeoRSCodec uses, so that the parity shares can be written to pooled
+// buffers. This fails to compile if appconsts.DefaultCodec no longer returns
+// the Leopard codec.
+var _ *rsmt2d.LeoRSCodec = appconsts.DefaultCodec()
+
+var (
+	// encoders caches the Reed-Solomon encoders by the width of the original
+	// data square, as rsmt2d.LeoRSCodec does, because they are costly to
+	// instantiate.
+	encoders sync.Map
+	// quadrantPool and rowPool pool the buffers of the parity shares of a
+	// quadrant and of a row across calls to NewDataAvailabilityHeaderFromShares.
+	quadrantPool sync.Pool
+	rowPool      sync.Pool
+)
+
+// NewDataAvailabilityHeaderFromShares returns the data availability header of
+// the extended data square of the shares of an original data square in
+// row-major order. It returns the same header as NewDataAvailabilityHeader of
+// ExtendShares, erasure coding the shares with the same codec, but doesn't
+// materialize the extended data square: the rows are extended one at a time
+// into pooled buffers and the row and column roots are computed incrementally
+// from their leaf hashes.
+// Besides the original data square, only the parity shares of the lower left
+// quadrant are held in memory, which is a quarter of the extended data square.
+func NewDataAvailabilityHeaderFromShares(s [][]byte) (DataAvailabilityHeader, error) {
+	return newDataAvailabilityHeader(len(s), func(i int) []byte { return s[i] })
+}
+
+// NewDataAvailabilityHeaderFromSquare returns the data availability header of
+// the extended data square of the data square. See
+// NewDataAvailabilityHeaderFromShares.
+func NewDataAvailabilityHeaderFromSquare(dataSquare square.Square) (DataAvailabilityHeader, error) {
+	return newDataAvailabilityHeader(len(dataSquare), func(i int) []byte { return dataSquare[i].ToBytes() })
+}
+
+// newDataAvailabilityHeader returns the data availability header of the
+// extended data square of the count shares of an original data square, where
+// share returns the share at the index in row-major order.
+func newDataAvailabilityHeader(count int, share func(i int) []byte) (DataAvailabilityHeader, error) {
+	// Check that the length of the square is a power of 2.
+	if !shares.IsPowerOfTwo(count) {
+		return DataAvailabilityHeader{}, fmt.Errorf("number of shares is not a power of 2: got %d", count)
+	}
+	squareSize := SquareSize(count)
+	if squareSize*squareSize != count {
+		return DataAvailabilityHeader{}, fmt.Errorf("number of shares %d is not a square", count)
+	}
+	for i := 0; i < count; i++ {
+		if len(share(i)) != appconsts.ShareSize {
+			return DataAvailabilityHeader{}, fmt.Errorf("share %d has size %d, expected %d", i, len(share(i)), appconsts.ShareSize)
+		}
+	}
+
+	enc, err := loadOrInitEncoder(squareSize)
+	if err != nil {
+		return DataAvailabilityHeader{}, err
+	}
+
+	width := 2 * squareSize
+	bufShare := func(buf []byte, i int) []byte {
+		return buf[i*appconsts.ShareSize : (i+1)*appconsts.ShareSize]
+	}
+
+	// extend the columns of the original data square into the lower left
+	// quadrant, which is needed to extend the lower rows
+	lowerLeft := getBuffer(&quadrantPool, squareSize*squareSize*appconsts.ShareSize)
+	defer quadrantPool.Put(lowerLeft)
+	shards := make([][]byte, width)
+	for col := 0; col < squareSize; col++ {
+		for row := 0; row < squareSize; row++ {
+			shards[row] = share(row*squareSize + col)
+			shards[squareSize+row] = bufShare(*lowerLeft, row*squareSize+col)
+		}
+		if err := enc.Encode(shards); err != nil {
+			return DataAvailabilityHeader{}, err
+		}
+	}
+
+	hasher := nmt.NewNmtHasher(appconsts.NewBaseHashFunc(), appconsts.NamespaceSize, true)
+	colTrees := make([]rootBuilder, width)
+	for i := range colTrees {
+		colTrees[i] = rootBuilder{hasher: hasher}
+	}
+	rowRoots := make([][]byte, width)
+	parity := getBuffer(&rowPool, squareSize*appconsts.ShareSize)
+	defer rowPool.Put(parity)
+	leaf := make([]byte, appconsts.NamespaceSize+appconsts.ShareSize)
+	for row := 0; row < width; row++ {
+		for col := 0; col < squareSize; col++ {
+			if row < squareSize {
+				shards[col] = share(row*squareSize + col)
+			} else {
+				shards[col] = bufShare(*lowerLeft, (row-squareSize)*squareSize+col)
+			}
+			shards[squareSize+col] = bufShare(*parity, col)
+		}
+		if err := enc.Encode(shards); err != nil {
+			return DataAvailabilityHeader{}, err
+		}
+
+		// the leaves are namespaced like in wrapper.ErasuredNamespacedMerkleTree
+		rowTree := rootBuilder{hasher: hasher}
+		for col, shard := range shards {
+			if row < squareSize && col < squareSize {
+				copy(leaf, shard[:appconsts.NamespaceSize])
+			} else {
+				copy(leaf, appns.ParitySharesNamespace.Bytes())
+			}
+			copy(leaf[appconsts.NamespaceSize:], shard)
+			leafHash, err := hasher.HashLeaf(leaf)
+			if err != nil {
+				return DataAvailabilityHeader{}, err
+			}
+			if err := rowTree.push(leafHash); err != nil {
+				return DataAvailabilityHeader{}, err
+			}
+			if err := colTrees[col].push(leafHash); err != nil {
+				return DataAvailabilityHeader{}, err
+			}
+		}
+		rowRoots[row] = rowTree.root()
+	}
+
+	colRoots := make([][]byte, width)
+	for col := range colTrees {
+		colRoots[col] = colTrees[col].root()
+	}
+	dah := DataAvailabilityHeader{
+		RowRoots:    rowRoots,
+		ColumnRoots: colRoots,
+	}
+	// Generate the hash of the data using the new roots
+	dah.Hash()
+	return dah, nil
+}
+
+// rootBuilder computes the root of a namespaced Merkle tree whose number of
+// leaves is a power of two from its leaf hashes pushed in order. It only keeps
+// the roots of the complete subtrees, one per height.
+type rootBuilder struct {
+	hasher *nmt.NmtHasher
+	stack  [][]byte
+	leaves int
+}
+
+// push adds the leaf hash and merges the subtrees it completes. It returns an
+// error if the leaves aren't ordered by namespace.
+func (b *rootBuilder) push(leafHash []byte) error {
+	b.stack = append(b.stack, leafHash)
+	b.leaves++
+	for n := b.leaves; n%2 == 0; n /= 2 {
+		last := len(b.stack) - 1
+		node, err := b.hasher.HashNode(b.stack[last-1], b.stack[last])
+		if err != nil {
+			return err
+		}
+		b.stack = append(b.stack[:last-1], node)
+	}
+	return nil
+}
+
+// root returns the root of the tree after all leaves were pushed.
+func (b *rootBuilder) root() []byte {
+	return b.stack[0]
+}
+
+func loadOrInitEncoder(squareSize int) (reedsolomon.Encoder, error) {
+	enc, ok := encoders.Load(squareSize)
+	if !ok {
+		var err error
+		enc, err = reedsolomon.New(squareSize, squareSize, reedsolomon.WithLeopardGF(true))
+		if err != nil {
+			return nil, err
+		}
+		encoders.Store(squareSize, enc)
+	}
+	return enc.(reedsolomon.Encoder), nil
+}
+
+// getBuffer returns a buffer of the size from the pool. The buffer should be
+// returned to the pool when it is no longer used.
+func getBuffer(pool *sync.Pool, size int) *[]byte {
+	if buf, ok := pool.Get().(*[]byte); ok && cap(*buf) >= size {
+		*buf = (*buf)[:size]
+		return buf
+	}
+	buf := make([]byte, size)
+	return &buf
+}
@@ -0,0 +1,134 @@
+package da
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	"github.com/celestiaorg/go-square/shares"
+	"github.com/celestiaorg/go-square/square"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDataAvailabilityHeaderFromShares(t *testing.T) {
+	for _, squareSize := range []int{1, 2, 4, 8, 16, 32, 64, 128, 256, 512} {
+		t.Run(fmt.Sprintf("square size %d", squareSize), func(t *testing.T) {
+			if squareSize > 256 && testing.Short() {
+				t.Skip("skipping the extension of large squares in short mode")
+			}
+			shares := generateRandomShares(squareSize * squareSize)
+			eds, err := ExtendShares(shares)
+			require.NoError(t, err)
+			want, err := NewDataAvailabilityHeader(eds)
+			require.NoError(t, err)
+
+			got, err := NewDataAvailabilityHeaderFromShares(shares)
+			require.NoError(t, err)
+			assert.Equal(t, want.RowRoots, got.RowRoots)
+			assert.Equal(t, want.ColumnRoots, got.ColumnRoots)
+			assert.Equal(t, want.Hash(), got.Hash())
+		})
+	}
+
+	t.Run("consecutive calls with different square sizes", func(t *testing.T) {
+		for _, squareSize := range []int{16, 4, 16} {
+			shares := generateRandomShares(squareSize * squareSize)
+			eds, err := ExtendShares(shares)
+			require.NoError(t, err)
+			want, err := NewDataAvailabilityHeader(eds)
+			require.NoError(t, err)
+			got, err := NewDataAvailabilityHeaderFromShares(shares)
+			require.NoError(t, err)
+			assert.Equal(t, want.Hash(), got.Hash())
+		}
+	})
+
+	t.Run("from the data square", func(t *testing.T) {
+		rawShares := generateRandomShares(16 * 16)
+		dataSquare, err := shares.FromBytes(rawShares)
+		require.NoError(t, err)
+		want, err := NewDataAvailabilityHeaderFromShares(rawShares)
+		require.NoError(t, err)
+		got, err := NewDataAvailabilityHeaderFromSquare(square.Square(dataSquare))
+		require.NoError(t, err)
+		assert.Equal(t, want.Hash(), got.Hash())
+	})
+
+	t.Run("the shares are not modified", func(t *testing.T) {
+		shares := generateRandomShares(8 * 8)
+		original := make([][]byte, len(shares))
+		for i, share := range shares {
+			original[i] = append([]byte{}, share...)
+		}
+		_, err := NewDataAvailabilityHeaderFromShares(shares)
+		require.NoError(t, err)
+		assert.Equal(t, original, shares)
+	})
+}
+
+func TestNewDataAvailabilityHeaderFromSharesErrors(t *testing.T) {
+	unordered := generateRandomShares(4 * 4)
+	unordered[0], unordered[1] = unordered[1], unordered[0]
+	shortShare := generateRandomShares(4 * 4)
+	shortShare[3] = shortShare[3][:appconsts.ShareSize-1]
+
+	tests := []struct {
+		name   string
+		shares [][]byte
+	}{
+		{name: "invalid number of shares", shares: generateShares(5)},
+		{name: "not a square", shares: generateShares(8)},
+		{name: "invalid share size", shares: shortShare},
+		{name: "unordered namespaces", shares: unordered},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewDataAvailabilityHeaderFromShares(tt.shares)
+			assert.Error(t, err)
+		})
+	}
+}
+
+// BenchmarkDataAvailabilityHeader compares computing the data availability
+// header from the extended data square to computing it from the shares without
+// materializing the extended data square.
+func BenchmarkDataAvailabilityHeader(b *testing.B) {
+	for _, squareSize := range []int{64, 128, 512} {
+		shares := generateRandomShares(squareSize * squareSize)
+		b.Run(fmt.Sprintf("ExtendShares square size %d", squareSize), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				eds, err := ExtendShares(shares)
+				require.NoError(b, err)
+				_, err = NewDataAvailabilityHeader(eds)
+				require.NoError(b, err)
+			}
+		})
+		b.Run(fmt.Sprintf("FromShares square size %d", squareSize), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_, err := NewDataAvailabilityHeaderFromShares(shares)
+				require.NoError(b, err)
+			}
+		})
+	}
+}
+
+// generateRandomShares generates count number of shares with random namespaces
+// and contents, sorted by namespace.
+func generateRandomShares(count int) [][]byte {
+	rng := rand.New(rand.NewSource(int64(count)))
+	shares := make([][]byte, count)
+	for i := range shares {
+		share := make([]byte, appconsts.ShareSize)
+		rng.Read(share)
+		// use valid version zero namespaces below the parity namespace
+		copy(share, bytes.Repeat([]byte{0}, appconsts.NamespaceSize-5))
+		shares[i] = share
+	}
+	sortByteArrays(shares)
+	return shares
+}
@@ -0,0 +1,229 @@
+// Package das simulates data availability sampling of extended data squares.
+// Light clients sample random shares of a square, each with an NMT proof to its
+// row root, while a block producer withholds shares according to a strategy.
+// The simulation reports how likely the light clients are to detect the
+// withholding and how much data they download, which allows evaluating square
+// size parameters against security targets offline.
+package das
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	"github.com/celestiaorg/celestia-app/pkg/da"
+	"github.com/celestiaorg/celestia-app/pkg/wrapper"
+	"github.com/celestiaorg/rsmt2d"
+
+	appns "github.com/celestiaorg/go-square/namespace"
+)
+
+// Strategy returns which shares of an extended data square of the width the
+// block producer withholds, in row-major order.
+type Strategy func(width uint, rng *rand.Rand) []bool
+
+// WithholdNone withholds no share.
+func WithholdNone(width uint, _ *rand.Rand) []bool {
+	return make([]bool, width*width)
+}
+
+// WithholdMinimal withholds the smallest set of shares that makes the square
+// unrecoverable: the intersection of k+1 rows and k+1 columns, where k is the
+// width of the original data square.
+func WithholdMinimal(width uint, _ *rand.Rand) []bool {
+	withheld := make([]bool, width*width)
+	for row := uint(0); row <= width/2; row++ {
+		for col := uint(0); col <= width/2; col++ {
+			withheld[row*width+col] = true
+		}
+	}
+	return withheld
+}
+
+// WithholdRandom returns a strategy that withholds each share with the
+// probability.
+func WithholdRandom(probability float64) Strategy {
+	return func(width uint, rng *rand.Rand) []bool {
+		withheld := make([]bool, width*width)
+		for i := range withheld {
+			withheld[i] = rng.Float64() < probability
+		}
+		return withheld
+	}
+}
+
+// Config configures a simulation.
+type Config struct {
+	// Clients is the number of light clients.
+	Clients int
+	// Samples is the number of distinct shares each light client samples.
+	Samples int
+	// Strategy decides the shares that are withheld.
+	Strategy Strategy
+	// Seed seeds the randomness of the strategy and the samples.
+	Seed int64
+}
+
+// Result is the outcome of a simulation.
+type Result struct {
+	// Width is the width of the extended data square.
+	Width uint
+	// Withheld is the number of withheld shares.
+	Withheld int
+	// Recoverable is true if the square can be repaired from the shares that
+	// aren't withheld, i.e. if the data is available despite the withholding.
+	Recoverable bool
+	// Detected is the number of light clients that failed to get at least
+	// one of their samples.
+	Detected int
+	// DetectionProbability is the fraction of the light clients that
+	// detected the withholding.
+	DetectionProbability float64
+	// ExpectedDetectionProbability is the probability that a light client
+	// samples at least one withheld share.
+	ExpectedDetectionProbability float64
+	// Bytes is the number of bytes of the shares and NMT proofs the light
+	// clients downloaded in total.
+	Bytes int
+	// BytesPerClient is the average number of bytes a light client
+	// downloaded.
+	BytesPerClient float64
+}
+
+// Simulate simulates the light clients of the config sampling the extended data
+// square while the shares chosen by the strategy are withheld. Each sample that
+// isn't withheld is served with an NMT proof to its row root, which is verified
+// and counted in the bandwidth.
+func Simulate(eds *rsmt2d.ExtendedDataSquare, cfg Config) (Result, error) {
+	width := eds.Width()
+	total := int(width * width)
+	if cfg.Clients <= 0 {
+		return Result{}, fmt.Errorf("the number of clients %d must be positive", cfg.Clients)
+	}
+	if cfg.Samples <= 0 || cfg.Samples > total {
+		return Result{}, fmt.Errorf("the number of samples %d must be in [1, %d]", cfg.Samples, total)
+	}
+	if cfg.Strategy == nil {
+		return Result{}, errors.New("missing withholding strategy")
+	}
+	dah, err := da.NewDataAvailabilityHeader(eds)
+	if err != nil {
+		return Result{}, err
+	}
+
+	rng := rand.New(rand.NewSource(cfg.Seed)) //nolint:gosec
+	withheld := cfg.Strategy(width, rng)
+	if len(withheld) != total {
+		return Result{}, fmt.Errorf("the strategy returned %d shares, expected %d", len(withheld), total)
+	}
+	result := Result{Width: width}
+	partial := eds.Flattened()
+	for i, w := range withheld {
+		if w {
+			partial[i] = nil
+			result.Withheld++
+		}
+	}
+	_, err = da.Repair(dah, partial)
+	result.Recoverable = err == nil
+
+	s := sampler{eds: eds, dah: dah, trees: make(map[uint]*wrapper.ErasuredNamespacedMerkleTree)}
+	for client := 0; client < cfg.Clients; client++ {
+		detected := false
+		for _, i := range sampleDistinct(rng, total, cfg.Samples) {
+			if withheld[i] {
+				detected = true
+				continue
+			}
+			n, err := s.sample(uint(i)/width, uint(i)%width)
+			if err != nil {
+				return Result{}, err
+			}
+			result.Bytes += n
+		}
+		if detected {
+			result.Detected++
+		}
+	}
+	result.DetectionProbability = float64(result.Detected) / float64(cfg.Clients)
+	result.ExpectedDetectionProbability = DetectionProbability(total, result.Withheld, cfg.Samples)
+	result.BytesPerClient = float64(result.Bytes) / float64(cfg.Clients)
+	return result, nil
+}
+
+// sampleDistinct returns the number of distinct random indexes in [0, total).
+func sampleDistinct(rng *rand.Rand, total, samples int) []int {
+	if 2*samples > total {
+		return rng.Perm(total)[:samples]
+	}
+	seen := make(map[int]bool, samples)
+	indexes := make([]int, 0, samples)
+	for len(indexes) < samples {
+		i := rng.Intn(total)
+		if !seen[i] {
+			seen[i] = true
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+// DetectionProbability returns the probability that a light client sampling
+// the number of distinct shares of the total shares samples at least one of
+// the withheld shares.
+func DetectionProbability(total, withheld, samples int) float64 {
+	// the probability that all samples are available
+	available := 1.0
+	for i := 0; i < samples; i++ {
+		available *= float64(total-withheld-i) / float64(total-i)
+		if available <= 0 {
+			return 1
+		}
+	}
+	return 1 - available
+}
+
+// sampler serves samples of an extended data square with NMT proofs to their
+// row roots. The trees of the rows are built once.
+type sampler struct {
+	eds   *rsmt2d.ExtendedDataSquare
+	dah   da.DataAvailabilityHeader
+	trees map[uint]*wrapper.ErasuredNamespacedMerkleTree
+}
+
+// sample proves the share at the row and column to the row root, verifies the
+// proof and returns the number of bytes of the share and the proof.
+func (s *sampler) sample(row, col uint) (int, error) {
+	width := s.eds.Width()
+	tree, ok := s.trees[row]
+	if !ok {
+		t := wrapper.NewErasuredNamespacedMerkleTree(uint64(width/2), row)
+		for _, share := range s.eds.Row(row) {
+			if err := t.Push(share); err != nil {
+				return 0, err
+			}
+		}
+		tree = &t
+		s.trees[row] = tree
+	}
+	proof, err := tree.ProveRange(int(col), int(col)+1)
+	if err != nil {
+		return 0, err
+	}
+
+	share := s.eds.GetCell(row, col)
+	namespace := appns.ParitySharesNamespace.Bytes()
+	if row < width/2 && col < width/2 {
+		namespace = share[:appconsts.NamespaceSize]
+	}
+	if !proof.VerifyInclusion(appconsts.NewBaseHashFunc(), namespace, [][]byte{share}, s.dah.RowRoots[row]) {
+		return 0, fmt.Errorf("invalid proof of share (%d, %d)", row, col)
+	}
+
+	n := len(share)
+	for _, node := range proof.Nodes() {
+		n += len(node)
+	}
+	return n, nil
+}
@@ -0,0 +1,61 @@
+package das_test
+
+import (
+	"testing"
+
+	"github.com/celestiaorg/celestia-app/pkg/da"
+	"github.com/celestiaorg/celestia-app/pkg/das"
+	"github.com/celestiaorg/celestia-app/test/util/testfactory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulate(t *testing.T) {
+	squareSize := 8
+	eds, err := da.ExtendShares(testfactory.GenerateRandNamespacedRawData(squareSize * squareSize))
+	require.NoError(t, err)
+	width := 2 * squareSize
+
+	t.Run("no withholding is never detected", func(t *testing.T) {
+		result, err := das.Simulate(eds, das.Config{Clients: 20, Samples: 16, Strategy: das.WithholdNone, Seed: 1})
+		require.NoError(t, err)
+		assert.True(t, result.Recoverable)
+		assert.Zero(t, result.Withheld)
+		assert.Zero(t, result.Detected)
+		assert.Zero(t, result.ExpectedDetectionProbability)
+		assert.Greater(t, result.BytesPerClient, float64(16*512))
+	})
+
+	t.Run("minimal withholding is unrecoverable and detected", func(t *testing.T) {
+		result, err := das.Simulate(eds, das.Config{Clients: 200, Samples: 16, Strategy: das.WithholdMinimal, Seed: 1})
+		require.NoError(t, err)
+		assert.False(t, result.Recoverable)
+		assert.Equal(t, (squareSize+1)*(squareSize+1), result.Withheld)
+		assert.InDelta(t, result.ExpectedDetectionProbability, result.DetectionProbability, 0.1)
+		assert.Greater(t, result.ExpectedDetectionProbability, 0.99)
+	})
+
+	t.Run("sampling every share always detects", func(t *testing.T) {
+		result, err := das.Simulate(eds, das.Config{Clients: 3, Samples: width * width, Strategy: das.WithholdRandom(0.05), Seed: 2})
+		require.NoError(t, err)
+		require.NotZero(t, result.Withheld)
+		assert.Equal(t, 3, result.Detected)
+		assert.Equal(t, 1.0, result.ExpectedDetectionProbability)
+	})
+
+	t.Run("invalid configs", func(t *testing.T) {
+		_, err := das.Simulate(eds, das.Config{Clients: 0, Samples: 1, Strategy: das.WithholdNone})
+		assert.Error(t, err)
+		_, err = das.Simulate(eds, das.Config{Clients: 1, Samples: width*width + 1, Strategy: das.WithholdNone})
+		assert.Error(t, err)
+		_, err = das.Simulate(eds, das.Config{Clients: 1, Samples: 1})
+		assert.Error(t, err)
+	})
+}
+
+func TestDetectionProbability(t *testing.T) {
+	assert.Equal(t, 0.0, das.DetectionProbability(16, 0, 4))
+	assert.Equal(t, 0.5, das.DetectionProbability(4, 2, 1))
+	assert.InDelta(t, 5.0/6, das.DetectionProbability(4, 2, 2), 1e-9)
+	assert.Equal(t, 1.0, das.DetectionProbability(4, 2, 3))
+}
@@ -0,0 +1,204 @@
+package fraud
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	"github.com/celestiaorg/celestia-app/pkg/da"
+	"github.com/celestiaorg/celestia-app/pkg/proof"
+	"github.com/celestiaorg/celestia-app/pkg/wrapper"
+	"github.com/celestiaorg/nmt"
+	"github.com/celestiaorg/rsmt2d"
+)
+
+// DetectBadEncoding repairs the extended data square of the data availability
+// header from the shares, which are the shares of the square in row-major order
+// with nil for the missing ones, such as the flattened extended data square. It
+// returns a proof of the first badly encoded axis it finds or nil if the square
+// is correctly encoded. It returns an error if there are too few shares to
+// repair the square.
+func DetectBadEncoding(dah da.DataAvailabilityHeader, shares [][]byte) (*BadEncodingProof, error) {
+	_, err := da.Repair(dah, shares)
+	var badEncoding *da.ErrBadEncoding
+	if errors.As(err, &badEncoding) {
+		return NewBadEncodingProof(badEncoding)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// NewBadEncodingProof returns the proof of the badly encoded axis of the error
+// returned by da.Repair. The shares of the axis are proven to the orthogonal
+// axes of the partially repaired square, so only the shares whose orthogonal
+// axis is complete are part of the proof.
+func NewBadEncodingProof(badEncoding *da.ErrBadEncoding) (*BadEncodingProof, error) {
+	eds := badEncoding.EDS
+	width := eds.Width()
+	if uint(len(badEncoding.Shares)) != width {
+		return nil, fmt.Errorf("the axis has %d shares, expected %d", len(badEncoding.Shares), width)
+	}
+
+	provable := 0
+	shares := make([]*ShareWithProof, width)
+	for i, share := range badEncoding.Shares {
+		shares[i] = &ShareWithProof{}
+		if len(share) == 0 {
+			continue
+		}
+		orthogonal := orthogonalAxis(eds, badEncoding.Axis, uint(i))
+		nmtProof, err := proveShare(orthogonal, uint64(width/2), uint(i), badEncoding.Index)
+		if err != nil {
+			return nil, err
+		}
+		if nmtProof == nil {
+			continue
+		}
+		shares[i] = &ShareWithProof{Share: share, Proof: nmtProof}
+		provable++
+	}
+	if uint(provable) < width/2 {
+		return nil, fmt.Errorf("only %d shares of %s %d can be proven, at least %d are needed", provable, badEncoding.Axis, badEncoding.Index, width/2)
+	}
+
+	return &BadEncodingProof{
+		Axis:   uint32(badEncoding.Axis),
+		Index:  uint32(badEncoding.Index),
+		Shares: shares,
+	}, nil
+}
+
+// Validate checks that the proof proves that the axis of the extended data
+// square of the data availability header is badly encoded. It returns nil if
+// the proof is valid, i.e. if the square is badly encoded.
+func (m BadEncodingProof) Validate(dah da.DataAvailabilityHeader) error {
+	if err := dah.ValidateBasic(); err != nil {
+		return err
+	}
+	width := uint(len(dah.RowRoots))
+	axis := rsmt2d.Axis(m.Axis)
+	if axis != rsmt2d.Row && axis != rsmt2d.Col {
+		return fmt.Errorf("invalid axis %d", m.Axis)
+	}
+	if uint(m.Index) >= width {
+		return fmt.Errorf("index %d is out of the range of the %d axes", m.Index, width)
+	}
+	if uint(len(m.Shares)) != width {
+		return fmt.Errorf("the number of shares %d must equal the width %d", len(m.Shares), width)
+	}
+	axisRoot, orthogonalRoots := dah.RowRoots[m.Index], dah.ColumnRoots
+	if axis == rsmt2d.Col {
+		axisRoot, orthogonalRoots = dah.ColumnRoots[m.Index], dah.RowRoots
+	}
+
+	// verify the shares to the roots of their orthogonal axes
+	present := uint(0)
+	shares := make([][]byte, width)
+	for i, share := range m.Shares {
+		if share == nil || len(share.Share) == 0 {
+			continue
+		}
+		if len(share.Share) != appconsts.ShareSize {
+			return fmt.Errorf("share %d has size %d, expected %d", i, len(share.Share), appconsts.ShareSize)
+		}
+		if err := verifyShare(share, orthogonalRoots[i], width, uint(i), uint(m.Index)); err != nil {
+			return fmt.Errorf("share %d: %w", i, err)
+		}
+		shares[i] = share.Share
+		present++
+	}
+	if present < width/2 {
+		return fmt.Errorf("the proof has %d shares, at least %d are needed to reconstruct the axis", present, width/2)
+	}
+
+	// reconstruct the axis from its first half so that shares that don't
+	// belong to a codeword are detected
+	codec := appconsts.DefaultCodec()
+	decoded, err := codec.Decode(shares)
+	if err != nil {
+		return err
+	}
+	parity, err := codec.Encode(decoded[:width/2])
+	if err != nil {
+		return err
+	}
+	rebuilt := append(append(make([][]byte, 0, width), decoded[:width/2]...), parity...)
+	for i, share := range shares {
+		if share != nil && !bytes.Equal(share, rebuilt[i]) {
+			// the proven shares are not a Reed-Solomon codeword
+			return nil
+		}
+	}
+
+	tree := wrapper.NewErasuredNamespacedMerkleTree(uint64(width/2), uint(m.Index))
+	for _, share := range rebuilt {
+		if err := tree.Push(share); err != nil {
+			// the first half of the axis violates the namespace order, which
+			// is not a bad encoding
+			return fmt.Errorf("reconstructed axis: %w", err)
+		}
+	}
+	root, err := tree.Root()
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(root, axisRoot) {
+		return errors.New("the axis is correctly encoded")
+	}
+	return nil
+}
+
+// orthogonalAxis returns the shares of the axis orthogonal to the axis kind
+// at the index.
+func orthogonalAxis(eds *rsmt2d.ExtendedDataSquare, axis rsmt2d.Axis, index uint) [][]byte {
+	if axis == rsmt2d.Row {
+		return eds.Col(index)
+	}
+	return eds.Row(index)
+}
+
+// proveShare returns the NMT proof of the share at the position of the axis at
+// the index. It returns nil if the axis is incomplete.
+func proveShare(axis [][]byte, squareSize uint64, index, position uint) (*proof.NMTProof, error) {
+	tree := wrapper.NewErasuredNamespacedMerkleTree(squareSize, index)
+	for _, share := range axis {
+		if len(share) == 0 {
+			return nil, nil
+		}
+		if err := tree.Push(share); err != nil {
+			return nil, err
+		}
+	}
+	nmtProof, err := tree.ProveRange(int(position), int(position)+1)
+	if err != nil {
+		return nil, err
+	}
+	return &proof.NMTProof{
+		Start:    int32(nmtProof.Start()),
+		End:      int32(nmtProof.End()),
+		Nodes:    nmtProof.Nodes(),
+		LeafHash: nmtProof.LeafHash(),
+	}, nil
+}
+
+// verifyShare verifies the NMT proof of the share at the position of the axis
+// at the index to the root of the axis. The share is a leaf of the axis at the
+// position, which is in the extended data square of the width.
+func verifyShare(share *ShareWithProof, root []byte, width, index, position uint) error {
+	p := share.Proof
+	if p == nil {
+		return errors.New("missing NMT proof")
+	}
+	if p.Start != int32(position) || p.End != int32(position)+1 {
+		return fmt.Errorf("the NMT proof of [%d, %d) doesn't prove position %d", p.Start, p.End, position)
+	}
+	namespace := leafNamespace(width, index, position, share.Share)
+	nmtProof := nmt.NewInclusionProof(int(p.Start), int(p.End), p.Nodes, true)
+	if !nmtProof.VerifyInclusion(appconsts.NewBaseHashFunc(), namespace, [][]byte{share.Share}, root) {
+		return errors.New("invalid NMT proof")
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package fraud_test
+
+import (
+	"testing"
+
+	"github.com/celestiaorg/celestia-app/app"
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	"github.com/celestiaorg/celestia-app/pkg/da"
+	"github.com/celestiaorg/celestia-app/pkg/fraud"
+	"github.com/celestiaorg/celestia-app/test/util/blobfactory"
+	"github.com/celestiaorg/celestia-app/test/util/malicious"
+	"github.com/celestiaorg/celestia-app/test/util/testfactory"
+	"github.com/celestiaorg/go-square/blob"
+	"github.com/celestiaorg/go-square/shares"
+	"github.com/celestiaorg/go-square/square"
+	"github.com/celestiaorg/rsmt2d"
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmrand "github.com/tendermint/tendermint/libs/rand"
+	core "github.com/tendermint/tendermint/proto/tendermint/types"
+)
+
+func TestBadEncodingProof(t *testing.T) {
+	// the square is constructed without decoding the PayForBlobs tx, so the
+	// blob tx doesn't need to be signed
+	txs := testfactory.GenerateRandomTxs(10, 200)
+	blobTx, err := blob.MarshalBlobTx(tmrand.Bytes(200), blobfactory.ManyRandBlobs(tmrand.NewRand(), 5000, 2000)...)
+	require.NoError(t, err)
+	dataSquare, err := square.Construct(append(txs, blobTx).ToSliceOfBytes(), appconsts.DefaultSquareSizeUpperBound, appconsts.DefaultSubtreeRootThreshold)
+	require.NoError(t, err)
+
+	goodEDS, err := da.ExtendShares(shares.ToBytes(dataSquare))
+	require.NoError(t, err)
+	goodDAH, err := da.NewDataAvailabilityHeader(goodEDS)
+	require.NoError(t, err)
+	badEDS, err := malicious.ExtendSharesWithBadEncoding(shares.ToBytes(dataSquare))
+	require.NoError(t, err)
+	badDAH, err := da.NewDataAvailabilityHeader(badEDS)
+	require.NoError(t, err)
+	width := int(badEDS.Width())
+
+	t.Run("no proof for a correctly encoded square", func(t *testing.T) {
+		badEncodingProof, err := fraud.DetectBadEncoding(goodDAH, goodEDS.Flattened())
+		require.NoError(t, err)
+		assert.Nil(t, badEncodingProof)
+	})
+
+	t.Run("proof from the full square", func(t *testing.T) {
+		badEncodingProof, err := fraud.DetectBadEncoding(badDAH, badEDS.Flattened())
+		require.NoError(t, err)
+		require.NotNil(t, badEncodingProof)
+		require.NoError(t, badEncodingProof.Validate(badDAH))
+		// the shares are not the ones of the honest square
+		require.Error(t, badEncodingProof.Validate(goodDAH))
+
+		bz, err := proto.Marshal(badEncodingProof)
+		require.NoError(t, err)
+		var decoded fraud.BadEncodingProof
+		require.NoError(t, proto.Unmarshal(bz, &decoded))
+		require.NoError(t, decoded.Validate(badDAH))
+	})
+
+	t.Run("proof from a partial square", func(t *testing.T) {
+		// remove the last quadrant, which the badly encoded axes don't cross
+		partial := badEDS.Flattened()
+		for i := range partial {
+			if i/width >= width/2 && i%width >= width/2 && i%width != width-1 {
+				partial[i] = nil
+			}
+		}
+		badEncodingProof, err := fraud.DetectBadEncoding(badDAH, partial)
+		require.NoError(t, err)
+		require.NotNil(t, badEncodingProof)
+		require.NoError(t, badEncodingProof.Validate(badDAH))
+	})
+
+	t.Run("invalid proofs", func(t *testing.T) {
+		badEncodingProof, err := fraud.DetectBadEncoding(badDAH, badEDS.Flattened())
+		require.NoError(t, err)
+
+		// a correctly encoded axis
+		otherAxis := *badEncodingProof
+		otherAxis.Index++
+		assert.Error(t, otherAxis.Validate(badDAH))
+
+		// too few shares to reconstruct the axis
+		tooFewShares := *badEncodingProof
+		tooFewShares.Shares = make([]*fraud.ShareWithProof, width)
+		for i := range tooFewShares.Shares {
+			tooFewShares.Shares[i] = &fraud.ShareWithProof{}
+		}
+		copy(tooFewShares.Shares, badEncodingProof.Shares[:width/2-1])
+		assert.Error(t, tooFewShares.Validate(badDAH))
+
+		// a share that doesn't match its proof
+		badShare := *badEncodingProof
+		badShare.Shares = append([]*fraud.ShareWithProof{}, badEncodingProof.Shares...)
+		badShare.Shares[0] = &fraud.ShareWithProof{Share: badEncodingProof.Shares[1].Share, Proof: badEncodingProof.Shares[0].Proof}
+		assert.Error(t, badShare.Validate(badDAH))
+
+		invalidAxis := *badEncodingProof
+		invalidAxis.Axis = 2
+		assert.Error(t, invalidAxis.Validate(badDAH))
+	})
+}
+
+// TestMaliciousAppBadEncoding checks that a bad encoding fraud proof can be
+// created for the blocks proposed by the malicious app.
+func TestMaliciousAppBadEncoding(t *testing.T) {
+	cparams := app.DefaultConsensusParams()
+	badApp := malicious.NewTestApp(cparams, malicious.BehaviorConfig{HandlerName: malicious.BadEncodingHandlerKey})
+
+	resp := badApp.PrepareProposal(abci.RequestPrepareProposal{BlockData: &core.Data{}})
+	dataSquare, err := square.Construct(resp.BlockData.Txs, appconsts.DefaultSquareSizeUpperBound, appconsts.DefaultSubtreeRootThreshold)
+	require.NoError(t, err)
+
+	// the proposal commits to the badly encoded square
+	eds, err := malicious.ExtendSharesWithBadEncoding(shares.ToBytes(dataSquare))
+	require.NoError(t, err)
+	dah, err := da.NewDataAvailabilityHeader(eds)
+	require.NoError(t, err)
+	require.Equal(t, resp.BlockData.Hash, dah.Hash())
+
+	badEncodingProof, err := fraud.DetectBadEncoding(dah, eds.Flattened())
+	require.NoError(t, err)
+	require.NotNil(t, badEncodingProof)
+	require.NoError(t, badEncodingProof.Validate(dah))
+	if rsmt2d.Axis(badEncodingProof.Axis) == rsmt2d.Row {
+		assert.Equal(t, uint32(0), badEncodingProof.Index)
+	} else {
+		assert.Equal(t, uint32(eds.Width()-1), badEncodingProof.Index)
+	}
+}
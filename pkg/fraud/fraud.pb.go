@@ -0,0 +1,927 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: celestia/core/v1/fraud/fraud.proto
+
+package fraud
+
+import (
+	fmt "fmt"
+	proof "github.com/celestiaorg/celestia-app/pkg/proof"
+	proto "github.com/gogo/protobuf/proto"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// BadEncodingProof proves that a row or column of an extended data square is
+// not the Reed-Solomon encoding of its first half. It contains the shares of
+// the axis that are needed to reconstruct it, each with an NMT proof to the
+// root of the orthogonal axis it is in. A verifier reconstructs the axis from
+// the shares and checks that it doesn't match the root of the axis.
+type BadEncodingProof struct {
+	// Axis is 0 for a row and 1 for a column, as rsmt2d.Axis.
+	Axis uint32 `protobuf:"varint,1,opt,name=axis,proto3" json:"axis,omitempty"`
+	// Index is the index of the badly encoded row or column.
+	Index uint32 `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	// Shares are the shares of the axis in order. The missing shares are empty.
+	Shares []*ShareWithProof `protobuf:"bytes,3,rep,name=shares,proto3" json:"shares,omitempty"`
+}
+
+func (m *BadEncodingProof) Reset()         { *m = BadEncodingProof{} }
+func (m *BadEncodingProof) String() string { return proto.CompactTextString(m) }
+func (*BadEncodingProof) ProtoMessage()    {}
+func (*BadEncodingProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b85a3b2032bc9e60, []int{0}
+}
+func (m *BadEncodingProof) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BadEncodingProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BadEncodingProof.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *BadEncodingProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BadEncodingProof.Merge(m, src)
+}
+func (m *BadEncodingProof) XXX_Size() int {
+	return m.Size()
+}
+func (m *BadEncodingProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_BadEncodingProof.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BadEncodingProof proto.InternalMessageInfo
+
+func (m *BadEncodingProof) GetAxis() uint32 {
+	if m != nil {
+		return m.Axis
+	}
+	return 0
+}
+
+func (m *BadEncodingProof) GetIndex() uint32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *BadEncodingProof) GetShares() []*ShareWithProof {
+	if m != nil {
+		return m.Shares
+	}
+	return nil
+}
+
+// ShareWithProof is a share together with its NMT proof to the root of the
+// orthogonal axis of the fraud proof it is part of.
+type ShareWithProof struct {
+	Share []byte          `protobuf:"bytes,1,opt,name=share,proto3" json:"share,omitempty"`
+	Proof *proof.NMTProof `protobuf:"bytes,2,opt,name=proof,proto3" json:"proof,omitempty"`
+}
+
+func (m *ShareWithProof) Reset()         { *m = ShareWithProof{} }
+func (m *ShareWithProof) String() string { return proto.CompactTextString(m) }
+func (*ShareWithProof) ProtoMessage()    {}
+func (*ShareWithProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b85a3b2032bc9e60, []int{1}
+}
+func (m *ShareWithProof) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ShareWithProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ShareWithProof.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ShareWithProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ShareWithProof.Merge(m, src)
+}
+func (m *ShareWithProof) XXX_Size() int {
+	return m.Size()
+}
+func (m *ShareWithProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_ShareWithProof.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ShareWithProof proto.InternalMessageInfo
+
+func (m *ShareWithProof) GetShare() []byte {
+	if m != nil {
+		return m.Share
+	}
+	return nil
+}
+
+func (m *ShareWithProof) GetProof() *proof.NMTProof {
+	if m != nil {
+		return m.Proof
+	}
+	return nil
+}
+
+// NamespaceOrderProof proves that two adjacent leaves of a row of an extended
+// data square are not ordered by namespace, which an honest NMT doesn't allow.
+// It contains the shares of the two leaves and an NMT range proof of them to
+// the row root, so it can be verified without downloading the row.
+type NamespaceOrderProof struct {
+	// Row is the index of the row of the leaves.
+	Row uint32 `protobuf:"varint,1,opt,name=row,proto3" json:"row,omitempty"`
+	// Shares are the shares of the two leaves in order.
+	Shares [][]byte `protobuf:"bytes,2,rep,name=shares,proto3" json:"shares,omitempty"`
+	// Proof is the NMT range proof of the two leaves to the row root. Its nodes
+	// are hashed without checking the namespace order.
+	Proof *proof.NMTProof `protobuf:"bytes,3,opt,name=proof,proto3" json:"proof,omitempty"`
+}
+
+func (m *NamespaceOrderProof) Reset()         { *m = NamespaceOrderProof{} }
+func (m *NamespaceOrderProof) String() string { return proto.CompactTextString(m) }
+func (*NamespaceOrderProof) ProtoMessage()    {}
+func (*NamespaceOrderProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b85a3b2032bc9e60, []int{2}
+}
+func (m *NamespaceOrderProof) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *NamespaceOrderProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_NamespaceOrderProof.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *NamespaceOrderProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NamespaceOrderProof.Merge(m, src)
+}
+func (m *NamespaceOrderProof) XXX_Size() int {
+	return m.Size()
+}
+func (m *NamespaceOrderProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_NamespaceOrderProof.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NamespaceOrderProof proto.InternalMessageInfo
+
+func (m *NamespaceOrderProof) GetRow() uint32 {
+	if m != nil {
+		return m.Row
+	}
+	return 0
+}
+
+func (m *NamespaceOrderProof) GetShares() [][]byte {
+	if m != nil {
+		return m.Shares
+	}
+	return nil
+}
+
+func (m *NamespaceOrderProof) GetProof() *proof.NMTProof {
+	if m != nil {
+		return m.Proof
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*BadEncodingProof)(nil), "celestia.core.v1.fraud.BadEncodingProof")
+	proto.RegisterType((*ShareWithProof)(nil), "celestia.core.v1.fraud.ShareWithProof")
+	proto.RegisterType((*NamespaceOrderProof)(nil), "celestia.core.v1.fraud.NamespaceOrderProof")
+}
+
+func init() {
+	proto.RegisterFile("celestia/core/v1/fraud/fraud.proto", fileDescriptor_b85a3b2032bc9e60)
+}
+
+var fileDescriptor_b85a3b2032bc9e60 = []byte{
+	// 314 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x91, 0xcf, 0x4b, 0xc3, 0x30,
+	0x14, 0xc7, 0xd7, 0xd5, 0xed, 0x90, 0x4d, 0x19, 0x51, 0x46, 0xf1, 0x10, 0x4a, 0x0f, 0xd2, 0xcb,
+	0x52, 0x36, 0xc1, 0xa3, 0x87, 0x81, 0x78, 0x72, 0x4a, 0x15, 0x04, 0x0f, 0x42, 0xd6, 0x66, 0x6d,
+	0xd0, 0x35, 0x21, 0xed, 0x7e, 0x1c, 0xfc, 0x23, 0xfc, 0xb3, 0x3c, 0xee, 0xe8, 0x51, 0xda, 0x7f,
+	0x44, 0x92, 0xac, 0x13, 0x99, 0x17, 0x2f, 0x8f, 0xf7, 0xf2, 0xfd, 0xbe, 0x7c, 0xde, 0xe3, 0x01,
+	0x2f, 0xa2, 0xaf, 0x34, 0x2f, 0x18, 0x09, 0x22, 0x2e, 0x69, 0xb0, 0x1c, 0x06, 0x33, 0x49, 0x16,
+	0xb1, 0x89, 0x58, 0x48, 0x5e, 0x70, 0xd8, 0xaf, 0x3d, 0x58, 0x79, 0xf0, 0x72, 0x88, 0xb5, 0x7a,
+	0xba, 0xdf, 0x2b, 0x24, 0xe7, 0x33, 0x13, 0x4d, 0xaf, 0xf7, 0x06, 0x7a, 0x63, 0x12, 0x5f, 0x65,
+	0x11, 0x8f, 0x59, 0x96, 0xdc, 0x29, 0x05, 0x42, 0x70, 0x40, 0xd6, 0x2c, 0x77, 0x2c, 0xd7, 0xf2,
+	0x0f, 0x43, 0x9d, 0xc3, 0x13, 0xd0, 0x62, 0x59, 0x4c, 0xd7, 0x4e, 0x53, 0x3f, 0x9a, 0x02, 0x5e,
+	0x82, 0x76, 0x9e, 0x12, 0x49, 0x73, 0xc7, 0x76, 0x6d, 0xbf, 0x33, 0x3a, 0xc3, 0x7f, 0x8f, 0x82,
+	0xef, 0x95, 0xeb, 0x91, 0x15, 0xa9, 0x26, 0x84, 0xdb, 0x2e, 0xef, 0x19, 0x1c, 0xfd, 0x56, 0x14,
+	0x47, 0x6b, 0x1a, 0xde, 0x0d, 0x4d, 0x01, 0x2f, 0x40, 0x4b, 0x0f, 0xad, 0xe9, 0x9d, 0x91, 0xbb,
+	0x8f, 0x31, 0x3b, 0x4d, 0x6e, 0x1e, 0x0c, 0xc0, 0xd8, 0xbd, 0x15, 0x38, 0x9e, 0x90, 0x39, 0xcd,
+	0x05, 0x89, 0xe8, 0xad, 0x8c, 0xa9, 0x34, 0x90, 0x1e, 0xb0, 0x25, 0x5f, 0x6d, 0xf7, 0x53, 0x29,
+	0xec, 0xef, 0x16, 0x69, 0xba, 0xb6, 0xdf, 0xad, 0x07, 0xfc, 0x01, 0xdb, 0xff, 0x02, 0x8f, 0xaf,
+	0x3f, 0x4a, 0x64, 0x6d, 0x4a, 0x64, 0x7d, 0x95, 0xc8, 0x7a, 0xaf, 0x50, 0x63, 0x53, 0xa1, 0xc6,
+	0x67, 0x85, 0x1a, 0x4f, 0x83, 0x84, 0x15, 0xe9, 0x62, 0x8a, 0x23, 0x3e, 0x0f, 0xea, 0xcf, 0xb8,
+	0x4c, 0x76, 0xf9, 0x80, 0x08, 0x11, 0x88, 0x97, 0xc4, 0x5c, 0x78, 0xda, 0xd6, 0x67, 0x3a, 0xff,
+	0x1e, 0x00, 0x9b, 0x1b, 0x4d, 0x68, 0x08, 0x02, 0x00, 0x00,
+}
+
+func (m *BadEncodingProof) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BadEncodingProof) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BadEncodingProof) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Shares) > 0 {
+		for iNdEx := len(m.Shares) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Shares[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintFraud(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.Index != 0 {
+		i = encodeVarintFraud(dAtA, i, uint64(m.Index))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Axis != 0 {
+		i = encodeVarintFraud(dAtA, i, uint64(m.Axis))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ShareWithProof) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ShareWithProof) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ShareWithProof) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Proof != nil {
+		{
+			size, err := m.Proof.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintFraud(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Share) > 0 {
+		i -= len(m.Share)
+		copy(dAtA[i:], m.Share)
+		i = encodeVarintFraud(dAtA, i, uint64(len(m.Share)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *NamespaceOrderProof) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *NamespaceOrderProof) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *NamespaceOrderProof) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Proof != nil {
+		{
+			size, err := m.Proof.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintFraud(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Shares) > 0 {
+		for iNdEx := len(m.Shares) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Shares[iNdEx])
+			copy(dAtA[i:], m.Shares[iNdEx])
+			i = encodeVarintFraud(dAtA, i, uint64(len(m.Shares[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Row != 0 {
+		i = encodeVarintFraud(dAtA, i, uint64(m.Row))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintFraud(dAtA []byte, offset int, v uint64) int {
+	offset -= sovFraud(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *BadEncodingProof) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Axis != 0 {
+		n += 1 + sovFraud(uint64(m.Axis))
+	}
+	if m.Index != 0 {
+		n += 1 + sovFraud(uint64(m.Index))
+	}
+	if len(m.Shares) > 0 {
+		for _, e := range m.Shares {
+			l = e.Size()
+			n += 1 + l + sovFraud(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ShareWithProof) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Share)
+	if l > 0 {
+		n += 1 + l + sovFraud(uint64(l))
+	}
+	if m.Proof != nil {
+		l = m.Proof.Size()
+		n += 1 + l + sovFraud(uint64(l))
+	}
+	return n
+}
+
+func (m *NamespaceOrderProof) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Row != 0 {
+		n += 1 + sovFraud(uint64(m.Row))
+	}
+	if len(m.Shares) > 0 {
+		for _, b := range m.Shares {
+			l = len(b)
+			n += 1 + l + sovFraud(uint64(l))
+		}
+	}
+	if m.Proof != nil {
+		l = m.Proof.Size()
+		n += 1 + l + sovFraud(uint64(l))
+	}
+	return n
+}
+
+func sovFraud(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozFraud(x uint64) (n int) {
+	return sovFraud(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *BadEncodingProof) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFraud
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BadEncodingProof: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BadEncodingProof: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Axis", wireType)
+			}
+			m.Axis = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFraud
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Axis |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			m.Index = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFraud
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Index |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Shares", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFraud
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFraud
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFraud
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Shares = append(m.Shares, &ShareWithProof{})
+			if err := m.Shares[len(m.Shares)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFraud(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFraud
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ShareWithProof) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFraud
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ShareWithProof: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ShareWithProof: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Share", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFraud
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthFraud
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFraud
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Share = append(m.Share[:0], dAtA[iNdEx:postIndex]...)
+			if m.Share == nil {
+				m.Share = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Proof", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFraud
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFraud
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFraud
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Proof == nil {
+				m.Proof = &proof.NMTProof{}
+			}
+			if err := m.Proof.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFraud(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFraud
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *NamespaceOrderProof) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFraud
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: NamespaceOrderProof: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: NamespaceOrderProof: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Row", wireType)
+			}
+			m.Row = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFraud
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Row |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Shares", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFraud
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthFraud
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFraud
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Shares = append(m.Shares, make([]byte, postIndex-iNdEx))
+			copy(m.Shares[len(m.Shares)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Proof", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFraud
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFraud
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFraud
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Proof == nil {
+				m.Proof = &proof.NMTProof{}
+			}
+			if err := m.Proof.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFraud(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFraud
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipFraud(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowFraud
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowFraud
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowFraud
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthFraud
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupFraud
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthFraud
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthFraud        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowFraud          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupFraud = fmt.Errorf("proto: unexpected end of group")
+)
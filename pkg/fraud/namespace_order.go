@@ -0,0 +1,211 @@
+package fraud
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	"github.com/celestiaorg/celestia-app/pkg/da"
+	"github.com/celestiaorg/celestia-app/pkg/proof"
+	"github.com/celestiaorg/nmt"
+	"github.com/celestiaorg/rsmt2d"
+
+	appns "github.com/celestiaorg/go-square/namespace"
+)
+
+// DetectNamespaceOrderViolation checks that the leaves of each row of the
+// original data square are ordered by namespace. It returns a proof of the
+// first two adjacent leaves that aren't or nil if all rows are ordered.
+func DetectNamespaceOrderViolation(eds *rsmt2d.ExtendedDataSquare) (*NamespaceOrderProof, error) {
+	half := eds.Width() / 2
+	for row := uint(0); row < half; row++ {
+		shares := eds.Row(row)
+		for i := uint(1); i < half; i++ {
+			if bytes.Compare(shares[i-1][:appconsts.NamespaceSize], shares[i][:appconsts.NamespaceSize]) > 0 {
+				return NewNamespaceOrderProof(shares, row, i-1)
+			}
+		}
+	}
+	return nil, nil
+}
+
+// NewNamespaceOrderProof returns a proof that the leaves at the position and
+// the one after it of the row at the index aren't ordered by namespace. The row
+// shares are all the shares of the row of the extended data square.
+func NewNamespaceOrderProof(rowShares [][]byte, row, position uint) (*NamespaceOrderProof, error) {
+	width := uint(len(rowShares))
+	if row >= width/2 || position+1 >= width/2 {
+		return nil, fmt.Errorf("leaves %d and %d of row %d are not in the original data square", position, position+1, row)
+	}
+	first, second := rowShares[position], rowShares[position+1]
+	if bytes.Compare(first[:appconsts.NamespaceSize], second[:appconsts.NamespaceSize]) <= 0 {
+		return nil, fmt.Errorf("leaves %d and %d of row %d are ordered", position, position+1, row)
+	}
+
+	hasher := newHasher()
+	leafHashes := make([][]byte, width)
+	for i, share := range rowShares {
+		leaf, err := hasher.HashLeaf(append(leafNamespace(width, row, uint(i), share), share...))
+		if err != nil {
+			return nil, err
+		}
+		leafHashes[i] = leaf
+	}
+
+	// the nodes are the roots of the largest subtrees outside of the range
+	// of the two leaves in order
+	start, end := int(position), int(position)+2
+	var nodes [][]byte
+	var collect func(lo, hi int)
+	collect = func(lo, hi int) {
+		if hi <= start || lo >= end {
+			nodes = append(nodes, subtreeRoot(leafHashes[lo:hi]))
+			return
+		}
+		if hi-lo == 1 {
+			return
+		}
+		k := (hi - lo) / 2
+		collect(lo, lo+k)
+		collect(lo+k, hi)
+	}
+	collect(0, int(width))
+
+	return &NamespaceOrderProof{
+		Row:    uint32(row),
+		Shares: [][]byte{first, second},
+		Proof: &proof.NMTProof{
+			Start: int32(start),
+			End:   int32(end),
+			Nodes: nodes,
+		},
+	}, nil
+}
+
+// Validate checks that the proof proves that two adjacent leaves of the row of
+// the extended data square of the data availability header aren't ordered by
+// namespace. It returns nil if the proof is valid, i.e. if the row is out of
+// order.
+func (m NamespaceOrderProof) Validate(dah da.DataAvailabilityHeader) error {
+	if err := dah.ValidateBasic(); err != nil {
+		return err
+	}
+	width := uint(len(dah.RowRoots))
+	if uint(m.Row) >= width/2 {
+		return fmt.Errorf("row %d is not in the original data square", m.Row)
+	}
+	if len(m.Shares) != 2 {
+		return fmt.Errorf("the proof has %d shares, expected 2", len(m.Shares))
+	}
+	for i, share := range m.Shares {
+		if len(share) != appconsts.ShareSize {
+			return fmt.Errorf("share %d has size %d, expected %d", i, len(share), appconsts.ShareSize)
+		}
+	}
+	p := m.Proof
+	if p == nil {
+		return errors.New("missing NMT proof")
+	}
+	if p.Start < 0 || p.End != p.Start+2 || uint(p.End) > width/2 {
+		return fmt.Errorf("the NMT proof of [%d, %d) doesn't prove two adjacent leaves of the original data square", p.Start, p.End)
+	}
+	if bytes.Compare(m.Shares[0][:appconsts.NamespaceSize], m.Shares[1][:appconsts.NamespaceSize]) <= 0 {
+		return errors.New("the leaves are ordered by namespace")
+	}
+
+	hasher := newHasher()
+	leafHashes := make([][]byte, 2)
+	for i, share := range m.Shares {
+		leaf, err := hasher.HashLeaf(append(leafNamespace(width, uint(m.Row), uint(p.Start)+uint(i), share), share...))
+		if err != nil {
+			return err
+		}
+		leafHashes[i] = leaf
+	}
+
+	nodes := p.Nodes
+	var compute func(lo, hi int) ([]byte, error)
+	compute = func(lo, hi int) ([]byte, error) {
+		if hi <= int(p.Start) || lo >= int(p.End) {
+			if len(nodes) == 0 {
+				return nil, errors.New("NMT proof has too few nodes")
+			}
+			node := nodes[0]
+			nodes = nodes[1:]
+			if len(node) != hasher.Size() {
+				return nil, fmt.Errorf("NMT proof node has invalid size %d", len(node))
+			}
+			return node, nil
+		}
+		if hi-lo == 1 {
+			return leafHashes[lo-int(p.Start)], nil
+		}
+		k := (hi - lo) / 2
+		left, err := compute(lo, lo+k)
+		if err != nil {
+			return nil, err
+		}
+		right, err := compute(lo+k, hi)
+		if err != nil {
+			return nil, err
+		}
+		return hashNode(left, right), nil
+	}
+	root, err := compute(0, int(width))
+	if err != nil {
+		return err
+	}
+	if len(nodes) != 0 {
+		return errors.New("NMT proof has too many nodes")
+	}
+	if !bytes.Equal(root, dah.RowRoots[m.Row]) {
+		return errors.New("the leaves don't hash to the row root")
+	}
+	return nil
+}
+
+// leafNamespace returns the namespace of the leaf of the share at the row and
+// column of the extended data square of the width. The leaves outside of the
+// original data square have the parity namespace.
+func leafNamespace(width, row, col uint, share []byte) []byte {
+	if row < width/2 && col < width/2 {
+		return append([]byte{}, share[:appconsts.NamespaceSize]...)
+	}
+	return appns.ParitySharesNamespace.Bytes()
+}
+
+func newHasher() *nmt.NmtHasher {
+	return nmt.NewNmtHasher(appconsts.NewBaseHashFunc(), appconsts.NamespaceSize, true)
+}
+
+// subtreeRoot returns the root of the subtree of the leaf hashes, whose number
+// must be a power of two.
+func subtreeRoot(leafHashes [][]byte) []byte {
+	if len(leafHashes) == 1 {
+		return leafHashes[0]
+	}
+	k := len(leafHashes) / 2
+	return hashNode(subtreeRoot(leafHashes[:k]), subtreeRoot(leafHashes[k:]))
+}
+
+// hashNode hashes two nodes of an NMT like nmt.NmtHasher.HashNode but without
+// checking that they are ordered by namespace, so that the roots of malicious
+// trees can be recomputed. The namespace range of the parent is the one an
+// honest tree would compute for ordered children.
+func hashNode(left, right []byte) []byte {
+	minNs := nmt.MinNamespace(left, appconsts.NamespaceSize)
+	maxNs := nmt.MaxNamespace(right, appconsts.NamespaceSize)
+	// the max namespace is ignored, as by the trees of the extended data square
+	if bytes.Equal(nmt.MinNamespace(right, appconsts.NamespaceSize), appns.ParitySharesNamespace.Bytes()) {
+		maxNs = nmt.MaxNamespace(left, appconsts.NamespaceSize)
+	}
+	h := appconsts.NewBaseHashFunc()
+	h.Write([]byte{nmt.NodePrefix})
+	h.Write(left)
+	h.Write(right)
+	node := make([]byte, 0, 2*appconsts.NamespaceSize+h.Size())
+	node = append(node, minNs...)
+	node = append(node, maxNs...)
+	return h.Sum(node)
+}
@@ -0,0 +1,91 @@
+package fraud_test
+
+import (
+	"testing"
+
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	"github.com/celestiaorg/celestia-app/pkg/da"
+	"github.com/celestiaorg/celestia-app/pkg/fraud"
+	"github.com/celestiaorg/celestia-app/test/util/blobfactory"
+	"github.com/celestiaorg/celestia-app/test/util/malicious"
+	"github.com/celestiaorg/go-square/blob"
+	"github.com/celestiaorg/go-square/shares"
+	"github.com/celestiaorg/go-square/square"
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tmrand "github.com/tendermint/tendermint/libs/rand"
+)
+
+func TestNamespaceOrderProof(t *testing.T) {
+	// the square is constructed without decoding the PayForBlobs tx, so the
+	// blob tx doesn't need to be signed
+	blobTx, err := blob.MarshalBlobTx(tmrand.Bytes(200), blobfactory.ManyRandBlobs(tmrand.NewRand(), 100, 100, 100, 100, 100, 100)...)
+	require.NoError(t, err)
+	txs := [][]byte{blobTx}
+
+	// the malicious square swaps the first two blobs, which are both in the
+	// first row as the blobs are one share each
+	badSquare, err := malicious.Construct(txs, appconsts.LatestVersion, appconsts.DefaultSquareSizeUpperBound, malicious.OutOfOrderExport)
+	require.NoError(t, err)
+	badEDS, err := malicious.ExtendShares(shares.ToBytes(badSquare))
+	require.NoError(t, err)
+	badDAH, err := da.NewDataAvailabilityHeader(badEDS)
+	require.NoError(t, err)
+
+	goodSquare, err := square.Construct(txs, appconsts.DefaultSquareSizeUpperBound, appconsts.DefaultSubtreeRootThreshold)
+	require.NoError(t, err)
+	goodEDS, err := da.ExtendShares(shares.ToBytes(goodSquare))
+	require.NoError(t, err)
+	goodDAH, err := da.NewDataAvailabilityHeader(goodEDS)
+	require.NoError(t, err)
+
+	t.Run("no proof for an ordered square", func(t *testing.T) {
+		orderProof, err := fraud.DetectNamespaceOrderViolation(goodEDS)
+		require.NoError(t, err)
+		assert.Nil(t, orderProof)
+	})
+
+	t.Run("proof of an unordered square", func(t *testing.T) {
+		orderProof, err := fraud.DetectNamespaceOrderViolation(badEDS)
+		require.NoError(t, err)
+		require.NotNil(t, orderProof)
+		require.NoError(t, orderProof.Validate(badDAH))
+		require.Error(t, orderProof.Validate(goodDAH))
+
+		bz, err := proto.Marshal(orderProof)
+		require.NoError(t, err)
+		var decoded fraud.NamespaceOrderProof
+		require.NoError(t, proto.Unmarshal(bz, &decoded))
+		require.NoError(t, decoded.Validate(badDAH))
+	})
+
+	t.Run("invalid proofs", func(t *testing.T) {
+		orderProof, err := fraud.DetectNamespaceOrderViolation(badEDS)
+		require.NoError(t, err)
+
+		swapped := *orderProof
+		swapped.Shares = [][]byte{orderProof.Shares[1], orderProof.Shares[0]}
+		assert.Error(t, swapped.Validate(badDAH))
+
+		otherRow := *orderProof
+		otherRow.Row++
+		assert.Error(t, otherRow.Validate(badDAH))
+
+		shifted := *orderProof
+		shiftedNMTProof := *orderProof.Proof
+		shiftedNMTProof.Start++
+		shiftedNMTProof.End++
+		shifted.Proof = &shiftedNMTProof
+		assert.Error(t, shifted.Validate(badDAH))
+
+		tooFewNodes := *orderProof
+		tooFewNMTProof := *orderProof.Proof
+		tooFewNMTProof.Nodes = tooFewNMTProof.Nodes[1:]
+		tooFewNodes.Proof = &tooFewNMTProof
+		assert.Error(t, tooFewNodes.Validate(badDAH))
+
+		_, err = fraud.NewNamespaceOrderProof(goodEDS.Row(0), 0, 0)
+		assert.Error(t, err)
+	})
+}
@@ -10,6 +10,17 @@ import (
 // GetCommitment gets the share commitment for a blob in the original data
 // square.
 func GetCommitment(cacher *EDSSubTreeRootCacher, dah da.DataAvailabilityHeader, start, blobShareLen, subtreeRootThreshold int) ([]byte, error) {
+	subTreeRoots, err := GetSubtreeRoots(cacher, dah, start, blobShareLen, subtreeRootThreshold)
+	if err != nil {
+		return nil, err
+	}
+	return merkle.HashFromByteSlices(subTreeRoots), nil
+}
+
+// GetSubtreeRoots gets the subtree roots that the share commitment of a blob in
+// the original data square is computed from, in the order of the ranges
+// returned by CommitmentSubtreeRanges.
+func GetSubtreeRoots(cacher *EDSSubTreeRootCacher, dah da.DataAvailabilityHeader, start, blobShareLen, subtreeRootThreshold int) ([][]byte, error) {
 	squareSize := len(dah.RowRoots) / 2
 	if start+blobShareLen > squareSize*squareSize {
 		return nil, errors.New("cannot get commitment for blob that doesn't fit in square")
@@ -26,5 +37,5 @@ func GetCommitment(cacher *EDSSubTreeRootCacher, dah da.DataAvailabilityHeader,
 		}
 		subTreeRoots[i] = subTreeRoot
 	}
-	return merkle.HashFromByteSlices(subTreeRoots), nil
+	return subTreeRoots, nil
 }
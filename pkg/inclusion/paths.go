@@ -11,14 +11,62 @@ type path struct {
 	row          int
 }
 
+// SubtreeRange is the range of leaves of a row of the data square that is
+// covered by a subtree root.
+type SubtreeRange struct {
+	Row int
+	// Start is the index of the first leaf of the subtree in the row.
+	Start int
+	// End is the index after the last leaf of the subtree in the row.
+	End int
+}
+
+// CommitmentSubtreeRanges returns the ranges of the subtree roots that the
+// share commitment of a blob is created from, in order. start is the index of
+// the first share of the blob in a data square of the given size.
+func CommitmentSubtreeRanges(squareSize, start, blobShareLen, subtreeRootThreshold int) []SubtreeRange {
+	maxDepth := int(math.Log2(float64(squareSize)))
+	coords := calculateCommitmentCoordinates(squareSize, start, blobShareLen, subtreeRootThreshold)
+	ranges := make([]SubtreeRange, len(coords))
+	for i, c := range coords {
+		width := 1 << (maxDepth - c.depth)
+		ranges[i] = SubtreeRange{
+			Row:   c.row,
+			Start: c.position * width,
+			End:   (c.position + 1) * width,
+		}
+	}
+	return ranges
+}
+
 // calculateCommitmentPaths calculates all of the paths to subtree roots needed to
 // create the commitment for a given blob.
 func calculateCommitmentPaths(squareSize, start, blobShareLen, subtreeRootThreshold int) []path {
+	coords := calculateCommitmentCoordinates(squareSize, start, blobShareLen, subtreeRootThreshold)
+	paths := make([]path, 0, len(coords))
+	for _, c := range coords {
+		paths = append(paths, path{
+			instructions: genSubTreeRootPath(c.depth, uint(c.position)),
+			row:          c.row,
+		})
+	}
+	return paths
+}
+
+// rowCoord is the coordinate of a subtree root in a row of the data square.
+type rowCoord struct {
+	coord
+	row int
+}
+
+// calculateCommitmentCoordinates calculates the coordinates of all subtree
+// roots needed to create the commitment for a given blob.
+func calculateCommitmentCoordinates(squareSize, start, blobShareLen, subtreeRootThreshold int) []rowCoord {
 	start = inclusion.NextShareIndex(start, blobShareLen, subtreeRootThreshold)
 	startRow, endRow := start/squareSize, (start+blobShareLen-1)/squareSize
 	normalizedStartIndex := start % squareSize
 	normalizedEndIndex := (start + blobShareLen) - endRow*squareSize
-	paths := []path{}
+	coords := []rowCoord{}
 	maxDepth := int(math.Log2(float64(squareSize)))
 	for i := startRow; i <= endRow; i++ {
 		start, end := 0, squareSize
@@ -34,16 +82,12 @@ func calculateCommitmentPaths(squareSize, start, blobShareLen, subtreeRootThresh
 		// SubtreeRootThreshold. See ADR-013 for more details.
 		subTreeRootMaxDepth := int(math.Log2(float64(inclusion.SubTreeWidth(blobShareLen, subtreeRootThreshold))))
 		minDepth := maxDepth - subTreeRootMaxDepth
-		coords := calculateSubTreeRootCoordinates(maxDepth, minDepth, start, end)
-		for _, c := range coords {
-			paths = append(paths, path{
-				instructions: genSubTreeRootPath(c.depth, uint(c.position)),
-				row:          i,
-			})
+		for _, c := range calculateSubTreeRootCoordinates(maxDepth, minDepth, start, end) {
+			coords = append(coords, rowCoord{coord: c, row: i})
 		}
 	}
 
-	return paths
+	return coords
 }
 
 // genSubTreeRootPath calculates the path to a given subtree root of a node, given the
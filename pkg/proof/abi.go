@@ -0,0 +1,341 @@
+package proof
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+
+	appns "github.com/celestiaorg/go-square/namespace"
+)
+
+// The ABI types below mirror the structs that the DAVerifier library of
+// github.com/celestiaorg/blobstream-contracts takes to verify shares on EVM
+// chains. Their fields are named after the fields of the Solidity structs so
+// that they can be passed to go-ethereum bindings and their Encode methods
+// return the ABI encoding of the structs, which is what abi.encode returns in
+// Solidity.
+
+// ABINamespace mirrors the Namespace struct.
+type ABINamespace struct {
+	Version [1]byte
+	Id      [28]byte //nolint:revive,stylecheck // named after the Solidity field
+}
+
+// ABINamespaceNode mirrors the NamespaceNode struct, which is a node of a
+// namespaced Merkle tree.
+type ABINamespaceNode struct {
+	Min    ABINamespace
+	Max    ABINamespace
+	Digest [32]byte
+}
+
+// ABINamespaceMerkleMultiproof mirrors the NamespaceMerkleMultiproof struct.
+type ABINamespaceMerkleMultiproof struct {
+	BeginKey  *big.Int
+	EndKey    *big.Int
+	SideNodes []ABINamespaceNode
+}
+
+// ABIBinaryMerkleProof mirrors the BinaryMerkleProof struct.
+type ABIBinaryMerkleProof struct {
+	SideNodes [][32]byte
+	Key       *big.Int
+	NumLeaves *big.Int
+}
+
+// ABIDataRootTuple mirrors the DataRootTuple struct.
+type ABIDataRootTuple struct {
+	Height   *big.Int
+	DataRoot [32]byte
+}
+
+// ABIAttestationProof mirrors the AttestationProof struct, which proves that a
+// data root tuple was committed to by the Blobstream contract.
+type ABIAttestationProof struct {
+	TupleRootNonce *big.Int
+	Tuple          ABIDataRootTuple
+	Proof          ABIBinaryMerkleProof
+}
+
+// ABISharesProof mirrors the SharesProof struct.
+type ABISharesProof struct {
+	Data             [][]byte
+	ShareProofs      []ABINamespaceMerkleMultiproof
+	Namespace        ABINamespace
+	RowRoots         []ABINamespaceNode
+	RowProofs        []ABIBinaryMerkleProof
+	AttestationProof ABIAttestationProof
+}
+
+// ABIRowProof holds the row roots and their proofs to the data root in the
+// form they are passed to DAVerifier.verifyMultiRowRootsToDataRootTupleRoot.
+type ABIRowProof struct {
+	RowRoots  []ABINamespaceNode
+	RowProofs []ABIBinaryMerkleProof
+}
+
+var (
+	namespaceABIComponents = []abi.ArgumentMarshaling{
+		{Name: "version", Type: "bytes1"},
+		{Name: "id", Type: "bytes28"},
+	}
+	namespaceNodeABIComponents = []abi.ArgumentMarshaling{
+		{Name: "min", Type: "tuple", Components: namespaceABIComponents},
+		{Name: "max", Type: "tuple", Components: namespaceABIComponents},
+		{Name: "digest", Type: "bytes32"},
+	}
+	namespaceMerkleMultiproofABIComponents = []abi.ArgumentMarshaling{
+		{Name: "beginKey", Type: "uint256"},
+		{Name: "endKey", Type: "uint256"},
+		{Name: "sideNodes", Type: "tuple[]", Components: namespaceNodeABIComponents},
+	}
+	binaryMerkleProofABIComponents = []abi.ArgumentMarshaling{
+		{Name: "sideNodes", Type: "bytes32[]"},
+		{Name: "key", Type: "uint256"},
+		{Name: "numLeaves", Type: "uint256"},
+	}
+	attestationProofABIComponents = []abi.ArgumentMarshaling{
+		{Name: "tupleRootNonce", Type: "uint256"},
+		{Name: "tuple", Type: "tuple", Components: []abi.ArgumentMarshaling{
+			{Name: "height", Type: "uint256"},
+			{Name: "dataRoot", Type: "bytes32"},
+		}},
+		{Name: "proof", Type: "tuple", Components: binaryMerkleProofABIComponents},
+	}
+	sharesProofABIComponents = []abi.ArgumentMarshaling{
+		{Name: "data", Type: "bytes[]"},
+		{Name: "shareProofs", Type: "tuple[]", Components: namespaceMerkleMultiproofABIComponents},
+		{Name: "namespace", Type: "tuple", Components: namespaceABIComponents},
+		{Name: "rowRoots", Type: "tuple[]", Components: namespaceNodeABIComponents},
+		{Name: "rowProofs", Type: "tuple[]", Components: binaryMerkleProofABIComponents},
+		{Name: "attestationProof", Type: "tuple", Components: attestationProofABIComponents},
+	}
+
+	namespaceABIType                 = mustNewABIType("tuple", namespaceABIComponents)
+	namespaceNodeABIType             = mustNewABIType("tuple", namespaceNodeABIComponents)
+	namespaceNodesABIType            = mustNewABIType("tuple[]", namespaceNodeABIComponents)
+	namespaceMerkleMultiproofABIType = mustNewABIType("tuple", namespaceMerkleMultiproofABIComponents)
+	binaryMerkleProofABIType         = mustNewABIType("tuple", binaryMerkleProofABIComponents)
+	binaryMerkleProofsABIType        = mustNewABIType("tuple[]", binaryMerkleProofABIComponents)
+	attestationProofABIType          = mustNewABIType("tuple", attestationProofABIComponents)
+	sharesProofABIType               = mustNewABIType("tuple", sharesProofABIComponents)
+	bytes32ABIType                   = mustNewABIType("bytes32", nil)
+	addressABIType                   = mustNewABIType("address", nil)
+)
+
+func mustNewABIType(t string, components []abi.ArgumentMarshaling) abi.Type {
+	typ, err := abi.NewType(t, "", components)
+	if err != nil {
+		panic(err)
+	}
+	return typ
+}
+
+// NewABINamespace converts the namespace into its ABI type.
+func NewABINamespace(namespace appns.Namespace) (ABINamespace, error) {
+	return abiNamespaceFromBytes(namespace.Bytes())
+}
+
+func abiNamespaceFromBytes(namespace []byte) (ABINamespace, error) {
+	if len(namespace) != appconsts.NamespaceSize {
+		return ABINamespace{}, fmt.Errorf("invalid namespace length %d, expected %d", len(namespace), appconsts.NamespaceSize)
+	}
+	var ns ABINamespace
+	copy(ns.Version[:], namespace[:appconsts.NamespaceVersionSize])
+	copy(ns.Id[:], namespace[appconsts.NamespaceVersionSize:])
+	return ns, nil
+}
+
+// NewABINamespaceNode converts a node of a namespaced Merkle tree, such as a
+// row root, which consists of the min and max namespace of the node followed by
+// its digest, into its ABI type.
+func NewABINamespaceNode(node []byte) (ABINamespaceNode, error) {
+	if len(node) != 2*appconsts.NamespaceSize+32 {
+		return ABINamespaceNode{}, fmt.Errorf("invalid namespace node length %d, expected %d", len(node), 2*appconsts.NamespaceSize+32)
+	}
+	minNs, err := abiNamespaceFromBytes(node[:appconsts.NamespaceSize])
+	if err != nil {
+		return ABINamespaceNode{}, err
+	}
+	maxNs, err := abiNamespaceFromBytes(node[appconsts.NamespaceSize : 2*appconsts.NamespaceSize])
+	if err != nil {
+		return ABINamespaceNode{}, err
+	}
+	n := ABINamespaceNode{Min: minNs, Max: maxNs}
+	copy(n.Digest[:], node[2*appconsts.NamespaceSize:])
+	return n, nil
+}
+
+// NewABIAttestationProof returns the proof that the data root of the block at
+// the height was committed to by the Blobstream contract in the data root
+// tuple root with the nonce. proof is the proof of the data root tuple to the
+// data root tuple root.
+func NewABIAttestationProof(nonce, height uint64, dataRoot []byte, proof Proof) (ABIAttestationProof, error) {
+	if len(dataRoot) != 32 {
+		return ABIAttestationProof{}, fmt.Errorf("invalid data root length %d, expected 32", len(dataRoot))
+	}
+	binaryProof, err := proof.ABI()
+	if err != nil {
+		return ABIAttestationProof{}, err
+	}
+	tuple := ABIDataRootTuple{Height: new(big.Int).SetUint64(height)}
+	copy(tuple.DataRoot[:], dataRoot)
+	return ABIAttestationProof{
+		TupleRootNonce: new(big.Int).SetUint64(nonce),
+		Tuple:          tuple,
+		Proof:          binaryProof,
+	}, nil
+}
+
+// ABI converts the proof into its ABI type.
+func (p Proof) ABI() (ABIBinaryMerkleProof, error) {
+	sideNodes := make([][32]byte, len(p.Aunts))
+	for i, aunt := range p.Aunts {
+		if len(aunt) != 32 {
+			return ABIBinaryMerkleProof{}, fmt.Errorf("invalid length %d of aunt %d, expected 32", len(aunt), i)
+		}
+		copy(sideNodes[i][:], aunt)
+	}
+	return ABIBinaryMerkleProof{
+		SideNodes: sideNodes,
+		Key:       big.NewInt(p.Index),
+		NumLeaves: big.NewInt(p.Total),
+	}, nil
+}
+
+// ABI converts the proof into its ABI type.
+func (p NMTProof) ABI() (ABINamespaceMerkleMultiproof, error) {
+	sideNodes := make([]ABINamespaceNode, len(p.Nodes))
+	for i, node := range p.Nodes {
+		n, err := NewABINamespaceNode(node)
+		if err != nil {
+			return ABINamespaceMerkleMultiproof{}, fmt.Errorf("node %d: %w", i, err)
+		}
+		sideNodes[i] = n
+	}
+	return ABINamespaceMerkleMultiproof{
+		BeginKey:  big.NewInt(int64(p.Start)),
+		EndKey:    big.NewInt(int64(p.End)),
+		SideNodes: sideNodes,
+	}, nil
+}
+
+// ABI converts the proof into its ABI type.
+func (rp RowProof) ABI() (ABIRowProof, error) {
+	if len(rp.Proofs) != len(rp.RowRoots) {
+		return ABIRowProof{}, fmt.Errorf("the number of proofs %d must equal the number of row roots %d", len(rp.Proofs), len(rp.RowRoots))
+	}
+	abiProof := ABIRowProof{
+		RowRoots:  make([]ABINamespaceNode, len(rp.RowRoots)),
+		RowProofs: make([]ABIBinaryMerkleProof, len(rp.Proofs)),
+	}
+	for i, rowRoot := range rp.RowRoots {
+		n, err := NewABINamespaceNode(rowRoot)
+		if err != nil {
+			return ABIRowProof{}, fmt.Errorf("row root %d: %w", i, err)
+		}
+		abiProof.RowRoots[i] = n
+		if rp.Proofs[i] == nil {
+			return ABIRowProof{}, fmt.Errorf("proof of row root %d is nil", i)
+		}
+		p, err := rp.Proofs[i].ABI()
+		if err != nil {
+			return ABIRowProof{}, fmt.Errorf("proof of row root %d: %w", i, err)
+		}
+		abiProof.RowProofs[i] = p
+	}
+	return abiProof, nil
+}
+
+// ABI converts the share proof into its ABI type given the proof that the data
+// root it proves the shares to was committed to by the Blobstream contract.
+func (sp ShareProof) ABI(attestationProof ABIAttestationProof) (ABISharesProof, error) {
+	if sp.RowProof == nil {
+		return ABISharesProof{}, errors.New("missing row proof")
+	}
+	if sp.NamespaceVersion > math.MaxUint8 {
+		return ABISharesProof{}, fmt.Errorf("invalid namespace version %d", sp.NamespaceVersion)
+	}
+	namespace, err := abiNamespaceFromBytes(append([]byte{uint8(sp.NamespaceVersion)}, sp.NamespaceId...))
+	if err != nil {
+		return ABISharesProof{}, err
+	}
+	shareProofs := make([]ABINamespaceMerkleMultiproof, len(sp.ShareProofs))
+	for i, proof := range sp.ShareProofs {
+		if proof == nil {
+			return ABISharesProof{}, fmt.Errorf("share proof %d is nil", i)
+		}
+		p, err := proof.ABI()
+		if err != nil {
+			return ABISharesProof{}, fmt.Errorf("share proof %d: %w", i, err)
+		}
+		shareProofs[i] = p
+	}
+	rowProof, err := sp.RowProof.ABI()
+	if err != nil {
+		return ABISharesProof{}, err
+	}
+	return ABISharesProof{
+		Data:             sp.Data,
+		ShareProofs:      shareProofs,
+		Namespace:        namespace,
+		RowRoots:         rowProof.RowRoots,
+		RowProofs:        rowProof.RowProofs,
+		AttestationProof: attestationProof,
+	}, nil
+}
+
+// Encode returns the ABI encoding of the namespace.
+func (n ABINamespace) Encode() ([]byte, error) {
+	return abi.Arguments{{Type: namespaceABIType}}.Pack(n)
+}
+
+// Encode returns the ABI encoding of the namespace node.
+func (n ABINamespaceNode) Encode() ([]byte, error) {
+	return abi.Arguments{{Type: namespaceNodeABIType}}.Pack(n)
+}
+
+// Encode returns the ABI encoding of the proof.
+func (p ABINamespaceMerkleMultiproof) Encode() ([]byte, error) {
+	return abi.Arguments{{Type: namespaceMerkleMultiproofABIType}}.Pack(p)
+}
+
+// Encode returns the ABI encoding of the proof.
+func (p ABIBinaryMerkleProof) Encode() ([]byte, error) {
+	return abi.Arguments{{Type: binaryMerkleProofABIType}}.Pack(p)
+}
+
+// Encode returns the ABI encoding of the proof.
+func (p ABIAttestationProof) Encode() ([]byte, error) {
+	return abi.Arguments{{Type: attestationProofABIType}}.Pack(p)
+}
+
+// Encode returns the ABI encoding of the row roots followed by the proofs of the
+// row roots, which is how they are passed to
+// DAVerifier.verifyMultiRowRootsToDataRootTupleRoot.
+func (p ABIRowProof) Encode() ([]byte, error) {
+	return abi.Arguments{{Type: namespaceNodesABIType}, {Type: binaryMerkleProofsABIType}}.Pack(p.RowRoots, p.RowProofs)
+}
+
+// Encode returns the ABI encoding of the proof.
+func (p ABISharesProof) Encode() ([]byte, error) {
+	return abi.Arguments{{Type: sharesProofABIType}}.Pack(p)
+}
+
+// EncodeWithRoot returns the ABI encoding of the proof followed by the data
+// root.
+func (p ABISharesProof) EncodeWithRoot() ([]byte, error) {
+	return abi.Arguments{{Type: sharesProofABIType}, {Type: bytes32ABIType}}.Pack(p, p.AttestationProof.Tuple.DataRoot)
+}
+
+// EncodeWithBridge returns the ABI encoding of the Blobstream contract at the
+// bridge address followed by the proof and the data root, which is how they
+// are passed to DAVerifier.verifySharesToDataRootTupleRoot.
+func (p ABISharesProof) EncodeWithBridge(bridge common.Address) ([]byte, error) {
+	return abi.Arguments{{Type: addressABIType}, {Type: sharesProofABIType}, {Type: bytes32ABIType}}.Pack(bridge, p, p.AttestationProof.Tuple.DataRoot)
+}
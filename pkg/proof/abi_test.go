@@ -0,0 +1,224 @@
+package proof_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	"github.com/celestiaorg/celestia-app/pkg/proof"
+	"github.com/celestiaorg/celestia-app/test/util/blobfactory"
+	"github.com/celestiaorg/celestia-app/test/util/testfactory"
+	"github.com/celestiaorg/celestia-app/test/util/testnode"
+	appns "github.com/celestiaorg/go-square/namespace"
+	"github.com/celestiaorg/go-square/square"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestABINamespaceEncode(t *testing.T) {
+	ns := appns.MustNewV0(bytes.Repeat([]byte{1}, appns.NamespaceVersionZeroIDSize))
+	abiNs, err := proof.NewABINamespace(ns)
+	require.NoError(t, err)
+	encoded, err := abiNs.Encode()
+	require.NoError(t, err)
+
+	// fixed size byte arrays are right padded to 32 bytes
+	want := make([]byte, 64)
+	want[0] = ns.Version
+	copy(want[32:], ns.ID)
+	require.Equal(t, want, encoded)
+}
+
+func TestShareProofABI(t *testing.T) {
+	ns := appns.MustNewV0(bytes.Repeat([]byte{1}, appns.NamespaceVersionZeroIDSize))
+	signer, err := testnode.NewOfflineSigner()
+	require.NoError(t, err)
+	blobTxs := blobfactory.RandBlobTxsWithNamespacesAndSigner(signer, []appns.Namespace{ns}, []int{5000})
+	txs := testfactory.GenerateRandomTxs(20, 500)
+	txs = append(txs, blobTxs...)
+	dataSquare, err := square.Construct(txs.ToSliceOfBytes(), appconsts.SquareSizeUpperBound(appconsts.LatestVersion), appconsts.SubtreeRootThreshold(appconsts.LatestVersion))
+	require.NoError(t, err)
+
+	rawTxs := txs.ToSliceOfBytes()
+	shareRange, err := square.BlobShareRange(rawTxs, len(rawTxs)-1, 0, appconsts.SquareSizeUpperBound(appconsts.LatestVersion), appconsts.SubtreeRootThreshold(appconsts.LatestVersion))
+	require.NoError(t, err)
+	shareProof, err := proof.NewShareInclusionProof(dataSquare, ns, shareRange)
+	require.NoError(t, err)
+
+	attestation, err := proof.NewABIAttestationProof(3, 10, bytes.Repeat([]byte{2}, 32), proof.Proof{
+		Total: 4,
+		Index: 1,
+		Aunts: [][]byte{bytes.Repeat([]byte{3}, 32), bytes.Repeat([]byte{4}, 32)},
+	})
+	require.NoError(t, err)
+	abiProof, err := shareProof.ABI(attestation)
+	require.NoError(t, err)
+	require.Equal(t, shareProof.Data, abiProof.Data)
+	require.Len(t, abiProof.ShareProofs, len(shareProof.ShareProofs))
+	require.Len(t, abiProof.RowRoots, len(shareProof.RowProof.RowRoots))
+	require.Equal(t, big.NewInt(shareProof.RowProof.Proofs[0].Index), abiProof.RowProofs[0].Key)
+	require.Equal(t, ns.ID, abiProof.Namespace.Id[:])
+
+	encoded, err := abiProof.Encode()
+	require.NoError(t, err)
+
+	// decode the encoding with the definition of the SharesProof struct of the
+	// DAVerifier contract
+	sharesProofType, err := abi.NewType("tuple", "", []abi.ArgumentMarshaling{
+		{Name: "data", Type: "bytes[]"},
+		{Name: "shareProofs", Type: "tuple[]", Components: []abi.ArgumentMarshaling{
+			{Name: "beginKey", Type: "uint256"},
+			{Name: "endKey", Type: "uint256"},
+			{Name: "sideNodes", Type: "tuple[]", Components: namespaceNodeComponents},
+		}},
+		{Name: "namespace", Type: "tuple", Components: namespaceComponents},
+		{Name: "rowRoots", Type: "tuple[]", Components: namespaceNodeComponents},
+		{Name: "rowProofs", Type: "tuple[]", Components: binaryMerkleProofComponents},
+		{Name: "attestationProof", Type: "tuple", Components: []abi.ArgumentMarshaling{
+			{Name: "tupleRootNonce", Type: "uint256"},
+			{Name: "tuple", Type: "tuple", Components: []abi.ArgumentMarshaling{
+				{Name: "height", Type: "uint256"},
+				{Name: "dataRoot", Type: "bytes32"},
+			}},
+			{Name: "proof", Type: "tuple", Components: binaryMerkleProofComponents},
+		}},
+	})
+	require.NoError(t, err)
+	decoded, err := abi.Arguments{{Type: sharesProofType}}.Unpack(encoded)
+	require.NoError(t, err)
+	got, ok := abi.ConvertType(decoded[0], new(proof.ABISharesProof)).(*proof.ABISharesProof)
+	require.True(t, ok)
+	require.Equal(t, abiProof.Data, got.Data)
+	require.Equal(t, abiProof.RowRoots, got.RowRoots)
+	require.Equal(t, abiProof.AttestationProof.Tuple.DataRoot, got.AttestationProof.Tuple.DataRoot)
+	reencoded, err := got.Encode()
+	require.NoError(t, err)
+	require.Equal(t, encoded, reencoded)
+
+	withRoot, err := abiProof.EncodeWithRoot()
+	require.NoError(t, err)
+	require.Len(t, withRoot, len(encoded)+32)
+
+	rowProof, err := shareProof.RowProof.ABI()
+	require.NoError(t, err)
+	_, err = rowProof.Encode()
+	require.NoError(t, err)
+
+	_, err = proof.NewABINamespaceNode(shareProof.RowProof.RowRoots[0][1:])
+	require.Error(t, err)
+}
+
+var (
+	namespaceComponents = []abi.ArgumentMarshaling{
+		{Name: "version", Type: "bytes1"},
+		{Name: "id", Type: "bytes28"},
+	}
+	namespaceNodeComponents = []abi.ArgumentMarshaling{
+		{Name: "min", Type: "tuple", Components: namespaceComponents},
+		{Name: "max", Type: "tuple", Components: namespaceComponents},
+		{Name: "digest", Type: "bytes32"},
+	}
+	binaryMerkleProofComponents = []abi.ArgumentMarshaling{
+		{Name: "sideNodes", Type: "bytes32[]"},
+		{Name: "key", Type: "uint256"},
+		{Name: "numLeaves", Type: "uint256"},
+	}
+)
+
+// TestShareProofABIGolden encodes the proof of the fixture of the DAVerifier
+// tests in github.com/celestiaorg/blobstream-contracts, which the contract
+// verifies against the data root tuple root of the fixture, as the arguments
+// of DAVerifier.verifySharesToDataRootTupleRoot.
+func TestShareProofABIGolden(t *testing.T) {
+	shareData := mustDecodeHex(t, "0000000000000000000000000000000000000000000000000000000001010000"+
+		"014500000026c3020a95010a92010a1c2f636f736d6f732e62616e6b2e763162"+
+		"657461312e4d736753656e6412720a2f63656c657374696131746b376c776a77"+
+		"336676616578657770687237687833333472766b67646b736d636537666b6612"+
+		"2f63656c65737469613167616b61646d63386a73667873646c676e6d64643867"+
+		"773736346739796165776e32726d386d1a0e0a04757469611206313030303030"+
+		"12670a500a460a1f2f636f736d6f732e63727970746f2e736563703235366b31"+
+		"2e5075624b657912230a2103f3e16481ff7c9c2a677f08a30a887e5f9c14313c"+
+		"b624b8c5f7f955d143c81d9212040a020801180112130a0d0a04757469611205"+
+		"323230303010d0e80c1a4068f074601f1bb923f6d6e69d2e3fc3af145c9252ec"+
+		"eeb0ac4fba9f661ca0428326f0080478cc969129c0074c3d97ae925de34c5f9d"+
+		"98a458cd47a565a2bb08cc000000000000000000000000000000000000000000"+
+		strings.Repeat("00", 4*32))
+	rowRoot := mustDecodeHex(t, "0000000000000000000000000000000000000000000000000000000001"+
+		"0000000000000000000000000000000000000000000000000000000001"+
+		"787bf77b567506b6e1d0048bfd89edd352a4fbc102e62f07cc9fe6b4cbe5ee69")
+	rowLeafHash := sha256.Sum256(append([]byte{0}, rowRoot...))
+	dataRoot := mustDecodeHex(t, "55cfc29fc0cd263906122d5cb859091224495b141fc0c51529612d7ab8962950")
+
+	shareProof := proof.ShareProof{
+		Data: [][]byte{shareData},
+		ShareProofs: []*proof.NMTProof{{
+			Start: 0,
+			End:   1,
+			Nodes: [][]byte{mustDecodeHex(t, strings.Repeat("ff", 2*29)+"0ec8148c743a4a4db384f40f487cae2fd1ca0d18442d1f162916bdf1cc61b679")},
+		}},
+		NamespaceId:      mustDecodeHex(t, "00000000000000000000000000000000000000000000000000000001"),
+		NamespaceVersion: 0,
+		RowProof: &proof.RowProof{
+			RowRoots: [][]byte{rowRoot},
+			Proofs: []*proof.Proof{{
+				Total:    4,
+				Index:    0,
+				LeafHash: rowLeafHash[:],
+				Aunts: [][]byte{
+					mustDecodeHex(t, "5bc0cf3322dd5c9141a2dcd76947882351690c9aec61015802efc6742992643f"),
+					mustDecodeHex(t, "ff576381b02abadc50e414f6b4efcae31091cd40a5aba75f56be52d1bb2efcae"),
+				},
+			}},
+			StartRow: 0,
+			EndRow:   0,
+		},
+	}
+	require.NoError(t, shareProof.Validate(dataRoot))
+
+	// the data root tuple is proven to the data root tuple root of the fixture
+	tuple, err := abi.Arguments{{Type: mustABIType(t, "uint256")}, {Type: mustABIType(t, "bytes32")}}.Pack(big.NewInt(3), [32]byte(dataRoot))
+	require.NoError(t, err)
+	tupleLeafHash := sha256.Sum256(append([]byte{0}, tuple...))
+	tupleProof := proof.Proof{
+		Total:    4,
+		Index:    2,
+		LeafHash: tupleLeafHash[:],
+		Aunts: [][]byte{
+			mustDecodeHex(t, "b5d4d27ec6b206a205bf09dde3371ffba62e5b53d27bbec4255b7f4f27ef5d90"),
+			mustDecodeHex(t, "406e22ba94989ca721453057a1391fc531edb342c86a0ab4cc722276b54036ec"),
+		},
+	}
+	require.NoError(t, tupleProof.Verify(mustDecodeHex(t, "f89859a09c0f2b1bbb039618d0fe60432b8c247f7ccde97814655f2acffb3434"), tuple))
+
+	attestation, err := proof.NewABIAttestationProof(2, 3, dataRoot, tupleProof)
+	require.NoError(t, err)
+	abiProof, err := shareProof.ABI(attestation)
+	require.NoError(t, err)
+	// the bridge is the address of the first contract deployed by the
+	// default account of a local development chain
+	encoded, err := abiProof.EncodeWithBridge(common.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3"))
+	require.NoError(t, err)
+
+	golden, err := os.ReadFile(filepath.Join("testdata", "verify_shares_calldata.hex"))
+	require.NoError(t, err)
+	require.Equal(t, strings.TrimSpace(string(golden)), hex.EncodeToString(encoded))
+}
+
+func mustABIType(t *testing.T, typ string) abi.Type {
+	abiType, err := abi.NewType(typ, "", nil)
+	require.NoError(t, err)
+	return abiType
+}
+
+func mustDecodeHex(t *testing.T, s string) []byte {
+	bz, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	return bz
+}
@@ -0,0 +1,204 @@
+package proof
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	"github.com/celestiaorg/celestia-app/pkg/da"
+	"github.com/celestiaorg/celestia-app/pkg/inclusion"
+	"github.com/celestiaorg/go-square/merkle"
+	"github.com/celestiaorg/nmt"
+	"github.com/celestiaorg/rsmt2d"
+
+	appns "github.com/celestiaorg/go-square/namespace"
+	"github.com/celestiaorg/go-square/shares"
+	"github.com/celestiaorg/go-square/square"
+)
+
+// NewCommitmentProof returns a proof of the share commitment of the blob whose
+// shares are in the share range of the data square to the data root. The
+// subtree root threshold must be the one of the app version the square was
+// constructed with.
+func NewCommitmentProof(dataSquare square.Square, shareRange shares.Range, subtreeRootThreshold int) (CommitmentProof, error) {
+	namespace, err := ParseNamespace(dataSquare, shareRange.Start, shareRange.End)
+	if err != nil {
+		return CommitmentProof{}, err
+	}
+	if shareRange.End == shareRange.Start {
+		return CommitmentProof{}, fmt.Errorf("share range [%d, %d) is empty", shareRange.Start, shareRange.End)
+	}
+	if namespace.IsReserved() {
+		return CommitmentProof{}, fmt.Errorf("shares of reserved namespace %x are not part of a blob", namespace.Bytes())
+	}
+
+	// extend the square with a cacher of the inner nodes of the rows so that
+	// the subtree roots can be looked up
+	squareSize := dataSquare.Size()
+	cacher := inclusion.NewSubtreeCacher(uint64(squareSize))
+	eds, err := rsmt2d.ComputeExtendedDataSquare(shares.ToBytes(dataSquare), appconsts.DefaultCodec(), cacher.Constructor)
+	if err != nil {
+		return CommitmentProof{}, err
+	}
+	dah, err := da.NewDataAvailabilityHeader(eds)
+	if err != nil {
+		return CommitmentProof{}, err
+	}
+	subtreeRoots, err := inclusion.GetSubtreeRoots(cacher, dah, shareRange.Start, shareRange.End-shareRange.Start, subtreeRootThreshold)
+	if err != nil {
+		return CommitmentProof{}, err
+	}
+
+	// the proofs of the shares of the blob prove the nodes next to its subtree
+	// roots
+	shareProof, err := newShareInclusionProof(eds, namespace, shareRange)
+	if err != nil {
+		return CommitmentProof{}, err
+	}
+
+	return CommitmentProof{
+		SubtreeRoots:      subtreeRoots,
+		SubtreeRootProofs: shareProof.ShareProofs,
+		NamespaceId:       namespace.ID,
+		NamespaceVersion:  uint32(namespace.Version),
+		RowProof:          shareProof.RowProof,
+	}, nil
+}
+
+// Validate checks that the subtree roots of the proof are the ones of a blob of
+// the namespace of the proof in the data square of the data root and that they
+// hash to the share commitment. The subtree root threshold must be the one of
+// the app version of the block. It returns nil if the proof is valid.
+func (m CommitmentProof) Validate(root, commitment []byte, subtreeRootThreshold int) error {
+	if m.NamespaceVersion > math.MaxUint8 {
+		return fmt.Errorf("invalid namespace version %d", m.NamespaceVersion)
+	}
+	namespace, err := appns.From(append([]byte{uint8(m.NamespaceVersion)}, m.NamespaceId...))
+	if err != nil {
+		return err
+	}
+
+	rp := m.RowProof
+	if rp == nil || len(rp.RowRoots) == 0 || len(rp.Proofs) == 0 {
+		return errors.New("missing row proof")
+	}
+	if err := rp.Validate(root); err != nil {
+		return err
+	}
+	if len(m.SubtreeRootProofs) != len(rp.RowRoots) {
+		return fmt.Errorf("the number of NMT proofs %d must equal the number of row roots %d", len(m.SubtreeRootProofs), len(rp.RowRoots))
+	}
+	for i, proof := range m.SubtreeRootProofs {
+		if proof == nil {
+			return fmt.Errorf("NMT proof of row %d is nil", i)
+		}
+	}
+	squareSize := int(rp.Proofs[0].Total / 4)
+	if squareSize == 0 || squareSize&(squareSize-1) != 0 {
+		return fmt.Errorf("invalid square size %d", squareSize)
+	}
+	shareRange, err := ShareProof{RowProof: rp, ShareProofs: m.SubtreeRootProofs}.shareRange()
+	if err != nil {
+		return err
+	}
+	if shareRange.End <= shareRange.Start {
+		return fmt.Errorf("share range [%d, %d) is empty", shareRange.Start, shareRange.End)
+	}
+
+	ranges := inclusion.CommitmentSubtreeRanges(squareSize, shareRange.Start, shareRange.End-shareRange.Start, subtreeRootThreshold)
+	if len(ranges) != len(m.SubtreeRoots) {
+		return fmt.Errorf("the number of subtree roots %d must equal %d", len(m.SubtreeRoots), len(ranges))
+	}
+	first, last := ranges[0], ranges[len(ranges)-1]
+	if first.Row*squareSize+first.Start != shareRange.Start || last.Row*squareSize+last.End != shareRange.End {
+		return fmt.Errorf("shares [%d, %d) are not the shares of a blob", shareRange.Start, shareRange.End)
+	}
+	hasher := nmt.NewNmtHasher(appconsts.NewBaseHashFunc(), appconsts.NamespaceSize, true)
+	for i, subtreeRoot := range m.SubtreeRoots {
+		if len(subtreeRoot) != hasher.Size() {
+			return fmt.Errorf("subtree root %d has invalid size %d", i, len(subtreeRoot))
+		}
+		minNs := nmt.MinNamespace(subtreeRoot, appconsts.NamespaceSize)
+		maxNs := nmt.MaxNamespace(subtreeRoot, appconsts.NamespaceSize)
+		if !bytes.Equal(minNs, namespace.Bytes()) || !bytes.Equal(maxNs, namespace.Bytes()) {
+			return fmt.Errorf("subtree root %d is not a subtree root of namespace %x", i, namespace.Bytes())
+		}
+	}
+
+	next := 0
+	for i, proof := range m.SubtreeRootProofs {
+		row := int(rp.StartRow) + i
+		end := next
+		for end < len(ranges) && ranges[end].Row == row {
+			end++
+		}
+		rowRoot, err := computeRowRoot(hasher, 2*squareSize, proof, ranges[next:end], m.SubtreeRoots[next:end])
+		if err != nil {
+			return fmt.Errorf("row %d: %w", row, err)
+		}
+		if !bytes.Equal(rowRoot, rp.RowRoots[i]) {
+			return fmt.Errorf("subtree roots of row %d don't hash to the row root", row)
+		}
+		next = end
+	}
+	if next != len(ranges) {
+		return errors.New("subtree roots are not in the rows of the proof")
+	}
+
+	if !bytes.Equal(merkle.HashFromByteSlices(m.SubtreeRoots), commitment) {
+		return errors.New("subtree roots don't hash to the share commitment")
+	}
+	return nil
+}
+
+// computeRowRoot computes the root of a row with the given number of leaves
+// from the subtree roots of the row and the nodes of the NMT proof, which are
+// the roots of the largest subtrees outside the range of the proof in order.
+// It returns an error if the subtree roots don't cover the range of the proof
+// exactly or if the proof has too few or too many nodes.
+func computeRowRoot(hasher *nmt.NmtHasher, width int, proof *NMTProof, ranges []inclusion.SubtreeRange, subtreeRoots [][]byte) ([]byte, error) {
+	nodes := proof.Nodes
+	var compute func(start, end int) ([]byte, error)
+	compute = func(start, end int) ([]byte, error) {
+		if end <= int(proof.Start) || start >= int(proof.End) {
+			if len(nodes) == 0 {
+				return nil, errors.New("NMT proof has too few nodes")
+			}
+			node := nodes[0]
+			nodes = nodes[1:]
+			return node, nil
+		}
+		if len(ranges) > 0 && ranges[0].Start == start && ranges[0].End == end {
+			subtreeRoot := subtreeRoots[0]
+			ranges, subtreeRoots = ranges[1:], subtreeRoots[1:]
+			return subtreeRoot, nil
+		}
+		if end-start == 1 {
+			return nil, fmt.Errorf("share %d of the proof is not covered by a subtree root", start)
+		}
+		k := (end - start) / 2
+		left, err := compute(start, start+k)
+		if err != nil {
+			return nil, err
+		}
+		right, err := compute(start+k, end)
+		if err != nil {
+			return nil, err
+		}
+		return hasher.HashNode(left, right)
+	}
+
+	root, err := compute(0, width)
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) != 0 {
+		return nil, errors.New("NMT proof has too many nodes")
+	}
+	if len(ranges) != 0 {
+		return nil, errors.New("subtree roots are outside of the range of the proof")
+	}
+	return root, nil
+}
@@ -0,0 +1,154 @@
+package proof
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	"github.com/celestiaorg/celestia-app/pkg/da"
+	"github.com/celestiaorg/celestia-app/pkg/wrapper"
+	"github.com/celestiaorg/go-square/merkle"
+	"github.com/celestiaorg/nmt"
+
+	appns "github.com/celestiaorg/go-square/namespace"
+	"github.com/celestiaorg/go-square/shares"
+	"github.com/celestiaorg/go-square/square"
+)
+
+// NewNamespaceAbsenceProof returns a proof that the namespace has no shares in
+// the data square. It returns an error if the namespace is in the square.
+func NewNamespaceAbsenceProof(dataSquare square.Square, namespace appns.Namespace) (NamespaceAbsenceProof, error) {
+	if namespace.IsParityShares() {
+		return NamespaceAbsenceProof{}, errors.New("the parity shares namespace can't be proven absent")
+	}
+	for i, share := range dataSquare {
+		ns, err := share.Namespace()
+		if err != nil {
+			return NamespaceAbsenceProof{}, err
+		}
+		if ns.Equals(namespace) {
+			return NamespaceAbsenceProof{}, fmt.Errorf("namespace %x is present at share %d", namespace.Bytes(), i)
+		}
+	}
+
+	eds, err := da.ExtendShares(shares.ToBytes(dataSquare))
+	if err != nil {
+		return NamespaceAbsenceProof{}, err
+	}
+	edsRowRoots, err := eds.RowRoots()
+	if err != nil {
+		return NamespaceAbsenceProof{}, err
+	}
+	edsColRoots, err := eds.ColRoots()
+	if err != nil {
+		return NamespaceAbsenceProof{}, err
+	}
+
+	// create the binary merkle inclusion proofs for the rows of the original
+	// data square to the data root
+	squareSize := dataSquare.Size()
+	_, allProofs := merkle.ProofsFromByteSlices(append(edsRowRoots, edsColRoots...))
+	rowProofs := make([]*Proof, squareSize)
+	nmtProofs := make([]*NMTProof, squareSize)
+	for i := 0; i < squareSize; i++ {
+		rowProofs[i] = &Proof{
+			Total:    allProofs[i].Total,
+			Index:    allProofs[i].Index,
+			LeafHash: allProofs[i].LeafHash,
+			Aunts:    allProofs[i].Aunts,
+		}
+
+		tree := wrapper.NewErasuredNamespacedMerkleTree(uint64(squareSize), uint(i))
+		for _, share := range eds.Row(uint(i)) {
+			if err := tree.Push(share); err != nil {
+				return NamespaceAbsenceProof{}, err
+			}
+		}
+		root, err := tree.Root()
+		if err != nil {
+			return NamespaceAbsenceProof{}, err
+		}
+		if !bytes.Equal(edsRowRoots[i], root) {
+			return NamespaceAbsenceProof{}, errors.New("eds row root is different than tree root")
+		}
+
+		proof, err := tree.ProveNamespace(namespace.Bytes())
+		if err != nil {
+			return NamespaceAbsenceProof{}, err
+		}
+		nmtProofs[i] = &NMTProof{
+			Start:    int32(proof.Start()),
+			End:      int32(proof.End()),
+			Nodes:    proof.Nodes(),
+			LeafHash: proof.LeafHash(),
+		}
+	}
+
+	return NamespaceAbsenceProof{
+		NamespaceId:      namespace.ID,
+		NamespaceVersion: uint32(namespace.Version),
+		RowProof: &RowProof{
+			RowRoots: edsRowRoots[:squareSize],
+			Proofs:   rowProofs,
+			StartRow: 0,
+			EndRow:   uint32(squareSize - 1),
+		},
+		NmtProofs: nmtProofs,
+	}, nil
+}
+
+// Validate checks that the proof covers all rows of the original data square
+// of the data root and that the namespace is absent from each of them. It
+// returns nil if the proof is valid.
+func (m NamespaceAbsenceProof) Validate(root []byte) error {
+	if m.NamespaceVersion > math.MaxUint8 {
+		return fmt.Errorf("invalid namespace version %d", m.NamespaceVersion)
+	}
+	namespace, err := appns.From(append([]byte{uint8(m.NamespaceVersion)}, m.NamespaceId...))
+	if err != nil {
+		return err
+	}
+	if namespace.IsParityShares() {
+		return errors.New("the parity shares namespace can't be proven absent")
+	}
+
+	rp := m.RowProof
+	if rp == nil || len(rp.RowRoots) == 0 || len(rp.Proofs) == 0 {
+		return errors.New("missing row proof")
+	}
+	if err := rp.Validate(root); err != nil {
+		return err
+	}
+	// the data root commits to the row and column roots of the extended data
+	// square, which has twice the width of the original data square.
+	squareSize := rp.Proofs[0].Total / 4
+	if rp.StartRow != 0 || int64(rp.EndRow)+1 != squareSize {
+		return fmt.Errorf("row proof must cover all %d rows of the original data square", squareSize)
+	}
+	for i, proof := range rp.Proofs {
+		if proof.Index != int64(i) || proof.Total != 4*squareSize {
+			return fmt.Errorf("row proof %d is not a proof of row %d", i, i)
+		}
+	}
+
+	if len(m.NmtProofs) != len(rp.RowRoots) {
+		return fmt.Errorf("the number of NMT proofs %d must equal the number of row roots %d", len(m.NmtProofs), len(rp.RowRoots))
+	}
+	for i, proof := range m.NmtProofs {
+		if proof == nil {
+			return fmt.Errorf("NMT proof of row %d is nil", i)
+		}
+		var nmtProof nmt.Proof
+		if len(proof.LeafHash) > 0 {
+			nmtProof = nmt.NewAbsenceProof(int(proof.Start), int(proof.End), proof.Nodes, proof.LeafHash, true)
+		} else {
+			nmtProof = nmt.NewInclusionProof(int(proof.Start), int(proof.End), proof.Nodes, true)
+		}
+		if !nmtProof.VerifyNamespace(appconsts.NewBaseHashFunc(), namespace.Bytes(), nil, rp.RowRoots[i]) {
+			return fmt.Errorf("NMT proof of row %d does not prove the absence of the namespace", i)
+		}
+	}
+	return nil
+}
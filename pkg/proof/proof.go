@@ -13,16 +13,24 @@ import (
 	appns "github.com/celestiaorg/go-square/namespace"
 	"github.com/celestiaorg/go-square/shares"
 	"github.com/celestiaorg/go-square/square"
+	"github.com/celestiaorg/rsmt2d"
 )
 
 // NewTxInclusionProof returns a new share inclusion proof for the given
 // transaction index.
 func NewTxInclusionProof(txs [][]byte, txIndex, appVersion uint64) (ShareProof, error) {
+	return NewTxInclusionProofWithSquareSize(txs, txIndex, appconsts.SquareSizeUpperBound(appVersion), appVersion)
+}
+
+// NewTxInclusionProofWithSquareSize is like NewTxInclusionProof but constructs
+// the data square with the given square size upper bound, which must be the
+// one the block was proposed with.
+func NewTxInclusionProofWithSquareSize(txs [][]byte, txIndex uint64, maxSquareSize int, appVersion uint64) (ShareProof, error) {
 	if txIndex >= uint64(len(txs)) {
 		return ShareProof{}, fmt.Errorf("txIndex %d out of bounds", txIndex)
 	}
 
-	builder, err := square.NewBuilder(appconsts.SquareSizeUpperBound(appVersion), appconsts.SubtreeRootThreshold(appVersion), txs...)
+	builder, err := square.NewBuilder(maxSquareSize, appconsts.SubtreeRootThreshold(appVersion), txs...)
 	if err != nil {
 		return ShareProof{}, err
 	}
@@ -57,16 +65,26 @@ func NewShareInclusionProof(
 	namespace appns.Namespace,
 	shareRange shares.Range,
 ) (ShareProof, error) {
-	squareSize := dataSquare.Size()
-	startRow := shareRange.Start / squareSize
-	endRow := (shareRange.End - 1) / squareSize
-	startLeaf := shareRange.Start % squareSize
-	endLeaf := (shareRange.End - 1) % squareSize
-
 	eds, err := da.ExtendShares(shares.ToBytes(dataSquare))
 	if err != nil {
 		return ShareProof{}, err
 	}
+	return newShareInclusionProof(eds, namespace, shareRange)
+}
+
+// newShareInclusionProof returns an NMT inclusion proof for a set of shares
+// belonging to the same namespace to the data root of the extended data
+// square.
+func newShareInclusionProof(
+	eds *rsmt2d.ExtendedDataSquare,
+	namespace appns.Namespace,
+	shareRange shares.Range,
+) (ShareProof, error) {
+	squareSize := int(eds.Width() / 2)
+	startRow := shareRange.Start / squareSize
+	endRow := (shareRange.End - 1) / squareSize
+	startLeaf := shareRange.Start % squareSize
+	endLeaf := (shareRange.End - 1) % squareSize
 
 	edsRowRoots, err := eds.RowRoots()
 	if err != nil {
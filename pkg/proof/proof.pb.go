@@ -100,6 +100,236 @@ func (m *ShareProof) GetNamespaceVersion() uint32 {
 	return 0
 }
 
+// ShareRangeProof proves a range of shares that may span multiple namespaces
+// to the data root. It contains a ShareProof for the shares of each namespace
+// in the range, in the order of the shares.
+type ShareRangeProof struct {
+	// Start is the index of the first share of the range.
+	Start uint32 `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`
+	// End is the index after the last share of the range.
+	End uint32 `protobuf:"varint,2,opt,name=end,proto3" json:"end,omitempty"`
+	// ShareProofs are the proofs of the shares of each namespace in the range.
+	ShareProofs []*ShareProof `protobuf:"bytes,3,rep,name=share_proofs,json=shareProofs,proto3" json:"share_proofs,omitempty"`
+}
+
+func (m *ShareRangeProof) Reset()         { *m = ShareRangeProof{} }
+func (m *ShareRangeProof) String() string { return proto.CompactTextString(m) }
+func (*ShareRangeProof) ProtoMessage()    {}
+func (*ShareRangeProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e53d87d8fb5ec353, []int{1}
+}
+func (m *ShareRangeProof) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ShareRangeProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ShareRangeProof.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ShareRangeProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ShareRangeProof.Merge(m, src)
+}
+func (m *ShareRangeProof) XXX_Size() int {
+	return m.Size()
+}
+func (m *ShareRangeProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_ShareRangeProof.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ShareRangeProof proto.InternalMessageInfo
+
+func (m *ShareRangeProof) GetStart() uint32 {
+	if m != nil {
+		return m.Start
+	}
+	return 0
+}
+
+func (m *ShareRangeProof) GetEnd() uint32 {
+	if m != nil {
+		return m.End
+	}
+	return 0
+}
+
+func (m *ShareRangeProof) GetShareProofs() []*ShareProof {
+	if m != nil {
+		return m.ShareProofs
+	}
+	return nil
+}
+
+// NamespaceAbsenceProof proves that a namespace has no shares in a data square.
+// It proves the roots of all rows of the original data square to the data root
+// and contains an NMT proof of the absence of the namespace for each of these
+// rows. The rows of the extended half of the square only contain parity shares
+// and so never contain the namespace.
+type NamespaceAbsenceProof struct {
+	NamespaceId      []byte `protobuf:"bytes,1,opt,name=namespace_id,json=namespaceId,proto3" json:"namespace_id,omitempty"`
+	NamespaceVersion uint32 `protobuf:"varint,2,opt,name=namespace_version,json=namespaceVersion,proto3" json:"namespace_version,omitempty"`
+	// RowProof proves the roots of all rows of the original data square.
+	RowProof *RowProof `protobuf:"bytes,3,opt,name=row_proof,json=rowProof,proto3" json:"row_proof,omitempty"`
+	// NmtProofs prove the absence of the namespace in each row. A proof is
+	// empty if the namespace is outside the namespace range of the row.
+	NmtProofs []*NMTProof `protobuf:"bytes,4,rep,name=nmt_proofs,json=nmtProofs,proto3" json:"nmt_proofs,omitempty"`
+}
+
+func (m *NamespaceAbsenceProof) Reset()         { *m = NamespaceAbsenceProof{} }
+func (m *NamespaceAbsenceProof) String() string { return proto.CompactTextString(m) }
+func (*NamespaceAbsenceProof) ProtoMessage()    {}
+func (*NamespaceAbsenceProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e53d87d8fb5ec353, []int{2}
+}
+func (m *NamespaceAbsenceProof) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *NamespaceAbsenceProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_NamespaceAbsenceProof.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *NamespaceAbsenceProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NamespaceAbsenceProof.Merge(m, src)
+}
+func (m *NamespaceAbsenceProof) XXX_Size() int {
+	return m.Size()
+}
+func (m *NamespaceAbsenceProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_NamespaceAbsenceProof.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NamespaceAbsenceProof proto.InternalMessageInfo
+
+func (m *NamespaceAbsenceProof) GetNamespaceId() []byte {
+	if m != nil {
+		return m.NamespaceId
+	}
+	return nil
+}
+
+func (m *NamespaceAbsenceProof) GetNamespaceVersion() uint32 {
+	if m != nil {
+		return m.NamespaceVersion
+	}
+	return 0
+}
+
+func (m *NamespaceAbsenceProof) GetRowProof() *RowProof {
+	if m != nil {
+		return m.RowProof
+	}
+	return nil
+}
+
+func (m *NamespaceAbsenceProof) GetNmtProofs() []*NMTProof {
+	if m != nil {
+		return m.NmtProofs
+	}
+	return nil
+}
+
+// CommitmentProof proves that a blob with a given share commitment was
+// published in a data square. It contains the subtree roots that the share
+// commitment is computed from, NMT proofs of these subtree roots to the roots of
+// the rows they are in and the proofs of these rows to the data root. Unlike a
+// ShareProof, it doesn't contain the shares of the blob, so it can be verified
+// given only the share commitment of the blob.
+type CommitmentProof struct {
+	// SubtreeRoots are the subtree roots of the blob, in the order they are
+	// committed to by the share commitment.
+	SubtreeRoots [][]byte `protobuf:"bytes,1,rep,name=subtree_roots,json=subtreeRoots,proto3" json:"subtree_roots,omitempty"`
+	// SubtreeRootProofs are the NMT proofs of the shares of the blob in each row
+	// it spans. Their nodes together with the subtree roots of the row
+	// recompute the row root.
+	SubtreeRootProofs []*NMTProof `protobuf:"bytes,2,rep,name=subtree_root_proofs,json=subtreeRootProofs,proto3" json:"subtree_root_proofs,omitempty"`
+	NamespaceId       []byte      `protobuf:"bytes,3,opt,name=namespace_id,json=namespaceId,proto3" json:"namespace_id,omitempty"`
+	NamespaceVersion  uint32      `protobuf:"varint,4,opt,name=namespace_version,json=namespaceVersion,proto3" json:"namespace_version,omitempty"`
+	// RowProof proves the rows the blob spans to the data root.
+	RowProof *RowProof `protobuf:"bytes,5,opt,name=row_proof,json=rowProof,proto3" json:"row_proof,omitempty"`
+}
+
+func (m *CommitmentProof) Reset()         { *m = CommitmentProof{} }
+func (m *CommitmentProof) String() string { return proto.CompactTextString(m) }
+func (*CommitmentProof) ProtoMessage()    {}
+func (*CommitmentProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e53d87d8fb5ec353, []int{3}
+}
+func (m *CommitmentProof) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CommitmentProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CommitmentProof.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CommitmentProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommitmentProof.Merge(m, src)
+}
+func (m *CommitmentProof) XXX_Size() int {
+	return m.Size()
+}
+func (m *CommitmentProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommitmentProof.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommitmentProof proto.InternalMessageInfo
+
+func (m *CommitmentProof) GetSubtreeRoots() [][]byte {
+	if m != nil {
+		return m.SubtreeRoots
+	}
+	return nil
+}
+
+func (m *CommitmentProof) GetSubtreeRootProofs() []*NMTProof {
+	if m != nil {
+		return m.SubtreeRootProofs
+	}
+	return nil
+}
+
+func (m *CommitmentProof) GetNamespaceId() []byte {
+	if m != nil {
+		return m.NamespaceId
+	}
+	return nil
+}
+
+func (m *CommitmentProof) GetNamespaceVersion() uint32 {
+	if m != nil {
+		return m.NamespaceVersion
+	}
+	return 0
+}
+
+func (m *CommitmentProof) GetRowProof() *RowProof {
+	if m != nil {
+		return m.RowProof
+	}
+	return nil
+}
+
 // RowProof is a Merkle proof that a set of rows exist in a Merkle tree with a
 // given data root.
 type RowProof struct {
@@ -114,7 +344,7 @@ func (m *RowProof) Reset()         { *m = RowProof{} }
 func (m *RowProof) String() string { return proto.CompactTextString(m) }
 func (*RowProof) ProtoMessage()    {}
 func (*RowProof) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e53d87d8fb5ec353, []int{1}
+	return fileDescriptor_e53d87d8fb5ec353, []int{4}
 }
 func (m *RowProof) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -178,6 +408,84 @@ func (m *RowProof) GetEndRow() uint32 {
 	return 0
 }
 
+// RowMultiProof is a Merkle proof that a set of rows, which don't have to be
+// contiguous, exist in a Merkle tree with a given data root. Unlike a RowProof,
+// which contains an independent Merkle proof for each row, it contains each
+// inner node that is needed to recompute the root only once. The nodes are the
+// roots of the largest subtrees that contain none of the proven rows, in order
+// from left to right.
+type RowMultiProof struct {
+	// Total is the number of leaves of the Merkle tree.
+	Total int64 `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	// Indices are the indices of the proven rows in ascending order.
+	Indices  []int64  `protobuf:"varint,2,rep,packed,name=indices,proto3" json:"indices,omitempty"`
+	RowRoots [][]byte `protobuf:"bytes,3,rep,name=row_roots,json=rowRoots,proto3" json:"row_roots,omitempty"`
+	// Nodes are the roots of the largest subtrees that contain none of the
+	// proven rows, in order from left to right.
+	Nodes [][]byte `protobuf:"bytes,4,rep,name=nodes,proto3" json:"nodes,omitempty"`
+}
+
+func (m *RowMultiProof) Reset()         { *m = RowMultiProof{} }
+func (m *RowMultiProof) String() string { return proto.CompactTextString(m) }
+func (*RowMultiProof) ProtoMessage()    {}
+func (*RowMultiProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e53d87d8fb5ec353, []int{5}
+}
+func (m *RowMultiProof) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RowMultiProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RowMultiProof.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RowMultiProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RowMultiProof.Merge(m, src)
+}
+func (m *RowMultiProof) XXX_Size() int {
+	return m.Size()
+}
+func (m *RowMultiProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_RowMultiProof.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RowMultiProof proto.InternalMessageInfo
+
+func (m *RowMultiProof) GetTotal() int64 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+func (m *RowMultiProof) GetIndices() []int64 {
+	if m != nil {
+		return m.Indices
+	}
+	return nil
+}
+
+func (m *RowMultiProof) GetRowRoots() [][]byte {
+	if m != nil {
+		return m.RowRoots
+	}
+	return nil
+}
+
+func (m *RowMultiProof) GetNodes() [][]byte {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
 // NMTProof is a proof of a namespace.ID in an NMT.
 // In case this proof proves the absence of a namespace.ID
 // in a tree it also contains the leaf hashes of the range
@@ -204,7 +512,7 @@ func (m *NMTProof) Reset()         { *m = NMTProof{} }
 func (m *NMTProof) String() string { return proto.CompactTextString(m) }
 func (*NMTProof) ProtoMessage()    {}
 func (*NMTProof) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e53d87d8fb5ec353, []int{2}
+	return fileDescriptor_e53d87d8fb5ec353, []int{6}
 }
 func (m *NMTProof) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -273,7 +581,7 @@ func (m *Proof) Reset()         { *m = Proof{} }
 func (m *Proof) String() string { return proto.CompactTextString(m) }
 func (*Proof) ProtoMessage()    {}
 func (*Proof) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e53d87d8fb5ec353, []int{3}
+	return fileDescriptor_e53d87d8fb5ec353, []int{7}
 }
 func (m *Proof) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -332,7 +640,11 @@ func (m *Proof) GetAunts() [][]byte {
 
 func init() {
 	proto.RegisterType((*ShareProof)(nil), "celestia.core.v1.proof.ShareProof")
+	proto.RegisterType((*ShareRangeProof)(nil), "celestia.core.v1.proof.ShareRangeProof")
+	proto.RegisterType((*NamespaceAbsenceProof)(nil), "celestia.core.v1.proof.NamespaceAbsenceProof")
+	proto.RegisterType((*CommitmentProof)(nil), "celestia.core.v1.proof.CommitmentProof")
 	proto.RegisterType((*RowProof)(nil), "celestia.core.v1.proof.RowProof")
+	proto.RegisterType((*RowMultiProof)(nil), "celestia.core.v1.proof.RowMultiProof")
 	proto.RegisterType((*NMTProof)(nil), "celestia.core.v1.proof.NMTProof")
 	proto.RegisterType((*Proof)(nil), "celestia.core.v1.proof.Proof")
 }
@@ -342,35 +654,46 @@ func init() {
 }
 
 var fileDescriptor_e53d87d8fb5ec353 = []byte{
-	// 446 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x52, 0xc1, 0x8e, 0xd3, 0x30,
-	0x10, 0xad, 0x37, 0x6d, 0x09, 0x6e, 0x56, 0x5a, 0x2c, 0x04, 0x96, 0x10, 0x51, 0xc8, 0x29, 0x12,
-	0xda, 0x44, 0x0b, 0xe2, 0xc8, 0x05, 0x0e, 0xc0, 0x01, 0x84, 0x0c, 0xe2, 0xc0, 0xa5, 0xf2, 0x36,
-	0x6e, 0x13, 0xd1, 0xcd, 0x44, 0xb6, 0xb7, 0xe1, 0x33, 0xf8, 0x0c, 0x3e, 0x85, 0x63, 0x8f, 0x1c,
-	0x51, 0xfb, 0x0b, 0x7c, 0x00, 0xf2, 0xb8, 0x09, 0xaa, 0x04, 0x7b, 0xb1, 0xe6, 0xbd, 0x99, 0x79,
-	0x6f, 0x66, 0x64, 0x9a, 0x2e, 0xd4, 0x5a, 0x19, 0x5b, 0xcb, 0x62, 0x01, 0x5a, 0x15, 0x9b, 0x8b,
-	0xa2, 0xd5, 0x00, 0x4b, 0xff, 0xe6, 0xad, 0x06, 0x0b, 0xec, 0x5e, 0x5f, 0x93, 0xbb, 0x9a, 0x7c,
-	0x73, 0x91, 0x63, 0x36, 0xfd, 0x4d, 0x28, 0xfd, 0x50, 0x49, 0xad, 0xde, 0x3b, 0xc8, 0x18, 0x1d,
-	0x97, 0xd2, 0x4a, 0x4e, 0x92, 0x20, 0x8b, 0x04, 0xc6, 0xec, 0x25, 0x8d, 0x8c, 0xab, 0x98, 0x63,
-	0x87, 0xe1, 0x27, 0x49, 0x90, 0xcd, 0x9e, 0x24, 0xf9, 0xbf, 0x15, 0xf3, 0x77, 0x6f, 0x3f, 0xa2,
-	0x96, 0x98, 0x99, 0x41, 0xd7, 0xb0, 0x47, 0x34, 0x6a, 0xe4, 0x95, 0x32, 0xad, 0x5c, 0xa8, 0x79,
-	0x5d, 0xf2, 0x20, 0x21, 0x59, 0x24, 0x66, 0x03, 0xf7, 0xa6, 0x64, 0xcf, 0xe9, 0x6d, 0x0d, 0x9d,
-	0x77, 0xe1, 0xe3, 0x84, 0xdc, 0x64, 0x22, 0xa0, 0xf3, 0x26, 0xa1, 0x3e, 0x44, 0xec, 0x31, 0xbd,
-	0xf3, 0xd7, 0x61, 0xa3, 0xb4, 0xa9, 0xa1, 0xe1, 0x93, 0x84, 0x64, 0xa7, 0xe2, 0x6c, 0x48, 0x7c,
-	0xf2, 0x7c, 0xfa, 0x9d, 0xd0, 0xb0, 0xd7, 0x60, 0x0f, 0xbc, 0xb1, 0x06, 0xb0, 0xe6, 0xb0, 0xb9,
-	0x93, 0x15, 0x0e, 0xb3, 0x67, 0x74, 0x7a, 0xb4, 0xf7, 0xc3, 0xff, 0x8d, 0xe4, 0xe7, 0x39, 0x14,
-	0xbb, 0x43, 0x3a, 0xbd, 0xc3, 0x9e, 0x18, 0x3b, 0x1f, 0x63, 0xa5, 0xb6, 0x73, 0x0d, 0x1d, 0x2e,
-	0x78, 0x2a, 0x42, 0x24, 0x04, 0x74, 0xec, 0x3e, 0xbd, 0xa5, 0x9a, 0x12, 0x53, 0x7e, 0xe8, 0xa9,
-	0x6a, 0x4a, 0x01, 0x5d, 0xaa, 0x68, 0xd8, 0x9f, 0x94, 0xdd, 0xa5, 0x13, 0x6c, 0xe0, 0x24, 0x21,
-	0xd9, 0x44, 0x78, 0xc0, 0xce, 0x68, 0xa0, 0x9a, 0x92, 0x9f, 0x20, 0xe7, 0x42, 0x57, 0xd7, 0x40,
-	0xa9, 0x0c, 0x0f, 0x70, 0x1b, 0x0f, 0x9c, 0xff, 0x5a, 0xc9, 0xe5, 0xbc, 0x92, 0xa6, 0x42, 0xff,
-	0x48, 0x84, 0x8e, 0x78, 0x2d, 0x4d, 0x95, 0x2e, 0xe9, 0x64, 0xf0, 0xb0, 0x60, 0xe5, 0x1a, 0x3d,
-	0x02, 0xe1, 0x81, 0x63, 0xeb, 0xa6, 0x54, 0x5f, 0xd1, 0x25, 0x10, 0x1e, 0x1c, 0x2b, 0x06, 0xc7,
-	0x8a, 0xae, 0x45, 0x5e, 0x37, 0xd6, 0xf0, 0xb1, 0x1f, 0x02, 0xc1, 0x8b, 0x57, 0x3f, 0x76, 0x31,
-	0xd9, 0xee, 0x62, 0xf2, 0x6b, 0x17, 0x93, 0x6f, 0xfb, 0x78, 0xb4, 0xdd, 0xc7, 0xa3, 0x9f, 0xfb,
-	0x78, 0xf4, 0xf9, 0x7c, 0x55, 0xdb, 0xea, 0xfa, 0x32, 0x5f, 0xc0, 0x55, 0xd1, 0xdf, 0x18, 0xf4,
-	0x6a, 0x88, 0xcf, 0x65, 0xdb, 0x16, 0xed, 0x97, 0x95, 0xff, 0xd7, 0x97, 0x53, 0xfc, 0xd8, 0x4f,
-	0xff, 0x04, 0x00, 0x00, 0xff, 0xff, 0x1f, 0x27, 0x8a, 0x77, 0xfe, 0x02, 0x00, 0x00,
+	// 619 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x54, 0xc1, 0x6e, 0x13, 0x3d,
+	0x10, 0xae, 0xb3, 0xd9, 0x36, 0x9d, 0x6e, 0xd5, 0xd6, 0x7f, 0x7f, 0x58, 0x09, 0x11, 0x85, 0xe5,
+	0x12, 0x09, 0x75, 0xa3, 0x82, 0x38, 0x22, 0x04, 0x15, 0x02, 0x0e, 0xad, 0x2a, 0x83, 0x38, 0x70,
+	0x89, 0x9c, 0xac, 0x9b, 0xac, 0xc8, 0xda, 0x91, 0xed, 0x74, 0x39, 0xf2, 0x08, 0xdc, 0x78, 0x05,
+	0x1e, 0x85, 0x63, 0x8f, 0x1c, 0x51, 0x7b, 0xe0, 0x05, 0x78, 0x00, 0x64, 0x7b, 0x77, 0x9b, 0x2d,
+	0x69, 0xd5, 0x5c, 0x56, 0x9e, 0xf1, 0xf8, 0xfb, 0xfc, 0x7d, 0x9e, 0x59, 0x88, 0x86, 0x6c, 0xc2,
+	0x94, 0x4e, 0x69, 0x6f, 0x28, 0x24, 0xeb, 0x9d, 0xee, 0xf7, 0xa6, 0x52, 0x88, 0x13, 0xf7, 0x8d,
+	0xa7, 0x52, 0x68, 0x81, 0xef, 0x94, 0x35, 0xb1, 0xa9, 0x89, 0x4f, 0xf7, 0x63, 0xbb, 0x1b, 0xfd,
+	0x41, 0x00, 0xef, 0xc6, 0x54, 0xb2, 0x63, 0x13, 0x62, 0x0c, 0xcd, 0x84, 0x6a, 0x1a, 0xa2, 0x8e,
+	0xd7, 0x0d, 0x88, 0x5d, 0xe3, 0x03, 0x08, 0x94, 0xa9, 0xe8, 0xdb, 0x13, 0x2a, 0x6c, 0x74, 0xbc,
+	0xee, 0xc6, 0xe3, 0x4e, 0xbc, 0x18, 0x31, 0x3e, 0x3a, 0x7c, 0x6f, 0xb1, 0xc8, 0x86, 0xaa, 0x70,
+	0x15, 0x7e, 0x00, 0x01, 0xa7, 0x19, 0x53, 0x53, 0x3a, 0x64, 0xfd, 0x34, 0x09, 0xbd, 0x0e, 0xea,
+	0x06, 0x64, 0xa3, 0xca, 0xbd, 0x4d, 0xf0, 0x33, 0x58, 0x97, 0x22, 0x77, 0x2c, 0x61, 0xb3, 0x83,
+	0x6e, 0x22, 0x21, 0x22, 0x77, 0x24, 0x2d, 0x59, 0xac, 0xf0, 0x23, 0xd8, 0xb9, 0x64, 0x38, 0x65,
+	0x52, 0xa5, 0x82, 0x87, 0x7e, 0x07, 0x75, 0x37, 0xc9, 0x76, 0xb5, 0xf1, 0xc1, 0xe5, 0xa3, 0x2f,
+	0x08, 0xb6, 0xac, 0x6c, 0x42, 0xf9, 0xa8, 0xd0, 0xbe, 0x0b, 0xbe, 0xd2, 0x54, 0xea, 0x10, 0xd9,
+	0x43, 0x2e, 0xc0, 0xdb, 0xe0, 0x31, 0x9e, 0x84, 0x0d, 0x9b, 0x33, 0x4b, 0xfc, 0xea, 0x8a, 0x1f,
+	0x9e, 0xf5, 0x23, 0xba, 0xee, 0xaa, 0x97, 0xee, 0xd6, 0x1c, 0x89, 0x7e, 0x23, 0xf8, 0xff, 0xa8,
+	0xbc, 0xd7, 0x8b, 0x81, 0x62, 0x7c, 0x58, 0x5c, 0xe4, 0xaa, 0x57, 0xe8, 0x5f, 0xaf, 0x16, 0x8a,
+	0x6d, 0x2c, 0x16, 0x5b, 0x37, 0xd6, 0x5b, 0xda, 0xd8, 0xe7, 0x00, 0x3c, 0xd3, 0xa5, 0xda, 0xe6,
+	0x2d, 0x5f, 0x7f, 0x9d, 0x67, 0xba, 0x50, 0xfa, 0xad, 0x01, 0x5b, 0x07, 0x22, 0xcb, 0x52, 0x9d,
+	0x31, 0xee, 0x92, 0xf8, 0x21, 0x6c, 0xaa, 0xd9, 0x40, 0x4b, 0xc6, 0xfa, 0x52, 0x08, 0xad, 0x8a,
+	0x8e, 0x0b, 0x8a, 0x24, 0x31, 0x39, 0x7c, 0x0c, 0xff, 0xcd, 0x17, 0x2d, 0xdb, 0x80, 0x3b, 0x73,
+	0x60, 0xb7, 0x6f, 0xc3, 0x85, 0xd6, 0x36, 0x6f, 0x63, 0xad, 0xbf, 0xac, 0xb5, 0xd1, 0x77, 0x04,
+	0xad, 0x32, 0x8d, 0xef, 0x39, 0xac, 0x79, 0x3b, 0x4c, 0xa5, 0xb3, 0xe2, 0x29, 0xac, 0xd6, 0xd4,
+	0xdf, 0xbf, 0x8e, 0xc5, 0x51, 0x14, 0xc5, 0x66, 0x9e, 0x0d, 0x5e, 0xa1, 0xd3, 0xae, 0x0d, 0x8f,
+	0x6d, 0xed, 0xbe, 0x14, 0x79, 0x21, 0xac, 0x65, 0x13, 0x44, 0xe4, 0xf8, 0x2e, 0xac, 0x31, 0x9e,
+	0xd8, 0x2d, 0x37, 0x3b, 0xab, 0x8c, 0x27, 0x44, 0xe4, 0x91, 0x84, 0x4d, 0x22, 0xf2, 0xc3, 0xd9,
+	0x44, 0xa7, 0xd5, 0xb8, 0x68, 0xa1, 0xe9, 0xc4, 0xb6, 0xa7, 0x47, 0x5c, 0x80, 0x43, 0x58, 0x4b,
+	0x79, 0x92, 0x0e, 0x99, 0xbb, 0xa8, 0x47, 0xca, 0xb0, 0x2e, 0xcf, 0xbb, 0x22, 0x6f, 0x17, 0x7c,
+	0x2e, 0x12, 0xe6, 0xda, 0x2b, 0x20, 0x2e, 0x88, 0x18, 0xb4, 0xca, 0xc7, 0xac, 0x4f, 0xa7, 0xbf,
+	0x60, 0x3a, 0x7d, 0x37, 0x9d, 0x15, 0x92, 0x37, 0x87, 0x64, 0xc8, 0x27, 0x8c, 0x9e, 0xf4, 0xc7,
+	0x54, 0x8d, 0xad, 0xe6, 0x80, 0xb4, 0x4c, 0xe2, 0x0d, 0x55, 0xe3, 0xe8, 0x04, 0xfc, 0x9b, 0x24,
+	0xed, 0x82, 0x9f, 0xf2, 0x84, 0x7d, 0xb6, 0x2c, 0x1e, 0x71, 0x41, 0x1d, 0xd1, 0xab, 0x23, 0x9a,
+	0x23, 0x74, 0xc6, 0x75, 0x25, 0xc7, 0x06, 0x2f, 0x5f, 0xff, 0x38, 0x6f, 0xa3, 0xb3, 0xf3, 0x36,
+	0xfa, 0x75, 0xde, 0x46, 0x5f, 0x2f, 0xda, 0x2b, 0x67, 0x17, 0xed, 0x95, 0x9f, 0x17, 0xed, 0x95,
+	0x8f, 0x7b, 0xa3, 0x54, 0x8f, 0x67, 0x83, 0x78, 0x28, 0xb2, 0x5e, 0xf9, 0xae, 0x42, 0x8e, 0xaa,
+	0xf5, 0x1e, 0x9d, 0x4e, 0x7b, 0xd3, 0x4f, 0x23, 0xf7, 0x4b, 0x1f, 0xac, 0xda, 0x7f, 0xfa, 0x93,
+	0xbf, 0x03, 0x00, 0x0d, 0xc2, 0xb2, 0xe9, 0xf9, 0x05, 0x00, 0x00,
 }
 
 func (m *ShareProof) Marshal() (dAtA []byte, err error) {
@@ -443,7 +766,7 @@ func (m *ShareProof) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *RowProof) Marshal() (dAtA []byte, err error) {
+func (m *ShareRangeProof) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -453,37 +776,20 @@ func (m *RowProof) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *RowProof) MarshalTo(dAtA []byte) (int, error) {
+func (m *ShareRangeProof) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *RowProof) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *ShareRangeProof) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.EndRow != 0 {
-		i = encodeVarintProof(dAtA, i, uint64(m.EndRow))
-		i--
-		dAtA[i] = 0x28
-	}
-	if m.StartRow != 0 {
-		i = encodeVarintProof(dAtA, i, uint64(m.StartRow))
-		i--
-		dAtA[i] = 0x20
-	}
-	if len(m.Root) > 0 {
-		i -= len(m.Root)
-		copy(dAtA[i:], m.Root)
-		i = encodeVarintProof(dAtA, i, uint64(len(m.Root)))
-		i--
-		dAtA[i] = 0x1a
-	}
-	if len(m.Proofs) > 0 {
-		for iNdEx := len(m.Proofs) - 1; iNdEx >= 0; iNdEx-- {
+	if len(m.ShareProofs) > 0 {
+		for iNdEx := len(m.ShareProofs) - 1; iNdEx >= 0; iNdEx-- {
 			{
-				size, err := m.Proofs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				size, err := m.ShareProofs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
 				if err != nil {
 					return 0, err
 				}
@@ -491,22 +797,23 @@ func (m *RowProof) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 				i = encodeVarintProof(dAtA, i, uint64(size))
 			}
 			i--
-			dAtA[i] = 0x12
+			dAtA[i] = 0x1a
 		}
 	}
-	if len(m.RowRoots) > 0 {
-		for iNdEx := len(m.RowRoots) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.RowRoots[iNdEx])
-			copy(dAtA[i:], m.RowRoots[iNdEx])
-			i = encodeVarintProof(dAtA, i, uint64(len(m.RowRoots[iNdEx])))
-			i--
-			dAtA[i] = 0xa
-		}
+	if m.End != 0 {
+		i = encodeVarintProof(dAtA, i, uint64(m.End))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Start != 0 {
+		i = encodeVarintProof(dAtA, i, uint64(m.Start))
+		i--
+		dAtA[i] = 0x8
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *NMTProof) Marshal() (dAtA []byte, err error) {
+func (m *NamespaceAbsenceProof) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -516,37 +823,296 @@ func (m *NMTProof) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *NMTProof) MarshalTo(dAtA []byte) (int, error) {
+func (m *NamespaceAbsenceProof) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *NMTProof) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *NamespaceAbsenceProof) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.LeafHash) > 0 {
-		i -= len(m.LeafHash)
-		copy(dAtA[i:], m.LeafHash)
-		i = encodeVarintProof(dAtA, i, uint64(len(m.LeafHash)))
-		i--
-		dAtA[i] = 0x22
-	}
-	if len(m.Nodes) > 0 {
-		for iNdEx := len(m.Nodes) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.Nodes[iNdEx])
-			copy(dAtA[i:], m.Nodes[iNdEx])
-			i = encodeVarintProof(dAtA, i, uint64(len(m.Nodes[iNdEx])))
+	if len(m.NmtProofs) > 0 {
+		for iNdEx := len(m.NmtProofs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.NmtProofs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintProof(dAtA, i, uint64(size))
+			}
 			i--
-			dAtA[i] = 0x1a
+			dAtA[i] = 0x22
 		}
 	}
-	if m.End != 0 {
-		i = encodeVarintProof(dAtA, i, uint64(m.End))
-		i--
-		dAtA[i] = 0x10
-	}
+	if m.RowProof != nil {
+		{
+			size, err := m.RowProof.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintProof(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.NamespaceVersion != 0 {
+		i = encodeVarintProof(dAtA, i, uint64(m.NamespaceVersion))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.NamespaceId) > 0 {
+		i -= len(m.NamespaceId)
+		copy(dAtA[i:], m.NamespaceId)
+		i = encodeVarintProof(dAtA, i, uint64(len(m.NamespaceId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CommitmentProof) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CommitmentProof) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CommitmentProof) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.RowProof != nil {
+		{
+			size, err := m.RowProof.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintProof(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.NamespaceVersion != 0 {
+		i = encodeVarintProof(dAtA, i, uint64(m.NamespaceVersion))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.NamespaceId) > 0 {
+		i -= len(m.NamespaceId)
+		copy(dAtA[i:], m.NamespaceId)
+		i = encodeVarintProof(dAtA, i, uint64(len(m.NamespaceId)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.SubtreeRootProofs) > 0 {
+		for iNdEx := len(m.SubtreeRootProofs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.SubtreeRootProofs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintProof(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.SubtreeRoots) > 0 {
+		for iNdEx := len(m.SubtreeRoots) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.SubtreeRoots[iNdEx])
+			copy(dAtA[i:], m.SubtreeRoots[iNdEx])
+			i = encodeVarintProof(dAtA, i, uint64(len(m.SubtreeRoots[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RowProof) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RowProof) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RowProof) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.EndRow != 0 {
+		i = encodeVarintProof(dAtA, i, uint64(m.EndRow))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.StartRow != 0 {
+		i = encodeVarintProof(dAtA, i, uint64(m.StartRow))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Root) > 0 {
+		i -= len(m.Root)
+		copy(dAtA[i:], m.Root)
+		i = encodeVarintProof(dAtA, i, uint64(len(m.Root)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Proofs) > 0 {
+		for iNdEx := len(m.Proofs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Proofs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintProof(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.RowRoots) > 0 {
+		for iNdEx := len(m.RowRoots) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.RowRoots[iNdEx])
+			copy(dAtA[i:], m.RowRoots[iNdEx])
+			i = encodeVarintProof(dAtA, i, uint64(len(m.RowRoots[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RowMultiProof) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RowMultiProof) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RowMultiProof) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Nodes) > 0 {
+		for iNdEx := len(m.Nodes) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Nodes[iNdEx])
+			copy(dAtA[i:], m.Nodes[iNdEx])
+			i = encodeVarintProof(dAtA, i, uint64(len(m.Nodes[iNdEx])))
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.RowRoots) > 0 {
+		for iNdEx := len(m.RowRoots) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.RowRoots[iNdEx])
+			copy(dAtA[i:], m.RowRoots[iNdEx])
+			i = encodeVarintProof(dAtA, i, uint64(len(m.RowRoots[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.Indices) > 0 {
+		dAtA5 := make([]byte, len(m.Indices)*10)
+		var j4 int
+		for _, num1 := range m.Indices {
+			num := uint64(num1)
+			for num >= 1<<7 {
+				dAtA5[j4] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j4++
+			}
+			dAtA5[j4] = uint8(num)
+			j4++
+		}
+		i -= j4
+		copy(dAtA[i:], dAtA5[:j4])
+		i = encodeVarintProof(dAtA, i, uint64(j4))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Total != 0 {
+		i = encodeVarintProof(dAtA, i, uint64(m.Total))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *NMTProof) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *NMTProof) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *NMTProof) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.LeafHash) > 0 {
+		i -= len(m.LeafHash)
+		copy(dAtA[i:], m.LeafHash)
+		i = encodeVarintProof(dAtA, i, uint64(len(m.LeafHash)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Nodes) > 0 {
+		for iNdEx := len(m.Nodes) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Nodes[iNdEx])
+			copy(dAtA[i:], m.Nodes[iNdEx])
+			i = encodeVarintProof(dAtA, i, uint64(len(m.Nodes[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.End != 0 {
+		i = encodeVarintProof(dAtA, i, uint64(m.End))
+		i--
+		dAtA[i] = 0x10
+	}
 	if m.Start != 0 {
 		i = encodeVarintProof(dAtA, i, uint64(m.Start))
 		i--
@@ -647,37 +1213,147 @@ func (m *ShareProof) Size() (n int) {
 	return n
 }
 
-func (m *RowProof) Size() (n int) {
+func (m *ShareRangeProof) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if len(m.RowRoots) > 0 {
-		for _, b := range m.RowRoots {
-			l = len(b)
-			n += 1 + l + sovProof(uint64(l))
-		}
+	if m.Start != 0 {
+		n += 1 + sovProof(uint64(m.Start))
 	}
-	if len(m.Proofs) > 0 {
-		for _, e := range m.Proofs {
+	if m.End != 0 {
+		n += 1 + sovProof(uint64(m.End))
+	}
+	if len(m.ShareProofs) > 0 {
+		for _, e := range m.ShareProofs {
 			l = e.Size()
 			n += 1 + l + sovProof(uint64(l))
 		}
 	}
-	l = len(m.Root)
-	if l > 0 {
-		n += 1 + l + sovProof(uint64(l))
-	}
-	if m.StartRow != 0 {
-		n += 1 + sovProof(uint64(m.StartRow))
-	}
+	return n
+}
+
+func (m *NamespaceAbsenceProof) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.NamespaceId)
+	if l > 0 {
+		n += 1 + l + sovProof(uint64(l))
+	}
+	if m.NamespaceVersion != 0 {
+		n += 1 + sovProof(uint64(m.NamespaceVersion))
+	}
+	if m.RowProof != nil {
+		l = m.RowProof.Size()
+		n += 1 + l + sovProof(uint64(l))
+	}
+	if len(m.NmtProofs) > 0 {
+		for _, e := range m.NmtProofs {
+			l = e.Size()
+			n += 1 + l + sovProof(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *CommitmentProof) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.SubtreeRoots) > 0 {
+		for _, b := range m.SubtreeRoots {
+			l = len(b)
+			n += 1 + l + sovProof(uint64(l))
+		}
+	}
+	if len(m.SubtreeRootProofs) > 0 {
+		for _, e := range m.SubtreeRootProofs {
+			l = e.Size()
+			n += 1 + l + sovProof(uint64(l))
+		}
+	}
+	l = len(m.NamespaceId)
+	if l > 0 {
+		n += 1 + l + sovProof(uint64(l))
+	}
+	if m.NamespaceVersion != 0 {
+		n += 1 + sovProof(uint64(m.NamespaceVersion))
+	}
+	if m.RowProof != nil {
+		l = m.RowProof.Size()
+		n += 1 + l + sovProof(uint64(l))
+	}
+	return n
+}
+
+func (m *RowProof) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.RowRoots) > 0 {
+		for _, b := range m.RowRoots {
+			l = len(b)
+			n += 1 + l + sovProof(uint64(l))
+		}
+	}
+	if len(m.Proofs) > 0 {
+		for _, e := range m.Proofs {
+			l = e.Size()
+			n += 1 + l + sovProof(uint64(l))
+		}
+	}
+	l = len(m.Root)
+	if l > 0 {
+		n += 1 + l + sovProof(uint64(l))
+	}
+	if m.StartRow != 0 {
+		n += 1 + sovProof(uint64(m.StartRow))
+	}
 	if m.EndRow != 0 {
 		n += 1 + sovProof(uint64(m.EndRow))
 	}
 	return n
 }
 
+func (m *RowMultiProof) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Total != 0 {
+		n += 1 + sovProof(uint64(m.Total))
+	}
+	if len(m.Indices) > 0 {
+		l = 0
+		for _, e := range m.Indices {
+			l += sovProof(uint64(e))
+		}
+		n += 1 + sovProof(uint64(l)) + l
+	}
+	if len(m.RowRoots) > 0 {
+		for _, b := range m.RowRoots {
+			l = len(b)
+			n += 1 + l + sovProof(uint64(l))
+		}
+	}
+	if len(m.Nodes) > 0 {
+		for _, b := range m.Nodes {
+			l = len(b)
+			n += 1 + l + sovProof(uint64(l))
+		}
+	}
+	return n
+}
+
 func (m *NMTProof) Size() (n int) {
 	if m == nil {
 		return 0
@@ -696,45 +1372,545 @@ func (m *NMTProof) Size() (n int) {
 			n += 1 + l + sovProof(uint64(l))
 		}
 	}
-	l = len(m.LeafHash)
-	if l > 0 {
-		n += 1 + l + sovProof(uint64(l))
-	}
-	return n
-}
+	l = len(m.LeafHash)
+	if l > 0 {
+		n += 1 + l + sovProof(uint64(l))
+	}
+	return n
+}
+
+func (m *Proof) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Total != 0 {
+		n += 1 + sovProof(uint64(m.Total))
+	}
+	if m.Index != 0 {
+		n += 1 + sovProof(uint64(m.Index))
+	}
+	l = len(m.LeafHash)
+	if l > 0 {
+		n += 1 + l + sovProof(uint64(l))
+	}
+	if len(m.Aunts) > 0 {
+		for _, b := range m.Aunts {
+			l = len(b)
+			n += 1 + l + sovProof(uint64(l))
+		}
+	}
+	return n
+}
+
+func sovProof(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozProof(x uint64) (n int) {
+	return sovProof(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *ShareProof) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProof
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ShareProof: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ShareProof: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProof
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthProof
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProof
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Data = append(m.Data, make([]byte, postIndex-iNdEx))
+			copy(m.Data[len(m.Data)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShareProofs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProof
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProof
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProof
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ShareProofs = append(m.ShareProofs, &NMTProof{})
+			if err := m.ShareProofs[len(m.ShareProofs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NamespaceId", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProof
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthProof
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProof
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NamespaceId = append(m.NamespaceId[:0], dAtA[iNdEx:postIndex]...)
+			if m.NamespaceId == nil {
+				m.NamespaceId = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RowProof", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProof
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProof
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProof
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.RowProof == nil {
+				m.RowProof = &RowProof{}
+			}
+			if err := m.RowProof.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NamespaceVersion", wireType)
+			}
+			m.NamespaceVersion = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProof
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NamespaceVersion |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProof(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthProof
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ShareRangeProof) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProof
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ShareRangeProof: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ShareRangeProof: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Start", wireType)
+			}
+			m.Start = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProof
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Start |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field End", wireType)
+			}
+			m.End = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProof
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.End |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShareProofs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProof
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProof
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProof
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ShareProofs = append(m.ShareProofs, &ShareProof{})
+			if err := m.ShareProofs[len(m.ShareProofs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProof(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthProof
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *NamespaceAbsenceProof) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProof
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: NamespaceAbsenceProof: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: NamespaceAbsenceProof: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NamespaceId", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProof
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthProof
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProof
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NamespaceId = append(m.NamespaceId[:0], dAtA[iNdEx:postIndex]...)
+			if m.NamespaceId == nil {
+				m.NamespaceId = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NamespaceVersion", wireType)
+			}
+			m.NamespaceVersion = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProof
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NamespaceVersion |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RowProof", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProof
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProof
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProof
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.RowProof == nil {
+				m.RowProof = &RowProof{}
+			}
+			if err := m.RowProof.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NmtProofs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProof
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProof
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProof
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NmtProofs = append(m.NmtProofs, &NMTProof{})
+			if err := m.NmtProofs[len(m.NmtProofs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProof(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthProof
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
 
-func (m *Proof) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Total != 0 {
-		n += 1 + sovProof(uint64(m.Total))
-	}
-	if m.Index != 0 {
-		n += 1 + sovProof(uint64(m.Index))
-	}
-	l = len(m.LeafHash)
-	if l > 0 {
-		n += 1 + l + sovProof(uint64(l))
-	}
-	if len(m.Aunts) > 0 {
-		for _, b := range m.Aunts {
-			l = len(b)
-			n += 1 + l + sovProof(uint64(l))
-		}
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	return n
-}
-
-func sovProof(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozProof(x uint64) (n int) {
-	return sovProof(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	return nil
 }
-func (m *ShareProof) Unmarshal(dAtA []byte) error {
+func (m *CommitmentProof) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -757,15 +1933,15 @@ func (m *ShareProof) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ShareProof: wiretype end group for non-group")
+			return fmt.Errorf("proto: CommitmentProof: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ShareProof: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CommitmentProof: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SubtreeRoots", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -792,12 +1968,12 @@ func (m *ShareProof) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Data = append(m.Data, make([]byte, postIndex-iNdEx))
-			copy(m.Data[len(m.Data)-1], dAtA[iNdEx:postIndex])
+			m.SubtreeRoots = append(m.SubtreeRoots, make([]byte, postIndex-iNdEx))
+			copy(m.SubtreeRoots[len(m.SubtreeRoots)-1], dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ShareProofs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SubtreeRootProofs", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -824,8 +2000,8 @@ func (m *ShareProof) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ShareProofs = append(m.ShareProofs, &NMTProof{})
-			if err := m.ShareProofs[len(m.ShareProofs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.SubtreeRootProofs = append(m.SubtreeRootProofs, &NMTProof{})
+			if err := m.SubtreeRootProofs[len(m.SubtreeRootProofs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -864,6 +2040,25 @@ func (m *ShareProof) Unmarshal(dAtA []byte) error {
 			}
 			iNdEx = postIndex
 		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NamespaceVersion", wireType)
+			}
+			m.NamespaceVersion = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProof
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NamespaceVersion |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field RowProof", wireType)
 			}
@@ -899,25 +2094,6 @@ func (m *ShareProof) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
-		case 5:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NamespaceVersion", wireType)
-			}
-			m.NamespaceVersion = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowProof
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.NamespaceVersion |= uint32(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipProof(dAtA[iNdEx:])
@@ -1127,6 +2303,215 @@ func (m *RowProof) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *RowMultiProof) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProof
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RowMultiProof: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RowMultiProof: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Total", wireType)
+			}
+			m.Total = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProof
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Total |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType == 0 {
+				var v int64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowProof
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= int64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.Indices = append(m.Indices, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowProof
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthProof
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthProof
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.Indices) == 0 {
+					m.Indices = make([]int64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v int64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowProof
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= int64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Indices = append(m.Indices, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Indices", wireType)
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RowRoots", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProof
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthProof
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProof
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RowRoots = append(m.RowRoots, make([]byte, postIndex-iNdEx))
+			copy(m.RowRoots[len(m.RowRoots)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Nodes", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProof
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthProof
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProof
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Nodes = append(m.Nodes, make([]byte, postIndex-iNdEx))
+			copy(m.Nodes[len(m.Nodes)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProof(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthProof
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *NMTProof) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
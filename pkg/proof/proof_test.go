@@ -12,11 +12,16 @@ import (
 
 	"github.com/celestiaorg/celestia-app/pkg/da"
 	"github.com/celestiaorg/celestia-app/pkg/proof"
+	"github.com/celestiaorg/celestia-app/pkg/wrapper"
 	"github.com/celestiaorg/go-square/square"
 
 	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	"github.com/celestiaorg/go-square/blob"
+	"github.com/celestiaorg/go-square/inclusion"
+	"github.com/celestiaorg/go-square/merkle"
 	appns "github.com/celestiaorg/go-square/namespace"
 	"github.com/celestiaorg/go-square/shares"
+	gogoproto "github.com/gogo/protobuf/proto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -256,3 +261,388 @@ func TestAllSharesInclusionProof(t *testing.T) {
 	require.NoError(t, err)
 	assert.NoError(t, proof.Validate(dataRoot))
 }
+
+func TestNewShareRangeProof(t *testing.T) {
+	ns1 := appns.MustNewV0(bytes.Repeat([]byte{1}, appns.NamespaceVersionZeroIDSize))
+	ns2 := appns.MustNewV0(bytes.Repeat([]byte{2}, appns.NamespaceVersionZeroIDSize))
+
+	signer, err := testnode.NewOfflineSigner()
+	require.NoError(t, err)
+	blobTxs := blobfactory.RandBlobTxsWithNamespacesAndSigner(signer, []appns.Namespace{ns1, ns2}, []int{500, 5000})
+	txs := testfactory.GenerateRandomTxs(50, 500)
+	txs = append(txs, blobTxs...)
+
+	dataSquare, err := square.Construct(txs.ToSliceOfBytes(), appconsts.SquareSizeUpperBound(appconsts.LatestVersion), appconsts.SubtreeRootThreshold(appconsts.LatestVersion))
+	require.NoError(t, err)
+	eds, err := da.ExtendShares(shares.ToBytes(dataSquare))
+	require.NoError(t, err)
+	dah, err := da.NewDataAvailabilityHeader(eds)
+	require.NoError(t, err)
+	dataRoot := dah.Hash()
+
+	// the range spans the tx, PFB and blob namespaces over multiple rows
+	shareRange := shares.NewRange(40, len(dataSquare))
+	rangeProof, err := proof.NewShareRangeProof(dataSquare, shareRange)
+	require.NoError(t, err)
+	require.NoError(t, rangeProof.Validate(dataRoot))
+	require.Equal(t, shares.ToBytes(dataSquare[shareRange.Start:shareRange.End]), rangeProof.Data())
+	require.Greater(t, len(rangeProof.ShareProofs), 3)
+
+	bz, err := gogoproto.Marshal(&rangeProof)
+	require.NoError(t, err)
+	var decoded proof.ShareRangeProof
+	require.NoError(t, gogoproto.Unmarshal(bz, &decoded))
+	require.NoError(t, decoded.Validate(dataRoot))
+
+	// a proof of a single namespace is a valid range proof
+	singleNamespace, err := proof.NewShareRangeProof(dataSquare, shares.NewRange(0, 10))
+	require.NoError(t, err)
+	require.Len(t, singleNamespace.ShareProofs, 1)
+	require.NoError(t, singleNamespace.Validate(dataRoot))
+
+	_, err = proof.NewShareRangeProof(dataSquare, shares.NewRange(10, 10))
+	require.Error(t, err)
+	_, err = proof.NewShareRangeProof(dataSquare, shares.NewRange(0, len(dataSquare)+1))
+	require.Error(t, err)
+
+	invalid := []struct {
+		name   string
+		modify func(p *proof.ShareRangeProof)
+	}{
+		{"missing namespace", func(p *proof.ShareRangeProof) {
+			p.ShareProofs = append(p.ShareProofs[:1], p.ShareProofs[2:]...)
+		}},
+		{"namespaces out of order", func(p *proof.ShareRangeProof) {
+			p.ShareProofs[0], p.ShareProofs[1] = p.ShareProofs[1], p.ShareProofs[0]
+		}},
+		{"wrong start row", func(p *proof.ShareRangeProof) {
+			last := p.ShareProofs[len(p.ShareProofs)-1]
+			last.RowProof.StartRow++
+			last.RowProof.EndRow++
+		}},
+		{"wrong start", func(p *proof.ShareRangeProof) { p.Start++ }},
+		{"wrong end", func(p *proof.ShareRangeProof) { p.End-- }},
+		{"no share proofs", func(p *proof.ShareRangeProof) { p.ShareProofs = nil }},
+	}
+	for _, tc := range invalid {
+		t.Run(tc.name, func(t *testing.T) {
+			var p proof.ShareRangeProof
+			require.NoError(t, gogoproto.Unmarshal(bz, &p))
+			tc.modify(&p)
+			require.Error(t, p.Validate(dataRoot))
+		})
+	}
+	require.Error(t, rangeProof.Validate(bytes.Repeat([]byte{1}, 32)))
+
+	// a share proof without rows passes its own validation
+	noRows := proof.ShareRangeProof{Start: 0, End: 1, ShareProofs: []*proof.ShareProof{{
+		Data:     [][]byte{},
+		RowProof: &proof.RowProof{StartRow: 1, EndRow: 0},
+	}}}
+	require.Error(t, noRows.Validate(dataRoot))
+
+	// a share proof without a row proof is rejected instead of panicking
+	nilRowProof := proof.ShareRangeProof{Start: 0, End: 1, ShareProofs: []*proof.ShareProof{{
+		Data:        [][]byte{shares.ToBytes(dataSquare[:1])[0]},
+		ShareProofs: []*proof.NMTProof{{Start: 0, End: 1}},
+	}}}
+	require.Error(t, nilRowProof.Validate(dataRoot))
+
+	// a valid proof of the first half of the first parity row can't be passed
+	// off as a range of the original data square
+	squareSize := dataSquare.Size()
+	tree := wrapper.NewErasuredNamespacedMerkleTree(uint64(squareSize), uint(squareSize))
+	parityRow := eds.Row(uint(squareSize))
+	for _, share := range parityRow {
+		require.NoError(t, tree.Push(share))
+	}
+	nmtProof, err := tree.ProveRange(0, squareSize)
+	require.NoError(t, err)
+	rowRoots, err := eds.RowRoots()
+	require.NoError(t, err)
+	colRoots, err := eds.ColRoots()
+	require.NoError(t, err)
+	_, rowProofs := merkle.ProofsFromByteSlices(append(rowRoots, colRoots...))
+	parityProof := &proof.ShareProof{
+		Data:             parityRow[:squareSize],
+		NamespaceId:      appns.ParitySharesNamespace.ID,
+		NamespaceVersion: uint32(appns.ParitySharesNamespace.Version),
+		ShareProofs: []*proof.NMTProof{{
+			Start:    int32(nmtProof.Start()),
+			End:      int32(nmtProof.End()),
+			Nodes:    nmtProof.Nodes(),
+			LeafHash: nmtProof.LeafHash(),
+		}},
+		RowProof: &proof.RowProof{
+			RowRoots: [][]byte{rowRoots[squareSize]},
+			Proofs: []*proof.Proof{{
+				Total:    rowProofs[squareSize].Total,
+				Index:    rowProofs[squareSize].Index,
+				LeafHash: rowProofs[squareSize].LeafHash,
+				Aunts:    rowProofs[squareSize].Aunts,
+			}},
+			StartRow: uint32(squareSize),
+			EndRow:   uint32(squareSize),
+		},
+	}
+	require.NoError(t, parityProof.Validate(dataRoot))
+	parityRange := proof.ShareRangeProof{
+		Start:       uint32(squareSize * squareSize),
+		End:         uint32(squareSize*squareSize + squareSize),
+		ShareProofs: []*proof.ShareProof{parityProof},
+	}
+	require.Error(t, parityRange.Validate(dataRoot))
+}
+
+func TestNewNamespaceAbsenceProof(t *testing.T) {
+	ns1 := appns.MustNewV0(bytes.Repeat([]byte{1}, appns.NamespaceVersionZeroIDSize))
+	ns2 := appns.MustNewV0(bytes.Repeat([]byte{2}, appns.NamespaceVersionZeroIDSize))
+	ns3 := appns.MustNewV0(bytes.Repeat([]byte{3}, appns.NamespaceVersionZeroIDSize))
+	ns4 := appns.MustNewV0(bytes.Repeat([]byte{4}, appns.NamespaceVersionZeroIDSize))
+
+	signer, err := testnode.NewOfflineSigner()
+	require.NoError(t, err)
+	blobTxs := blobfactory.RandBlobTxsWithNamespacesAndSigner(signer, []appns.Namespace{ns1, ns3}, []int{500, 5000})
+	txs := testfactory.GenerateRandomTxs(50, 500)
+	txs = append(txs, blobTxs...)
+
+	dataSquare, err := square.Construct(txs.ToSliceOfBytes(), appconsts.SquareSizeUpperBound(appconsts.LatestVersion), appconsts.SubtreeRootThreshold(appconsts.LatestVersion))
+	require.NoError(t, err)
+	eds, err := da.ExtendShares(shares.ToBytes(dataSquare))
+	require.NoError(t, err)
+	dah, err := da.NewDataAvailabilityHeader(eds)
+	require.NoError(t, err)
+	dataRoot := dah.Hash()
+
+	// ns2 is between two namespaces of the square and ns4 is after all blobs
+	for _, ns := range []appns.Namespace{ns2, ns4} {
+		absenceProof, err := proof.NewNamespaceAbsenceProof(dataSquare, ns)
+		require.NoError(t, err)
+		require.Len(t, absenceProof.NmtProofs, dataSquare.Size())
+		require.NoError(t, absenceProof.Validate(dataRoot))
+
+		bz, err := gogoproto.Marshal(&absenceProof)
+		require.NoError(t, err)
+		var decoded proof.NamespaceAbsenceProof
+		require.NoError(t, gogoproto.Unmarshal(bz, &decoded))
+		require.NoError(t, decoded.Validate(dataRoot))
+	}
+
+	_, err = proof.NewNamespaceAbsenceProof(dataSquare, ns1)
+	require.Error(t, err)
+	_, err = proof.NewNamespaceAbsenceProof(dataSquare, appns.TxNamespace)
+	require.Error(t, err)
+	_, err = proof.NewNamespaceAbsenceProof(dataSquare, appns.ParitySharesNamespace)
+	require.Error(t, err)
+
+	absenceProof, err := proof.NewNamespaceAbsenceProof(dataSquare, ns2)
+	require.NoError(t, err)
+	bz, err := gogoproto.Marshal(&absenceProof)
+	require.NoError(t, err)
+	invalid := []struct {
+		name   string
+		modify func(p *proof.NamespaceAbsenceProof)
+	}{
+		{"present namespace", func(p *proof.NamespaceAbsenceProof) { p.NamespaceId = ns3.ID }},
+		{"missing row", func(p *proof.NamespaceAbsenceProof) {
+			p.RowProof.RowRoots = p.RowProof.RowRoots[1:]
+			p.RowProof.Proofs = p.RowProof.Proofs[1:]
+			p.RowProof.StartRow = 1
+			p.NmtProofs = p.NmtProofs[1:]
+		}},
+		{"repeated row", func(p *proof.NamespaceAbsenceProof) {
+			p.RowProof.RowRoots[1] = p.RowProof.RowRoots[0]
+			p.RowProof.Proofs[1] = p.RowProof.Proofs[0]
+			p.NmtProofs[1] = p.NmtProofs[0]
+		}},
+		{"missing NMT proof", func(p *proof.NamespaceAbsenceProof) { p.NmtProofs = p.NmtProofs[1:] }},
+		{"no row proof", func(p *proof.NamespaceAbsenceProof) { p.RowProof = nil }},
+	}
+	for _, tc := range invalid {
+		t.Run(tc.name, func(t *testing.T) {
+			var p proof.NamespaceAbsenceProof
+			require.NoError(t, gogoproto.Unmarshal(bz, &p))
+			tc.modify(&p)
+			require.Error(t, p.Validate(dataRoot))
+		})
+	}
+	require.Error(t, absenceProof.Validate(bytes.Repeat([]byte{1}, 32)))
+}
+
+func TestNewCommitmentProof(t *testing.T) {
+	ns1 := appns.MustNewV0(bytes.Repeat([]byte{1}, appns.NamespaceVersionZeroIDSize))
+	ns2 := appns.MustNewV0(bytes.Repeat([]byte{2}, appns.NamespaceVersionZeroIDSize))
+	threshold := appconsts.SubtreeRootThreshold(appconsts.LatestVersion)
+	maxSquareSize := appconsts.SquareSizeUpperBound(appconsts.LatestVersion)
+
+	signer, err := testnode.NewOfflineSigner()
+	require.NoError(t, err)
+	blobTxs := blobfactory.RandBlobTxsWithNamespacesAndSigner(signer, []appns.Namespace{ns1, ns2}, []int{500, 50000})
+	txs := testfactory.GenerateRandomTxs(50, 500)
+	txs = append(txs, blobTxs...)
+	rawTxs := txs.ToSliceOfBytes()
+
+	dataSquare, err := square.Construct(rawTxs, maxSquareSize, threshold)
+	require.NoError(t, err)
+	eds, err := da.ExtendShares(shares.ToBytes(dataSquare))
+	require.NoError(t, err)
+	dah, err := da.NewDataAvailabilityHeader(eds)
+	require.NoError(t, err)
+	dataRoot := dah.Hash()
+
+	// the second blob spans multiple rows
+	for i := range blobTxs {
+		txIndex := len(txs) - len(blobTxs) + i
+		blobTx, isBlobTx := blob.UnmarshalBlobTx(rawTxs[txIndex])
+		require.True(t, isBlobTx)
+		commitment, err := inclusion.CreateCommitment(blobTx.Blobs[0], merkle.HashFromByteSlices, threshold)
+		require.NoError(t, err)
+		shareRange, err := square.BlobShareRange(rawTxs, txIndex, 0, maxSquareSize, threshold)
+		require.NoError(t, err)
+
+		commitmentProof, err := proof.NewCommitmentProof(dataSquare, shareRange, threshold)
+		require.NoError(t, err)
+		require.NoError(t, commitmentProof.Validate(dataRoot, commitment, threshold))
+
+		bz, err := gogoproto.Marshal(&commitmentProof)
+		require.NoError(t, err)
+		var decoded proof.CommitmentProof
+		require.NoError(t, gogoproto.Unmarshal(bz, &decoded))
+		require.NoError(t, decoded.Validate(dataRoot, commitment, threshold))
+	}
+
+	_, err = proof.NewCommitmentProof(dataSquare, shares.NewRange(0, 10), threshold)
+	require.Error(t, err)
+	_, err = proof.NewCommitmentProof(dataSquare, shares.NewRange(10, 10), threshold)
+	require.Error(t, err)
+
+	txIndex := len(txs) - 1
+	blobTx, _ := blob.UnmarshalBlobTx(rawTxs[txIndex])
+	commitment, err := inclusion.CreateCommitment(blobTx.Blobs[0], merkle.HashFromByteSlices, threshold)
+	require.NoError(t, err)
+	shareRange, err := square.BlobShareRange(rawTxs, txIndex, 0, maxSquareSize, threshold)
+	require.NoError(t, err)
+	commitmentProof, err := proof.NewCommitmentProof(dataSquare, shareRange, threshold)
+	require.NoError(t, err)
+	require.Greater(t, len(commitmentProof.SubtreeRootProofs), 1)
+	bz, err := gogoproto.Marshal(&commitmentProof)
+	require.NoError(t, err)
+
+	invalid := []struct {
+		name   string
+		modify func(p *proof.CommitmentProof)
+	}{
+		{"swapped subtree roots", func(p *proof.CommitmentProof) {
+			p.SubtreeRoots[0], p.SubtreeRoots[1] = p.SubtreeRoots[1], p.SubtreeRoots[0]
+		}},
+		{"missing subtree root", func(p *proof.CommitmentProof) { p.SubtreeRoots = p.SubtreeRoots[1:] }},
+		{"wrong namespace", func(p *proof.CommitmentProof) { p.NamespaceId = ns1.ID }},
+		{"missing row", func(p *proof.CommitmentProof) {
+			p.RowProof.RowRoots = p.RowProof.RowRoots[1:]
+			p.RowProof.Proofs = p.RowProof.Proofs[1:]
+			p.RowProof.StartRow++
+			p.SubtreeRootProofs = p.SubtreeRootProofs[1:]
+		}},
+		{"missing NMT proof node", func(p *proof.CommitmentProof) {
+			last := p.SubtreeRootProofs[len(p.SubtreeRootProofs)-1]
+			last.Nodes = last.Nodes[1:]
+		}},
+		{"shifted start", func(p *proof.CommitmentProof) { p.SubtreeRootProofs[0].Start++ }},
+		{"no row proof", func(p *proof.CommitmentProof) { p.RowProof = nil }},
+	}
+	for _, tc := range invalid {
+		t.Run(tc.name, func(t *testing.T) {
+			var p proof.CommitmentProof
+			require.NoError(t, gogoproto.Unmarshal(bz, &p))
+			tc.modify(&p)
+			require.Error(t, p.Validate(dataRoot, commitment, threshold))
+		})
+	}
+	require.Error(t, commitmentProof.Validate(dataRoot, bytes.Repeat([]byte{1}, 32), threshold))
+	require.Error(t, commitmentProof.Validate(bytes.Repeat([]byte{1}, 32), commitment, threshold))
+}
+
+func TestRowMultiProof(t *testing.T) {
+	signer, err := testnode.NewOfflineSigner()
+	require.NoError(t, err)
+	blobTxs := blobfactory.RandBlobTxsWithNamespacesAndSigner(signer, []appns.Namespace{appns.RandomBlobNamespace()}, []int{50000})
+	txs := testfactory.GenerateRandomTxs(50, 500)
+	txs = append(txs, blobTxs...)
+
+	dataSquare, err := square.Construct(txs.ToSliceOfBytes(), appconsts.SquareSizeUpperBound(appconsts.LatestVersion), appconsts.SubtreeRootThreshold(appconsts.LatestVersion))
+	require.NoError(t, err)
+	eds, err := da.ExtendShares(shares.ToBytes(dataSquare))
+	require.NoError(t, err)
+	dah, err := da.NewDataAvailabilityHeader(eds)
+	require.NoError(t, err)
+	dataRoot := dah.Hash()
+
+	// a row proof of a range of shares spanning multiple rows
+	shareProof, err := proof.NewShareRangeProof(dataSquare, shares.NewRange(0, len(dataSquare)))
+	require.NoError(t, err)
+	rowProof := shareProof.ShareProofs[len(shareProof.ShareProofs)-1].RowProof
+	require.Greater(t, len(rowProof.RowRoots), 1)
+	multiProof, err := rowProof.MultiProof()
+	require.NoError(t, err)
+	require.NoError(t, multiProof.Validate(dataRoot))
+	require.Equal(t, rowProof.RowRoots, multiProof.RowRoots)
+	aunts := 0
+	for _, p := range rowProof.Proofs {
+		aunts += len(p.Aunts)
+	}
+	require.Less(t, len(multiProof.Nodes), aunts)
+
+	bz, err := gogoproto.Marshal(&multiProof)
+	require.NoError(t, err)
+	var decoded proof.RowMultiProof
+	require.NoError(t, gogoproto.Unmarshal(bz, &decoded))
+	require.NoError(t, decoded.Validate(dataRoot))
+
+	// a proof of sparse rows and columns given out of order
+	roots := append(append([][]byte{}, dah.RowRoots...), dah.ColumnRoots...)
+	_, allProofs := merkle.ProofsFromByteSlices(roots)
+	var sparseRoots [][]byte
+	var sparseProofs []*proof.Proof
+	for _, i := range []int{len(roots) - 1, 0, 3, 4, len(dah.RowRoots) + 1} {
+		sparseRoots = append(sparseRoots, roots[i])
+		sparseProofs = append(sparseProofs, &proof.Proof{
+			Total:    allProofs[i].Total,
+			Index:    allProofs[i].Index,
+			LeafHash: allProofs[i].LeafHash,
+			Aunts:    allProofs[i].Aunts,
+		})
+	}
+	sparse, err := proof.NewRowMultiProof(sparseRoots, sparseProofs)
+	require.NoError(t, err)
+	require.NoError(t, sparse.Validate(dataRoot))
+	require.Equal(t, []int64{0, 3, 4, int64(len(dah.RowRoots) + 1), int64(len(roots) - 1)}, sparse.Indices)
+
+	_, err = proof.NewRowMultiProof(sparseRoots[:2], append(sparseProofs[:1], sparseProofs[0]))
+	require.Error(t, err)
+
+	invalid := []struct {
+		name   string
+		modify func(p *proof.RowMultiProof)
+	}{
+		{"wrong row root", func(p *proof.RowMultiProof) { p.RowRoots[0] = p.RowRoots[1] }},
+		{"wrong index", func(p *proof.RowMultiProof) { p.Indices[1]++ }},
+		{"unsorted indices", func(p *proof.RowMultiProof) {
+			p.Indices[0], p.Indices[1] = p.Indices[1], p.Indices[0]
+			p.RowRoots[0], p.RowRoots[1] = p.RowRoots[1], p.RowRoots[0]
+		}},
+		{"missing node", func(p *proof.RowMultiProof) { p.Nodes = p.Nodes[1:] }},
+		{"extra node", func(p *proof.RowMultiProof) { p.Nodes = append(p.Nodes, p.Nodes[0]) }},
+		{"wrong total", func(p *proof.RowMultiProof) { p.Total++ }},
+		{"index out of range", func(p *proof.RowMultiProof) { p.Indices[len(p.Indices)-1] = p.Total }},
+	}
+	bz, err = gogoproto.Marshal(&sparse)
+	require.NoError(t, err)
+	for _, tc := range invalid {
+		t.Run(tc.name, func(t *testing.T) {
+			var p proof.RowMultiProof
+			require.NoError(t, gogoproto.Unmarshal(bz, &p))
+			tc.modify(&p)
+			require.Error(t, p.Validate(dataRoot))
+		})
+	}
+}
@@ -2,12 +2,14 @@ package proof
 
 import (
 	"bytes"
+	"encoding/hex"
 	"fmt"
 	"strconv"
 
 	"github.com/celestiaorg/celestia-app/pkg/appconsts"
 	"github.com/celestiaorg/go-square/shares"
 	"github.com/celestiaorg/go-square/square"
+	"github.com/gogo/protobuf/proto"
 
 	appns "github.com/celestiaorg/go-square/namespace"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -16,6 +18,31 @@ import (
 	"github.com/tendermint/tendermint/types"
 )
 
+// SquareSizeUpperBoundFn returns the square size upper bound that the data
+// square of the block at the height and app version was constructed with.
+type SquareSizeUpperBoundFn func(height int64, appVersion uint64) int
+
+// Querier serves the proof queries. It constructs the data squares of the
+// blocks passed in the queries with the square size upper bound returned by
+// its SquareSizeUpperBoundFn.
+type Querier struct {
+	squareSizeUpperBound SquareSizeUpperBoundFn
+}
+
+// NewQuerier returns a Querier that constructs the data squares with the square
+// size upper bound returned by the function, such as the governance square
+// size at the height of the block.
+func NewQuerier(squareSizeUpperBound SquareSizeUpperBoundFn) Querier {
+	return Querier{squareSizeUpperBound: squareSizeUpperBound}
+}
+
+// defaultQuerier constructs the data squares with the upper bound square size
+// of the app version of the block, which is the square size of the blocks
+// unless governance lowered it.
+var defaultQuerier = NewQuerier(func(_ int64, appVersion uint64) int {
+	return appconsts.SquareSizeUpperBound(appVersion)
+})
+
 const TxInclusionQueryPath = "txInclusionProof"
 
 // Querier defines the logic performed when the ABCI client using the Query
@@ -25,7 +52,17 @@ const TxInclusionQueryPath = "txInclusionProof"
 //
 // example path for proving the third transaction in that block:
 // custom/txInclusionProof/3
-func QueryTxInclusionProof(_ sdk.Context, path []string, req abci.RequestQuery) ([]byte, error) {
+//
+// It constructs the data square with the upper bound square size of the app
+// version of the block. Use the method of a Querier to construct it with the
+// square size the block was proposed with.
+func QueryTxInclusionProof(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, error) {
+	return defaultQuerier.QueryTxInclusionProof(ctx, path, req)
+}
+
+// QueryTxInclusionProof is like the package level function but constructs the data square
+// with the square size upper bound of the querier.
+func (q Querier) QueryTxInclusionProof(_ sdk.Context, path []string, req abci.RequestQuery) ([]byte, error) {
 	// parse the index from the path
 	if len(path) != 1 {
 		return nil, fmt.Errorf("expected query path length: 1 actual: %d ", len(path))
@@ -47,7 +84,8 @@ func QueryTxInclusionProof(_ sdk.Context, path []string, req abci.RequestQuery)
 	}
 
 	// create and marshal the tx inclusion proof, which we return in the form of []byte
-	shareProof, err := NewTxInclusionProof(data.Txs.ToSliceOfBytes(), uint64(index), pbb.Header.Version.App)
+	appVersion := pbb.Header.Version.App
+	shareProof, err := NewTxInclusionProofWithSquareSize(data.Txs.ToSliceOfBytes(), uint64(index), q.squareSizeUpperBound(pbb.Header.Height, appVersion), appVersion)
 	if err != nil {
 		return nil, err
 	}
@@ -66,75 +104,205 @@ const ShareInclusionQueryPath = "shareInclusionProof"
 // inclusion proofs of a set of shares to the data root. The share range should
 // be appended to the path. Example path for proving the set of shares [3, 5]:
 // custom/shareInclusionProof/3/5
-func QueryShareInclusionProof(_ sdk.Context, path []string, req abci.RequestQuery) ([]byte, error) {
-	// parse the share range from the path
-	if len(path) != 2 {
-		return nil, fmt.Errorf("expected query path length: 2 actual: %d ", len(path))
+//
+// It constructs the data square with the upper bound square size of the app
+// version of the block. Use the method of a Querier to construct it with the
+// square size the block was proposed with.
+func QueryShareInclusionProof(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, error) {
+	return defaultQuerier.QueryShareInclusionProof(ctx, path, req)
+}
+
+// QueryShareInclusionProof is like the package level function but constructs the data square
+// with the square size upper bound of the querier.
+func (q Querier) QueryShareInclusionProof(_ sdk.Context, path []string, req abci.RequestQuery) ([]byte, error) {
+	dataSquare, _, beginShare, endShare, err := q.parseShareRangeQuery(path, req)
+	if err != nil {
+		return nil, err
 	}
-	beginShare, err := strconv.ParseInt(path[0], 10, 64)
+
+	nID, err := ParseNamespace(dataSquare, int(beginShare), int(endShare))
 	if err != nil {
 		return nil, err
 	}
-	endShare, err := strconv.ParseInt(path[1], 10, 64)
+
+	// create and marshal the share inclusion proof, which we return in the form of []byte
+	shareProof, err := NewShareInclusionProof(
+		dataSquare,
+		nID,
+		shares.NewRange(int(beginShare), int(endShare)),
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	// unmarshal the block data that is passed from the ABCI client
-	pbb := new(tmproto.Block)
-	err = pbb.Unmarshal(req.Data)
+	rawShareProof, err := shareProof.Marshal()
 	if err != nil {
-		return nil, fmt.Errorf("error reading block: %w", err)
+		return nil, err
 	}
 
-	// construct the data square from the block data. As we don't have
-	// access to the application's state machine we use the upper bound
-	// square size instead of the square size dictated from governance
-	dataSquare, err := square.Construct(pbb.Data.Txs, appconsts.SquareSizeUpperBound(pbb.Header.Version.App), appconsts.SubtreeRootThreshold(pbb.Header.Version.App))
+	return rawShareProof, nil
+}
+
+const ShareRangeQueryPath = "shareRangeProof"
+
+// QueryShareRangeProof defines the logic performed when querying for the proof
+// of a set of shares to the data root. Unlike QueryShareInclusionProof, the
+// shares may belong to multiple namespaces. The share range should be appended
+// to the path and the marshalled bytes of the ShareRangeProof are returned.
+// Example path for proving the set of shares [3, 5]:
+// custom/shareRangeProof/3/5
+//
+// It constructs the data square with the upper bound square size of the app
+// version of the block. Use the method of a Querier to construct it with the
+// square size the block was proposed with.
+func QueryShareRangeProof(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, error) {
+	return defaultQuerier.QueryShareRangeProof(ctx, path, req)
+}
+
+// QueryShareRangeProof is like the package level function but constructs the data square
+// with the square size upper bound of the querier.
+func (q Querier) QueryShareRangeProof(_ sdk.Context, path []string, req abci.RequestQuery) ([]byte, error) {
+	dataSquare, _, beginShare, endShare, err := q.parseShareRangeQuery(path, req)
 	if err != nil {
 		return nil, err
 	}
 
-	nID, err := ParseNamespace(dataSquare, int(beginShare), int(endShare))
+	shareRangeProof, err := NewShareRangeProof(dataSquare, shares.NewRange(int(beginShare), int(endShare)))
 	if err != nil {
 		return nil, err
 	}
 
-	// create and marshal the share inclusion proof, which we return in the form of []byte
-	shareProof, err := NewShareInclusionProof(
+	return proto.Marshal(&shareRangeProof)
+}
+
+const CommitmentQueryPath = "commitmentProof"
+
+// QueryCommitmentProof defines the logic performed when querying for the proof
+// of the share commitment of a blob to the data root. The share range of the
+// blob should be appended to the path and the marshalled bytes of the
+// CommitmentProof are returned. Example path for proving the blob in the
+// shares [3, 5]: custom/commitmentProof/3/5
+//
+// It constructs the data square with the upper bound square size of the app
+// version of the block. Use the method of a Querier to construct it with the
+// square size the block was proposed with.
+func QueryCommitmentProof(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, error) {
+	return defaultQuerier.QueryCommitmentProof(ctx, path, req)
+}
+
+// QueryCommitmentProof is like the package level function but constructs the data square
+// with the square size upper bound of the querier.
+func (q Querier) QueryCommitmentProof(_ sdk.Context, path []string, req abci.RequestQuery) ([]byte, error) {
+	dataSquare, appVersion, beginShare, endShare, err := q.parseShareRangeQuery(path, req)
+	if err != nil {
+		return nil, err
+	}
+
+	commitmentProof, err := NewCommitmentProof(
 		dataSquare,
-		nID,
 		shares.NewRange(int(beginShare), int(endShare)),
+		appconsts.SubtreeRootThreshold(appVersion),
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	rawShareProof, err := shareProof.Marshal()
+	return proto.Marshal(&commitmentProof)
+}
+
+// parseShareRangeQuery parses the share range from the path of a share proof
+// query and constructs the data square of the block passed in the request. It
+// also returns the app version of the block.
+func (q Querier) parseShareRangeQuery(path []string, req abci.RequestQuery) (square.Square, uint64, int64, int64, error) {
+	// parse the share range from the path
+	if len(path) != 2 {
+		return nil, 0, 0, 0, fmt.Errorf("expected query path length: 2 actual: %d ", len(path))
+	}
+	beginShare, err := strconv.ParseInt(path[0], 10, 64)
 	if err != nil {
-		return nil, err
+		return nil, 0, 0, 0, err
+	}
+	endShare, err := strconv.ParseInt(path[1], 10, 64)
+	if err != nil {
+		return nil, 0, 0, 0, err
 	}
 
-	return rawShareProof, nil
+	dataSquare, appVersion, err := q.querySquare(req)
+	if err != nil {
+		return nil, 0, 0, 0, err
+	}
+	return dataSquare, appVersion, beginShare, endShare, nil
 }
 
-// ParseNamespace validates the share range, checks if it only contains one namespace and returns
-// that namespace ID.
-func ParseNamespace(rawShares []shares.Share, startShare, endShare int) (appns.Namespace, error) {
-	if startShare < 0 {
-		return appns.Namespace{}, fmt.Errorf("start share %d should be positive", startShare)
+// querySquare constructs the data square of the block passed in the request of
+// a proof query and returns it together with the app version of the block.
+func (q Querier) querySquare(req abci.RequestQuery) (square.Square, uint64, error) {
+	// unmarshal the block data that is passed from the ABCI client
+	pbb := new(tmproto.Block)
+	err := pbb.Unmarshal(req.Data)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error reading block: %w", err)
 	}
 
-	if endShare < 0 {
-		return appns.Namespace{}, fmt.Errorf("end share %d should be positive", endShare)
+	// construct the data square from the block data with the square size
+	// upper bound the block was proposed with
+	appVersion := pbb.Header.Version.App
+	maxSquareSize := q.squareSizeUpperBound(pbb.Header.Height, appVersion)
+	dataSquare, err := square.Construct(pbb.Data.Txs, maxSquareSize, appconsts.SubtreeRootThreshold(appVersion))
+	if err != nil {
+		return nil, 0, err
 	}
+	return dataSquare, appVersion, nil
+}
 
-	if endShare < startShare {
-		return appns.Namespace{}, fmt.Errorf("end share %d cannot be lower than starting share %d", endShare, startShare)
+const NamespaceAbsenceQueryPath = "namespaceAbsenceProof"
+
+// QueryNamespaceAbsenceProof defines the logic performed when querying for the
+// proof that a namespace has no shares in a block. The hex encoded namespace,
+// including its version, should be appended to the path and the marshalled
+// bytes of the NamespaceAbsenceProof are returned. Example path:
+// custom/namespaceAbsenceProof/0000000000000000000000000000000000000000000102030405060708090a
+//
+// It constructs the data square with the upper bound square size of the app
+// version of the block. Use the method of a Querier to construct it with the
+// square size the block was proposed with.
+func QueryNamespaceAbsenceProof(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, error) {
+	return defaultQuerier.QueryNamespaceAbsenceProof(ctx, path, req)
+}
+
+// QueryNamespaceAbsenceProof is like the package level function but constructs the data square
+// with the square size upper bound of the querier.
+func (q Querier) QueryNamespaceAbsenceProof(_ sdk.Context, path []string, req abci.RequestQuery) ([]byte, error) {
+	if len(path) != 1 {
+		return nil, fmt.Errorf("expected query path length: 1 actual: %d ", len(path))
+	}
+	nsBytes, err := hex.DecodeString(path[0])
+	if err != nil {
+		return nil, err
+	}
+	namespace, err := appns.From(nsBytes)
+	if err != nil {
+		return nil, err
 	}
 
-	if endShare > len(rawShares) {
-		return appns.Namespace{}, fmt.Errorf("end share %d is higher than block shares %d", endShare, len(rawShares))
+	dataSquare, _, err := q.querySquare(req)
+	if err != nil {
+		return nil, err
+	}
+
+	absenceProof, err := NewNamespaceAbsenceProof(dataSquare, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	return proto.Marshal(&absenceProof)
+}
+
+// ParseNamespace validates the share range, checks if it only contains one namespace and returns
+// that namespace ID.
+func ParseNamespace(rawShares []shares.Share, startShare, endShare int) (appns.Namespace, error) {
+	if err := validateShareRange(rawShares, startShare, endShare); err != nil {
+		return appns.Namespace{}, err
 	}
 
 	startShareNs, err := rawShares[startShare].Namespace()
@@ -153,3 +321,23 @@ func ParseNamespace(rawShares []shares.Share, startShare, endShare int) (appns.N
 	}
 	return startShareNs, nil
 }
+
+// validateShareRange checks that the share range is within the shares.
+func validateShareRange(rawShares []shares.Share, startShare, endShare int) error {
+	if startShare < 0 {
+		return fmt.Errorf("start share %d should be positive", startShare)
+	}
+
+	if endShare < 0 {
+		return fmt.Errorf("end share %d should be positive", endShare)
+	}
+
+	if endShare < startShare {
+		return fmt.Errorf("end share %d cannot be lower than starting share %d", endShare, startShare)
+	}
+
+	if endShare > len(rawShares) {
+		return fmt.Errorf("end share %d is higher than block shares %d", endShare, len(rawShares))
+	}
+	return nil
+}
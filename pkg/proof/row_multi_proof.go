@@ -0,0 +1,191 @@
+package proof
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/bits"
+)
+
+// NewRowMultiProof combines the Merkle proofs of the row roots, which must be
+// proofs of distinct rows of the same Merkle tree, into a RowMultiProof. The
+// rows may be given in any order.
+func NewRowMultiProof(rowRoots [][]byte, proofs []*Proof) (RowMultiProof, error) {
+	if len(proofs) == 0 || len(proofs) != len(rowRoots) {
+		return RowMultiProof{}, fmt.Errorf("the number of proofs %d must equal the number of row roots %d", len(proofs), len(rowRoots))
+	}
+
+	total := proofs[0].Total
+	rows := make(map[int64][]byte, len(proofs))
+	// nodes are the inner nodes of the proofs by the range of leaves they
+	// cover
+	nodes := make(map[[2]int64][]byte)
+	for i, proof := range proofs {
+		if proof == nil {
+			return RowMultiProof{}, fmt.Errorf("proof %d is nil", i)
+		}
+		if proof.Total != total {
+			return RowMultiProof{}, fmt.Errorf("proof %d has total %d, expected %d", i, proof.Total, total)
+		}
+		if proof.Index < 0 || proof.Index >= total {
+			return RowMultiProof{}, fmt.Errorf("proof %d has invalid index %d", i, proof.Index)
+		}
+		if _, ok := rows[proof.Index]; ok {
+			return RowMultiProof{}, fmt.Errorf("row %d is proven twice", proof.Index)
+		}
+		rows[proof.Index] = rowRoots[i]
+
+		siblings := siblingRanges(proof.Index, total)
+		if len(siblings) != len(proof.Aunts) {
+			return RowMultiProof{}, fmt.Errorf("proof %d has %d aunts, expected %d", i, len(proof.Aunts), len(siblings))
+		}
+		// the aunts are ordered from the leaf to the root
+		for j, sibling := range siblings {
+			nodes[sibling] = proof.Aunts[len(proof.Aunts)-1-j]
+		}
+	}
+
+	multiProof := RowMultiProof{Total: total}
+	var build func(start, end int64) error
+	build = func(start, end int64) error {
+		if !containsRow(rows, start, end) {
+			node, ok := nodes[[2]int64{start, end}]
+			if !ok {
+				return fmt.Errorf("missing node of rows [%d, %d)", start, end)
+			}
+			multiProof.Nodes = append(multiProof.Nodes, node)
+			return nil
+		}
+		if end-start == 1 {
+			multiProof.Indices = append(multiProof.Indices, start)
+			multiProof.RowRoots = append(multiProof.RowRoots, rows[start])
+			return nil
+		}
+		k := splitPoint(end - start)
+		if err := build(start, start+k); err != nil {
+			return err
+		}
+		return build(start+k, end)
+	}
+	if err := build(0, total); err != nil {
+		return RowMultiProof{}, err
+	}
+	return multiProof, nil
+}
+
+// MultiProof converts the row proof into a RowMultiProof of the same rows.
+func (rp RowProof) MultiProof() (RowMultiProof, error) {
+	return NewRowMultiProof(rp.RowRoots, rp.Proofs)
+}
+
+// Validate checks that the row roots of the proof exist at their indices in a
+// Merkle tree with the given root. It returns nil if the proof is valid.
+func (m RowMultiProof) Validate(root []byte) error {
+	if len(m.Indices) == 0 {
+		return errors.New("empty row multi proof")
+	}
+	if len(m.Indices) != len(m.RowRoots) {
+		return fmt.Errorf("the number of indices %d must equal the number of row roots %d", len(m.Indices), len(m.RowRoots))
+	}
+	for i, index := range m.Indices {
+		if index < 0 || index >= m.Total {
+			return fmt.Errorf("index %d is out of the range of the %d leaves", index, m.Total)
+		}
+		if i > 0 && index <= m.Indices[i-1] {
+			return errors.New("indices must be strictly ascending")
+		}
+	}
+
+	indices, rowRoots, nodes := m.Indices, m.RowRoots, m.Nodes
+	var compute func(start, end int64) ([]byte, error)
+	compute = func(start, end int64) ([]byte, error) {
+		if len(indices) == 0 || indices[0] >= end {
+			if len(nodes) == 0 {
+				return nil, errors.New("row multi proof has too few nodes")
+			}
+			node := nodes[0]
+			nodes = nodes[1:]
+			return node, nil
+		}
+		if end-start == 1 {
+			leaf := leafHash(rowRoots[0])
+			indices, rowRoots = indices[1:], rowRoots[1:]
+			return leaf, nil
+		}
+		k := splitPoint(end - start)
+		left, err := compute(start, start+k)
+		if err != nil {
+			return nil, err
+		}
+		right, err := compute(start+k, end)
+		if err != nil {
+			return nil, err
+		}
+		return innerHash(left, right), nil
+	}
+
+	computed, err := compute(0, m.Total)
+	if err != nil {
+		return err
+	}
+	if len(nodes) != 0 {
+		return errors.New("row multi proof has too many nodes")
+	}
+	if !bytes.Equal(computed, root) {
+		return errors.New("row multi proof failed to verify")
+	}
+	return nil
+}
+
+// siblingRanges returns the ranges of leaves covered by the siblings of the
+// nodes on the path from the root to the leaf at the index, starting at the
+// root.
+func siblingRanges(index, total int64) [][2]int64 {
+	var siblings [][2]int64
+	start, end := int64(0), total
+	for end-start > 1 {
+		k := splitPoint(end - start)
+		if index < start+k {
+			siblings = append(siblings, [2]int64{start + k, end})
+			end = start + k
+		} else {
+			siblings = append(siblings, [2]int64{start, start + k})
+			start += k
+		}
+	}
+	return siblings
+}
+
+// containsRow returns true if one of the rows is in the range [start, end).
+func containsRow(rows map[int64][]byte, start, end int64) bool {
+	for index := range rows {
+		if index >= start && index < end {
+			return true
+		}
+	}
+	return false
+}
+
+// splitPoint returns the largest power of 2 less than length, which is the
+// number of leaves in the left subtree of a Merkle tree with length leaves.
+func splitPoint(length int64) int64 {
+	return 1 << (bits.Len64(uint64(length-1)) - 1)
+}
+
+// leafHash and innerHash are the RFC 6962 hash functions of the Merkle tree of
+// the data root.
+func leafHash(leaf []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{0})
+	h.Write(leaf)
+	return h.Sum(nil)
+}
+
+func innerHash(left, right []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{1})
+	h.Write(left)
+	h.Write(right)
+	return h.Sum(nil)
+}
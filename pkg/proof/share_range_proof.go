@@ -0,0 +1,182 @@
+package proof
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/celestiaorg/celestia-app/pkg/da"
+
+	appns "github.com/celestiaorg/go-square/namespace"
+	"github.com/celestiaorg/go-square/shares"
+	"github.com/celestiaorg/go-square/square"
+)
+
+// NewShareRangeProof returns a proof of the shares in the range of the data
+// square to the data root. Unlike NewShareInclusionProof, the shares may belong
+// to multiple namespaces.
+func NewShareRangeProof(dataSquare square.Square, shareRange shares.Range) (ShareRangeProof, error) {
+	namespaces, err := parseNamespaces(dataSquare, shareRange.Start, shareRange.End)
+	if err != nil {
+		return ShareRangeProof{}, err
+	}
+
+	eds, err := da.ExtendShares(shares.ToBytes(dataSquare))
+	if err != nil {
+		return ShareRangeProof{}, err
+	}
+
+	// prove each run of shares of the same namespace separately
+	var proofs []*ShareProof
+	start := 0
+	for i := 1; i <= len(namespaces); i++ {
+		if i < len(namespaces) && namespaces[i].Equals(namespaces[start]) {
+			continue
+		}
+		proof, err := newShareInclusionProof(eds, namespaces[start], shares.NewRange(shareRange.Start+start, shareRange.Start+i))
+		if err != nil {
+			return ShareRangeProof{}, err
+		}
+		proofs = append(proofs, &proof)
+		start = i
+	}
+
+	return ShareRangeProof{
+		Start:       uint32(shareRange.Start),
+		End:         uint32(shareRange.End),
+		ShareProofs: proofs,
+	}, nil
+}
+
+// Data returns the shares of the range.
+func (m ShareRangeProof) Data() [][]byte {
+	var data [][]byte
+	for _, proof := range m.ShareProofs {
+		data = append(data, proof.Data...)
+	}
+	return data
+}
+
+// Validate checks that the share proofs cover exactly the shares of the range,
+// that each of them proves the shares of a different namespace in ascending
+// order and that they are valid for the data root. It returns nil if the
+// proof is valid.
+func (m ShareRangeProof) Validate(root []byte) error {
+	if m.End <= m.Start {
+		return fmt.Errorf("end share %d must be greater than start share %d", m.End, m.Start)
+	}
+	if len(m.ShareProofs) == 0 {
+		return errors.New("empty share range proof")
+	}
+
+	next := int(m.Start)
+	var prev []byte
+	for i, proof := range m.ShareProofs {
+		if proof == nil {
+			return fmt.Errorf("share proof %d is nil", i)
+		}
+		// the structure of the proof is checked first because the
+		// validation of the share proof expects its rows to be set.
+		shareRange, err := proof.shareRange()
+		if err != nil {
+			return fmt.Errorf("share proof %d: %w", i, err)
+		}
+		if err := proof.Validate(root); err != nil {
+			return fmt.Errorf("share proof %d: %w", i, err)
+		}
+
+		namespace := append([]byte{uint8(proof.NamespaceVersion)}, proof.NamespaceId...)
+		if prev != nil && bytes.Compare(namespace, prev) <= 0 {
+			return fmt.Errorf("namespace of share proof %d is not greater than the namespace of the previous proof", i)
+		}
+		prev = namespace
+
+		if shareRange.Start != next {
+			return fmt.Errorf("share proof %d starts at share %d, expected %d", i, shareRange.Start, next)
+		}
+		next = shareRange.End
+	}
+	if next != int(m.End) {
+		return fmt.Errorf("share proofs end at share %d, expected %d", next, m.End)
+	}
+	return nil
+}
+
+// shareRange returns the range of the shares in the original data square that
+// the share proof claims to prove. It returns an error if the row proofs don't
+// prove the rows the proof claims, if the rows are not rows of the original
+// data square or if the proven shares are not contiguous. It doesn't verify the
+// proof, so it can be called before ShareProof.Validate.
+func (sp ShareProof) shareRange() (shares.Range, error) {
+	if sp.RowProof == nil || len(sp.RowProof.Proofs) == 0 || len(sp.ShareProofs) == 0 {
+		return shares.Range{}, errors.New("share proof does not prove any rows")
+	}
+	if sp.RowProof.EndRow < sp.RowProof.StartRow {
+		return shares.Range{}, fmt.Errorf("end row %d is before start row %d", sp.RowProof.EndRow, sp.RowProof.StartRow)
+	}
+	if len(sp.RowProof.Proofs) != len(sp.ShareProofs) || len(sp.RowProof.RowRoots) != len(sp.ShareProofs) {
+		return shares.Range{}, fmt.Errorf("the number of row proofs %d and row roots %d must equal the number of share proofs %d", len(sp.RowProof.Proofs), len(sp.RowProof.RowRoots), len(sp.ShareProofs))
+	}
+	if int(sp.RowProof.EndRow-sp.RowProof.StartRow)+1 != len(sp.ShareProofs) {
+		return shares.Range{}, fmt.Errorf("rows %d to %d don't match the number of share proofs %d", sp.RowProof.StartRow, sp.RowProof.EndRow, len(sp.ShareProofs))
+	}
+	if sp.RowProof.Proofs[0] == nil {
+		return shares.Range{}, errors.New("row proof 0 is nil")
+	}
+	// the row proofs prove the row and column roots of the extended data
+	// square, which has twice the width of the original data square.
+	squareSize := int(sp.RowProof.Proofs[0].Total / 4)
+	if squareSize == 0 {
+		return shares.Range{}, fmt.Errorf("invalid number of row and column roots %d", sp.RowProof.Proofs[0].Total)
+	}
+	if int(sp.RowProof.EndRow) >= squareSize {
+		return shares.Range{}, fmt.Errorf("row %d is not a row of the original data square of size %d", sp.RowProof.EndRow, squareSize)
+	}
+	for i, proof := range sp.RowProof.Proofs {
+		if proof == nil || proof.Index != int64(sp.RowProof.StartRow)+int64(i) || int(proof.Total) != 4*squareSize {
+			return shares.Range{}, fmt.Errorf("row proof %d is not a proof of row %d", i, int(sp.RowProof.StartRow)+i)
+		}
+	}
+	for i, proof := range sp.ShareProofs {
+		if proof == nil {
+			return shares.Range{}, fmt.Errorf("proof of row %d is nil", i)
+		}
+	}
+	last := len(sp.ShareProofs) - 1
+	for i, proof := range sp.ShareProofs {
+		if i > 0 && proof.Start != 0 {
+			return shares.Range{}, fmt.Errorf("proof of row %d does not start at the first share", i)
+		}
+		if i < last && int(proof.End) != squareSize {
+			return shares.Range{}, fmt.Errorf("proof of row %d does not end at the last share", i)
+		}
+		if int(proof.End) > squareSize {
+			return shares.Range{}, fmt.Errorf("proof of row %d exceeds the original data square", i)
+		}
+	}
+
+	start := int(sp.RowProof.StartRow)*squareSize + int(sp.ShareProofs[0].Start)
+	end := int(sp.RowProof.EndRow)*squareSize + int(sp.ShareProofs[last].End)
+	return shares.NewRange(start, end), nil
+}
+
+// parseNamespaces validates the share range and returns the namespace of each
+// share in it.
+func parseNamespaces(rawShares []shares.Share, startShare, endShare int) ([]appns.Namespace, error) {
+	if err := validateShareRange(rawShares, startShare, endShare); err != nil {
+		return nil, err
+	}
+	if endShare == startShare {
+		return nil, fmt.Errorf("share range [%d, %d) is empty", startShare, endShare)
+	}
+
+	namespaces := make([]appns.Namespace, 0, endShare-startShare)
+	for _, share := range rawShares[startShare:endShare] {
+		ns, err := share.Namespace()
+		if err != nil {
+			return nil, err
+		}
+		namespaces = append(namespaces, ns)
+	}
+	return namespaces, nil
+}
@@ -0,0 +1,143 @@
+package user
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/celestiaorg/go-square/blob"
+)
+
+// A blob that is too large to be paid for by a single PayForBlobs transaction
+// is split by the BlobClient into chunks. Each chunk is submitted as a blob of
+// the original namespace and share version whose data starts with a chunk
+// header followed by a part of the original data:
+//
+//	| magic | version | index | total | length | digest | data |
+//
+// The fields of the header are:
+//   - magic (4 bytes) is ChunkMagic.
+//   - version (1 byte) is ChunkHeaderVersion.
+//   - index (4 bytes, big endian) is the position of the chunk, starting at 0.
+//   - total (4 bytes, big endian) is the number of chunks.
+//   - length (8 bytes, big endian) is the length of the original data.
+//   - digest (32 bytes) is the SHA-256 hash of the original data, which
+//     identifies the chunks that belong together.
+//
+// The original data is the concatenation of the data of all chunks in order.
+// Blobs that fit into a single PayForBlobs transaction are submitted unchanged
+// without a header.
+const (
+	// ChunkMagic is the prefix of the data of every chunk.
+	ChunkMagic = "CBLK"
+	// ChunkHeaderVersion is the version of the chunk header format.
+	ChunkHeaderVersion = 0
+	// ChunkHeaderSize is the size of the chunk header in bytes.
+	ChunkHeaderSize = len(ChunkMagic) + 1 + 4 + 4 + 8 + sha256.Size
+)
+
+// ChunkHeader is the header of a chunk of a blob that was split by SplitBlob.
+type ChunkHeader struct {
+	Index  uint32
+	Total  uint32
+	Length uint64
+	Digest [sha256.Size]byte
+}
+
+// marshal returns the encoding of the header.
+func (h ChunkHeader) marshal() []byte {
+	bz := make([]byte, 0, ChunkHeaderSize)
+	bz = append(bz, ChunkMagic...)
+	bz = append(bz, ChunkHeaderVersion)
+	bz = binary.BigEndian.AppendUint32(bz, h.Index)
+	bz = binary.BigEndian.AppendUint32(bz, h.Total)
+	bz = binary.BigEndian.AppendUint64(bz, h.Length)
+	return append(bz, h.Digest[:]...)
+}
+
+// ParseChunk returns the header and the data of a chunk. It returns an error if
+// the data doesn't start with a valid chunk header.
+func ParseChunk(data []byte) (ChunkHeader, []byte, error) {
+	if len(data) < ChunkHeaderSize || !bytes.HasPrefix(data, []byte(ChunkMagic)) {
+		return ChunkHeader{}, nil, errors.New("data does not start with a chunk header")
+	}
+	if version := data[len(ChunkMagic)]; version != ChunkHeaderVersion {
+		return ChunkHeader{}, nil, fmt.Errorf("unsupported chunk header version %d", version)
+	}
+
+	bz := data[len(ChunkMagic)+1:]
+	h := ChunkHeader{
+		Index:  binary.BigEndian.Uint32(bz[0:4]),
+		Total:  binary.BigEndian.Uint32(bz[4:8]),
+		Length: binary.BigEndian.Uint64(bz[8:16]),
+	}
+	copy(h.Digest[:], bz[16:])
+	if h.Index >= h.Total {
+		return ChunkHeader{}, nil, fmt.Errorf("chunk index %d out of range for %d chunks", h.Index, h.Total)
+	}
+	return h, data[ChunkHeaderSize:], nil
+}
+
+// SplitBlob splits the blob into chunks whose data, including the chunk header,
+// is at most maxChunkSize bytes.
+func SplitBlob(b *blob.Blob, maxChunkSize int) ([]*blob.Blob, error) {
+	chunkDataSize := maxChunkSize - ChunkHeaderSize
+	if chunkDataSize <= 0 {
+		return nil, fmt.Errorf("max chunk size %d must be larger than the chunk header size %d", maxChunkSize, ChunkHeaderSize)
+	}
+
+	total := (len(b.Data) + chunkDataSize - 1) / chunkDataSize
+	header := ChunkHeader{
+		Total:  uint32(total),
+		Length: uint64(len(b.Data)),
+		Digest: sha256.Sum256(b.Data),
+	}
+	chunks := make([]*blob.Blob, total)
+	for i := range chunks {
+		start := i * chunkDataSize
+		end := min(start+chunkDataSize, len(b.Data))
+		header.Index = uint32(i)
+		data := append(header.marshal(), b.Data[start:end]...)
+		chunks[i] = blob.New(b.Namespace(), data, uint8(b.ShareVersion))
+	}
+	return chunks, nil
+}
+
+// JoinChunks returns the original data of the chunks of a blob split by
+// SplitBlob. The chunks may be passed in any order but all of them are
+// required.
+func JoinChunks(chunks ...[]byte) ([]byte, error) {
+	if len(chunks) == 0 {
+		return nil, errors.New("no chunks")
+	}
+
+	var first ChunkHeader
+	parts := make([][]byte, len(chunks))
+	for i, chunk := range chunks {
+		h, data, err := ParseChunk(chunk)
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 {
+			first = h
+			if int(h.Total) != len(chunks) {
+				return nil, fmt.Errorf("got %d chunks, expected %d", len(chunks), h.Total)
+			}
+		}
+		if h.Total != first.Total || h.Length != first.Length || h.Digest != first.Digest {
+			return nil, fmt.Errorf("chunk %d belongs to a different blob", h.Index)
+		}
+		if parts[h.Index] != nil {
+			return nil, fmt.Errorf("duplicate chunk %d", h.Index)
+		}
+		parts[h.Index] = data
+	}
+
+	data := bytes.Join(parts, nil)
+	if uint64(len(data)) != first.Length || sha256.Sum256(data) != first.Digest {
+		return nil, errors.New("joined chunks don't match the length or digest in the chunk header")
+	}
+	return data, nil
+}
@@ -0,0 +1,46 @@
+package user_test
+
+import (
+	"testing"
+
+	"github.com/celestiaorg/celestia-app/pkg/user"
+	"github.com/celestiaorg/go-square/blob"
+	appns "github.com/celestiaorg/go-square/namespace"
+	"github.com/stretchr/testify/require"
+	tmrand "github.com/tendermint/tendermint/libs/rand"
+)
+
+func TestSplitBlob(t *testing.T) {
+	ns := appns.MustNewV0(tmrand.Bytes(appns.NamespaceVersionZeroIDSize))
+	b := blob.New(ns, tmrand.Bytes(1000), 0)
+
+	chunks, err := user.SplitBlob(b, user.ChunkHeaderSize+300)
+	require.NoError(t, err)
+	require.Len(t, chunks, 4)
+
+	data := make([][]byte, len(chunks))
+	for i, chunk := range chunks {
+		require.Equal(t, ns, chunk.Namespace())
+		require.LessOrEqual(t, len(chunk.Data), user.ChunkHeaderSize+300)
+		header, _, err := user.ParseChunk(chunk.Data)
+		require.NoError(t, err)
+		require.EqualValues(t, i, header.Index)
+		require.EqualValues(t, 4, header.Total)
+		require.EqualValues(t, 1000, header.Length)
+		// the chunks can be joined in any order
+		data[len(chunks)-1-i] = chunk.Data
+	}
+
+	joined, err := user.JoinChunks(data...)
+	require.NoError(t, err)
+	require.Equal(t, b.Data, joined)
+
+	_, err = user.JoinChunks(data[1:]...)
+	require.Error(t, err)
+	_, err = user.JoinChunks(data[0], data[0], data[1], data[2])
+	require.Error(t, err)
+	_, _, err = user.ParseChunk(b.Data)
+	require.Error(t, err)
+	_, err = user.SplitBlob(b, user.ChunkHeaderSize)
+	require.Error(t, err)
+}
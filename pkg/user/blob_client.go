@@ -0,0 +1,327 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	blobtypes "github.com/celestiaorg/celestia-app/x/blob/types"
+	"github.com/celestiaorg/go-square/blob"
+	"github.com/celestiaorg/go-square/inclusion"
+	"github.com/celestiaorg/go-square/namespace"
+	"github.com/celestiaorg/go-square/shares"
+	"github.com/celestiaorg/go-square/square"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/tendermint/crypto/merkle"
+)
+
+const (
+	// pfbTxBaseSize and pfbTxSizePerBlob are used to estimate the size of
+	// the signed transaction of a PayForBlobs when checking if its blobs fit
+	// into a square. Both are larger than the actual sizes.
+	pfbTxBaseSize    = 1000
+	pfbTxSizePerBlob = 100
+)
+
+// BlobClient submits an arbitrary number of blobs of any size. It packs the
+// blobs into as few PayForBlobs transactions as possible such that each
+// transaction fits into a data square of the current governance max square
+// size. Blobs that don't fit into a square on their own are split into chunks
+// (see SplitBlob). The transactions are submitted concurrently.
+type BlobClient struct {
+	signer *Signer
+	// pool is used to submit the transactions from multiple accounts. If it
+	// is nil, all transactions are signed by signer.
+	pool *SignerPool
+}
+
+// NewBlobClient returns a BlobClient that submits all transactions from the
+// account of the signer. The transactions are broadcast one after another
+// and then confirmed concurrently.
+func NewBlobClient(signer *Signer) *BlobClient {
+	return &BlobClient{signer: signer}
+}
+
+// NewPooledBlobClient returns a BlobClient that submits the transactions
+// concurrently from the sub-accounts of the pool.
+func NewPooledBlobClient(pool *SignerPool) *BlobClient {
+	return &BlobClient{signer: pool.Primary(), pool: pool}
+}
+
+// SubmittedBlob describes the inclusion of a blob passed to Submit. A blob that
+// was split into chunks has one entry per chunk in each slice, in the order of
+// the chunks. Otherwise, each slice has a single entry.
+type SubmittedBlob struct {
+	Namespace namespace.Namespace
+	// Heights are the heights of the blocks the blob was included in.
+	Heights []int64
+	// Commitments are the share commitments of the submitted blobs.
+	Commitments [][]byte
+	// TxHashes are the hashes of the PayForBlobs transactions.
+	TxHashes []string
+}
+
+// Split returns true if the blob was split into chunks.
+func (s SubmittedBlob) Split() bool {
+	return len(s.Commitments) > 1
+}
+
+// pendingBlob is a blob, or a chunk of a blob, that is submitted.
+type pendingBlob struct {
+	blob *blob.Blob
+	// index is the index of the blob passed to Submit and chunk is the index
+	// of the chunk within that blob.
+	index, chunk int
+	shares       int
+}
+
+// Submit submits the blobs and returns where each of them was included, in the
+// order of the blobs. TxOptions are applied to every PayForBlobs transaction;
+// if they don't set a gas limit, the gas and fee are estimated per
+// transaction. If some of the transactions fail, the blobs of the successful
+// transactions are still returned together with an error joining the errors
+// of the failed transactions.
+func (c *BlobClient) Submit(ctx context.Context, blobs []*blob.Blob, opts ...TxOption) ([]SubmittedBlob, error) {
+	if len(blobs) == 0 {
+		return nil, errors.New("no blobs to submit")
+	}
+	maxSquareSize, err := c.MaxSquareSize(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pending, err := c.split(blobs, maxSquareSize)
+	if err != nil {
+		return nil, err
+	}
+	batches := c.pack(pending, maxSquareSize)
+
+	results := make([]SubmittedBlob, len(blobs))
+	for i, b := range blobs {
+		numChunks := 0
+		for _, p := range pending {
+			if p.index == i {
+				numChunks++
+			}
+		}
+		results[i] = SubmittedBlob{
+			Namespace:   b.Namespace(),
+			Heights:     make([]int64, numChunks),
+			Commitments: make([][]byte, numChunks),
+			TxHashes:    make([]string, numChunks),
+		}
+	}
+
+	resps, errs := c.submit(ctx, batches, opts)
+	threshold := appconsts.SubtreeRootThreshold(c.signer.appVersion)
+	for i, batch := range batches {
+		for _, p := range batch {
+			commitment, err := inclusion.CreateCommitment(p.blob, merkle.HashFromByteSlices, threshold)
+			if err != nil {
+				return nil, err
+			}
+			result := &results[p.index]
+			result.Commitments[p.chunk] = commitment
+			if resps[i] != nil {
+				result.TxHashes[p.chunk] = resps[i].TxHash
+			}
+			if errs[i] == nil {
+				result.Heights[p.chunk] = resps[i].Height
+			}
+		}
+	}
+	return results, errors.Join(errs...)
+}
+
+// MaxSquareSize returns the size of the largest data square, which is the
+// smaller of the governance max square size and the square size upper bound
+// of the app version.
+func (c *BlobClient) MaxSquareSize(ctx context.Context) (int, error) {
+	if err := c.signer.checkOnline(); err != nil {
+		return 0, err
+	}
+	resp, err := blobtypes.NewQueryClient(c.signer.grpc).Params(ctx, &blobtypes.QueryParamsRequest{})
+	if err != nil {
+		return 0, fmt.Errorf("error querying blob params: %w", err)
+	}
+	return min(int(resp.Params.GovMaxSquareSize), appconsts.SquareSizeUpperBound(c.signer.appVersion)), nil
+}
+
+// split returns the blobs to submit, splitting the blobs that don't fit into a
+// square on their own into chunks.
+func (c *BlobClient) split(blobs []*blob.Blob, maxSquareSize int) ([]pendingBlob, error) {
+	// the max size of a chunk depends on the share version of the blob
+	maxBlobSizes := make(map[uint8]int)
+	pending := make([]pendingBlob, 0, len(blobs))
+	for i, b := range blobs {
+		if err := b.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid blob %d: %w", i, err)
+		}
+		if c.fits(maxSquareSize, b) {
+			pending = append(pending, newPendingBlob(b, i, 0))
+			continue
+		}
+
+		maxBlobSize, ok := maxBlobSizes[uint8(b.ShareVersion)]
+		if !ok {
+			maxBlobSize = c.maxBlobSize(maxSquareSize, b)
+			maxBlobSizes[uint8(b.ShareVersion)] = maxBlobSize
+		}
+		chunks, err := SplitBlob(b, maxBlobSize)
+		if err != nil {
+			return nil, err
+		}
+		for j, chunk := range chunks {
+			pending = append(pending, newPendingBlob(chunk, i, j))
+		}
+	}
+	return pending, nil
+}
+
+// pack groups the blobs into batches that each fit into a single PayForBlobs
+// transaction. It places the largest blobs first, each into the first batch
+// it fits into.
+func (c *BlobClient) pack(pending []pendingBlob, maxSquareSize int) [][]pendingBlob {
+	sorted := make([]pendingBlob, len(pending))
+	copy(sorted, pending)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].shares > sorted[j].shares
+	})
+
+	var batches [][]pendingBlob
+	for _, p := range sorted {
+		placed := false
+		for i, batch := range batches {
+			if c.fits(maxSquareSize, append(batchBlobs(batch), p.blob)...) {
+				batches[i] = append(batch, p)
+				placed = true
+				break
+			}
+		}
+		if !placed {
+			batches = append(batches, []pendingBlob{p})
+		}
+	}
+	return batches
+}
+
+// submit submits a PayForBlobs transaction for each batch and returns the
+// response and error of each.
+func (c *BlobClient) submit(ctx context.Context, batches [][]pendingBlob, opts []TxOption) ([]*sdktypes.TxResponse, []error) {
+	resps := make([]*sdktypes.TxResponse, len(batches))
+	errs := make([]error, len(batches))
+
+	if c.pool != nil {
+		var wg sync.WaitGroup
+		for i, batch := range batches {
+			wg.Add(1)
+			go func(i int, batch []pendingBlob) {
+				defer wg.Done()
+				resps[i], errs[i] = c.pool.SubmitPayForBlob(ctx, batchBlobs(batch), opts...)
+			}(i, batch)
+		}
+		wg.Wait()
+		return resps, wrapBatchErrors(errs)
+	}
+
+	// a single account broadcasts its transactions in order of their
+	// sequence so that they aren't rejected for a sequence mismatch.
+	var hashes []string
+	var broadcast []int
+	for i, batch := range batches {
+		resps[i], errs[i] = c.broadcast(ctx, batchBlobs(batch), opts)
+		if errs[i] == nil {
+			hashes = append(hashes, resps[i].TxHash)
+			broadcast = append(broadcast, i)
+		}
+	}
+	if len(hashes) > 0 {
+		confirmed, err := c.signer.ConfirmTxs(ctx, hashes...)
+		for j, i := range broadcast {
+			switch {
+			case confirmed[j].TxHash == "":
+				// the tx wasn't found before err was returned
+				errs[i] = err
+			case confirmed[j].Code != 0:
+				resps[i] = confirmed[j]
+				errs[i] = fmt.Errorf("tx failed with code %d: %s", confirmed[j].Code, confirmed[j].RawLog)
+			default:
+				resps[i] = confirmed[j]
+			}
+		}
+	}
+	return resps, wrapBatchErrors(errs)
+}
+
+// broadcast signs and broadcasts a PayForBlobs transaction for the blobs with
+// the client's signer without waiting for it to be confirmed.
+func (c *BlobClient) broadcast(ctx context.Context, blobs []*blob.Blob, opts []TxOption) (*sdktypes.TxResponse, error) {
+	opts, err := c.signer.withEstimatedGas(ctx, opts, func() (uint64, error) {
+		return c.signer.EstimatePayForBlobGas(ctx, blobs)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return c.signer.broadcastWithRetry(ctx, func() ([]byte, error) {
+		return c.signer.CreatePayForBlob(blobs, opts...)
+	})
+}
+
+// fits returns true if a PayForBlobs transaction of the blobs fits into an
+// otherwise empty square of the max square size. This is stricter than the
+// check of the BlobShareDecorator as it includes the padding between blobs.
+func (c *BlobClient) fits(maxSquareSize int, blobs ...*blob.Blob) bool {
+	builder, err := square.NewBuilder(maxSquareSize, appconsts.SubtreeRootThreshold(c.signer.appVersion))
+	if err != nil {
+		return false
+	}
+	tx := make([]byte, pfbTxBaseSize+pfbTxSizePerBlob*len(blobs))
+	return builder.AppendBlobTx(&blob.BlobTx{Tx: tx, Blobs: blobs})
+}
+
+// maxBlobSize returns the largest size of the data of a blob in the namespace
+// and share version of b that fits into a square on its own.
+func (c *BlobClient) maxBlobSize(maxSquareSize int, b *blob.Blob) int {
+	// the data of a blob can't be larger than the blob shares of the square
+	low, high := 0, maxSquareSize*maxSquareSize*appconsts.ContinuationSparseShareContentSize
+	for low < high {
+		mid := (low + high + 1) / 2
+		if c.fits(maxSquareSize, blob.New(b.Namespace(), make([]byte, mid), uint8(b.ShareVersion))) {
+			low = mid
+		} else {
+			high = mid - 1
+		}
+	}
+	return low
+}
+
+func newPendingBlob(b *blob.Blob, index, chunk int) pendingBlob {
+	return pendingBlob{
+		blob:   b,
+		index:  index,
+		chunk:  chunk,
+		shares: shares.SparseSharesNeeded(uint32(len(b.Data))),
+	}
+}
+
+// batchBlobs returns the blobs of the batch.
+func batchBlobs(batch []pendingBlob) []*blob.Blob {
+	blobs := make([]*blob.Blob, len(batch))
+	for i, p := range batch {
+		blobs[i] = p.blob
+	}
+	return blobs
+}
+
+// wrapBatchErrors adds the index of the transaction to each error.
+func wrapBatchErrors(errs []error) []error {
+	for i, err := range errs {
+		if err != nil {
+			errs[i] = fmt.Errorf("PayForBlobs %d: %w", i, err)
+		}
+	}
+	return errs
+}
@@ -0,0 +1,170 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	"github.com/celestiaorg/celestia-app/pkg/proof"
+	"github.com/celestiaorg/go-square/blob"
+	"github.com/celestiaorg/go-square/inclusion"
+	"github.com/celestiaorg/go-square/namespace"
+	"github.com/celestiaorg/go-square/shares"
+	"github.com/celestiaorg/go-square/square"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/tendermint/crypto/merkle"
+	"github.com/tendermint/tendermint/libs/bytes"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	coretypes "github.com/tendermint/tendermint/types"
+)
+
+// ProofClient is the part of the Tendermint RPC client that is used to prove
+// the inclusion of blobs. It is implemented by the clients in
+// github.com/tendermint/tendermint/rpc/client.
+type ProofClient interface {
+	Block(ctx context.Context, height *int64) (*ctypes.ResultBlock, error)
+	ABCIQuery(ctx context.Context, path string, data bytes.HexBytes) (*ctypes.ResultABCIQuery, error)
+}
+
+// BlobInclusion describes the inclusion of a blob in a block together with the
+// proof of its shares to the data root of the block.
+type BlobInclusion struct {
+	Namespace namespace.Namespace
+	// Commitment is the share commitment of the blob.
+	Commitment []byte
+	// Height is the height of the block the blob was included in.
+	Height int64
+	// ShareRange is the end exclusive range of the shares of the blob in the
+	// data square of the block.
+	ShareRange shares.Range
+	// Proof proves the shares of the blob to the data root of the block.
+	Proof *proof.ShareProof
+}
+
+// SetProofClient sets the Tendermint RPC client that is used to prove the
+// inclusion of blobs (see ProveBlobs).
+func (s *Signer) SetProofClient(client ProofClient) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.proofClient = client
+}
+
+// SubmitPayForBlobWithProofs is the same as SubmitPayForBlob but also returns
+// the inclusion of each blob, in the order of the blobs, once the transaction
+// has been confirmed. It requires a proof client to be set.
+func (s *Signer) SubmitPayForBlobWithProofs(ctx context.Context, blobs []*blob.Blob, opts ...TxOption) (*sdktypes.TxResponse, []BlobInclusion, error) {
+	resp, err := s.SubmitPayForBlob(ctx, blobs, opts...)
+	if err != nil {
+		return resp, nil, err
+	}
+	inclusions, err := s.ProveBlobs(ctx, resp)
+	return resp, inclusions, err
+}
+
+// ProveBlobs returns the inclusion of the blobs of a committed PayForBlobs
+// transaction, in the order of the blobs, given its response as returned by
+// ConfirmTx. The share proofs are created by the node through the
+// proof.ShareInclusionQueryPath ABCI query and are validated against the data
+// root of the block before they are returned.
+func (s *Signer) ProveBlobs(ctx context.Context, resp *sdktypes.TxResponse) ([]BlobInclusion, error) {
+	s.mtx.RLock()
+	client := s.proofClient
+	s.mtx.RUnlock()
+	if client == nil {
+		return nil, errors.New("signer has no proof client")
+	}
+	if resp == nil || resp.Height == 0 {
+		return nil, errors.New("tx has not been committed")
+	}
+	if resp.Code != 0 {
+		return nil, fmt.Errorf("tx failed with code %d: %s", resp.Code, resp.RawLog)
+	}
+
+	height := resp.Height
+	blockRes, err := client.Block(ctx, &height)
+	if err != nil {
+		return nil, fmt.Errorf("error querying block %d: %w", height, err)
+	}
+	block := blockRes.Block
+	txIndex, blobTx, err := findBlobTx(block, resp.TxHash)
+	if err != nil {
+		return nil, err
+	}
+
+	pbb, err := block.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	rawBlock, err := pbb.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	appVersion := block.Header.Version.App
+	maxSquareSize := appconsts.SquareSizeUpperBound(appVersion)
+	threshold := appconsts.SubtreeRootThreshold(appVersion)
+	txs := block.Txs.ToSliceOfBytes()
+	inclusions := make([]BlobInclusion, len(blobTx.Blobs))
+	for i, b := range blobTx.Blobs {
+		commitment, err := inclusion.CreateCommitment(b, merkle.HashFromByteSlices, threshold)
+		if err != nil {
+			return nil, err
+		}
+		shareRange, err := square.BlobShareRange(txs, txIndex, i, maxSquareSize, threshold)
+		if err != nil {
+			return nil, err
+		}
+		shareProof, err := queryShareProof(ctx, client, rawBlock, shareRange)
+		if err != nil {
+			return nil, fmt.Errorf("error proving blob %d: %w", i, err)
+		}
+		if err := shareProof.Validate(block.DataHash); err != nil {
+			return nil, fmt.Errorf("invalid proof of blob %d: %w", i, err)
+		}
+		inclusions[i] = BlobInclusion{
+			Namespace:  b.Namespace(),
+			Commitment: commitment,
+			Height:     height,
+			ShareRange: shareRange,
+			Proof:      shareProof,
+		}
+	}
+	return inclusions, nil
+}
+
+// findBlobTx returns the index of the tx with the hash in the block and the
+// blob tx it was submitted as.
+func findBlobTx(block *coretypes.Block, txHash string) (int, *blob.BlobTx, error) {
+	for i, tx := range block.Txs {
+		if !strings.EqualFold(fmt.Sprintf("%X", tx.Hash()), txHash) {
+			continue
+		}
+		blobTx, isBlobTx := blob.UnmarshalBlobTx(tx)
+		if !isBlobTx {
+			return 0, nil, fmt.Errorf("tx %s is not a PayForBlobs tx", txHash)
+		}
+		return i, blobTx, nil
+	}
+	return 0, nil, fmt.Errorf("tx %s not found in block %d", txHash, block.Height)
+}
+
+// queryShareProof queries the node for the proof of the shares in the range of
+// the block.
+func queryShareProof(ctx context.Context, client ProofClient, rawBlock []byte, shareRange shares.Range) (*proof.ShareProof, error) {
+	path := fmt.Sprintf("custom/%s/%d/%d", proof.ShareInclusionQueryPath, shareRange.Start, shareRange.End)
+	res, err := client.ABCIQuery(ctx, path, rawBlock)
+	if err != nil {
+		return nil, err
+	}
+	if !res.Response.IsOK() {
+		return nil, fmt.Errorf("share inclusion query failed with code %d: %s", res.Response.Code, res.Response.Log)
+	}
+
+	shareProof := &proof.ShareProof{}
+	if err := shareProof.Unmarshal(res.Response.Value); err != nil {
+		return nil, err
+	}
+	return shareProof, nil
+}
@@ -0,0 +1,301 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	blobtypes "github.com/celestiaorg/celestia-app/x/blob/types"
+	"github.com/celestiaorg/go-square/blob"
+	"github.com/cosmos/cosmos-sdk/client/grpc/tmservice"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+)
+
+// FeeBumpStrategy determines how the fee of a stuck transaction is increased.
+type FeeBumpStrategy int
+
+const (
+	// LinearFeeBump adds a fixed amount to the fee on every bump.
+	LinearFeeBump FeeBumpStrategy = iota
+	// ExponentialFeeBump multiplies the fee by a fixed factor on every bump.
+	ExponentialFeeBump
+)
+
+// FeeBumpPolicy describes when and by how much the fee of a transaction that
+// has not been included in a block is increased.
+type FeeBumpPolicy struct {
+	// Blocks is the number of blocks to wait for the transaction to be
+	// included before resubmitting it with a higher fee.
+	Blocks int64
+	// Strategy determines how the fee is increased.
+	Strategy FeeBumpStrategy
+	// Increment is the amount of utia added to the fee by a linear bump.
+	Increment uint64
+	// Multiplier is the factor the fee is multiplied by in an exponential bump.
+	// It must be greater than one.
+	Multiplier float64
+	// MaxFee is the maximum fee in utia the transaction is resubmitted with.
+	MaxFee uint64
+}
+
+// NewLinearFeeBumpPolicy returns a policy that adds increment to the fee
+// every time the transaction has not been included for the given number of
+// blocks, until maxFee is reached.
+func NewLinearFeeBumpPolicy(blocks int64, increment, maxFee uint64) FeeBumpPolicy {
+	return FeeBumpPolicy{
+		Blocks:    blocks,
+		Strategy:  LinearFeeBump,
+		Increment: increment,
+		MaxFee:    maxFee,
+	}
+}
+
+// NewExponentialFeeBumpPolicy returns a policy that multiplies the fee by
+// multiplier every time the transaction has not been included for the given
+// number of blocks, until maxFee is reached.
+func NewExponentialFeeBumpPolicy(blocks int64, multiplier float64, maxFee uint64) FeeBumpPolicy {
+	return FeeBumpPolicy{
+		Blocks:     blocks,
+		Strategy:   ExponentialFeeBump,
+		Multiplier: multiplier,
+		MaxFee:     maxFee,
+	}
+}
+
+// ValidateBasic checks that the policy is well formed.
+func (p FeeBumpPolicy) ValidateBasic() error {
+	if p.Blocks < 1 {
+		return errors.New("fee bump policy must wait at least one block")
+	}
+	switch p.Strategy {
+	case LinearFeeBump:
+		if p.Increment == 0 {
+			return errors.New("linear fee bump increment must be greater than zero")
+		}
+	case ExponentialFeeBump:
+		if p.Multiplier <= 1 {
+			return errors.New("exponential fee bump multiplier must be greater than one")
+		}
+	default:
+		return fmt.Errorf("unknown fee bump strategy %d", p.Strategy)
+	}
+	return nil
+}
+
+// NextFee returns the fee to resubmit a transaction with, given its current
+// fee. The returned fee never exceeds MaxFee. If the current fee can not be
+// increased further, false is returned.
+func (p FeeBumpPolicy) NextFee(fee uint64) (uint64, bool) {
+	var next uint64
+	switch p.Strategy {
+	case LinearFeeBump:
+		next = fee + p.Increment
+		if next < fee {
+			next = math.MaxUint64
+		}
+	case ExponentialFeeBump:
+		bumped := math.Ceil(float64(fee) * p.Multiplier)
+		if bumped >= math.MaxUint64 {
+			next = math.MaxUint64
+		} else {
+			next = uint64(bumped)
+		}
+	}
+	if next > p.MaxFee {
+		next = p.MaxFee
+	}
+	return next, next > fee
+}
+
+// SubmitTxWithFeeBump forms a transaction from the provided messages, signs it,
+// and submits it to the chain. If the transaction has not been included after
+// the number of blocks specified by the policy, it is re-signed with the same
+// sequence and a higher fee and resubmitted, replacing the original
+// transaction in the mempool. The initial fee and gas limit are set using the
+// TxOptions or estimated as in SubmitTx.
+func (s *Signer) SubmitTxWithFeeBump(ctx context.Context, msgs []sdktypes.Msg, policy FeeBumpPolicy, opts ...TxOption) (*sdktypes.TxResponse, error) {
+	opts, err := s.withEstimatedGas(ctx, opts, func() (uint64, error) {
+		return s.EstimateGas(ctx, msgs, opts...)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.submitWithFeeBump(ctx, policy, func(sequence, fee uint64) ([]byte, error) {
+		return s.createTxWithSequence(msgs, sequence, append(slices.Clip(opts), SetFee(fee))...)
+	}, opts...)
+}
+
+// SubmitPayForBlobWithFeeBump is the same as SubmitTxWithFeeBump but for a
+// PayForBlobs transaction formed from the provided blobs.
+func (s *Signer) SubmitPayForBlobWithFeeBump(ctx context.Context, blobs []*blob.Blob, policy FeeBumpPolicy, opts ...TxOption) (*sdktypes.TxResponse, error) {
+	msg, err := blobtypes.NewMsgPayForBlobs(s.address.String(), s.appVersion, blobs...)
+	if err != nil {
+		return nil, err
+	}
+
+	opts, err = s.withEstimatedGas(ctx, opts, func() (uint64, error) {
+		return s.EstimatePayForBlobGas(ctx, blobs)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.submitWithFeeBump(ctx, policy, func(sequence, fee uint64) ([]byte, error) {
+		txBytes, err := s.createTxWithSequence([]sdktypes.Msg{msg}, sequence, append(slices.Clip(opts), SetFee(fee))...)
+		if err != nil {
+			return nil, err
+		}
+		return blob.MarshalBlobTx(txBytes, blobs...)
+	}, opts...)
+}
+
+// submitWithFeeBump submits the transaction created by createTx and waits for
+// any of its versions to be included, bumping the fee according to the policy.
+func (s *Signer) submitWithFeeBump(
+	ctx context.Context,
+	policy FeeBumpPolicy,
+	createTx func(sequence, fee uint64) ([]byte, error),
+	opts ...TxOption,
+) (*sdktypes.TxResponse, error) {
+	if err := policy.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	fee := s.txBuilder(opts...).GetTx().GetFee().AmountOf(appconsts.BondDenom).Uint64()
+
+	// the sequence the transaction was signed with, which is corrected if the
+	// first broadcast is rejected because of a sequence mismatch
+	var sequence uint64
+	resp, err := s.broadcastWithRetry(ctx, func() ([]byte, error) {
+		sequence = s.getAndIncrementSequence()
+		return createTx(sequence, fee)
+	})
+	if err != nil {
+		return resp, err
+	}
+	// the hashes of all versions of the transaction that have been submitted
+	hashes := []string{resp.TxHash}
+	defer func() {
+		s.forgetAccepted(hashes...)
+		s.forgetTxs(hashes...)
+	}()
+
+	height, err := s.latestHeight(ctx)
+	if err != nil {
+		return nil, err
+	}
+	bumpHeight := height + policy.Blocks
+	canBump := true
+
+	s.mtx.RLock()
+	pollTime := s.pollTime
+	s.mtx.RUnlock()
+
+	for {
+		// wait for any version to be committed until the next time the fee
+		// may be bumped
+		c := newTxConfirmations(hashes)
+		c.anyTx = true
+		waitCtx, cancel := context.WithTimeout(ctx, pollTime)
+		err := s.confirm(waitCtx, c)
+		timedOut := waitCtx.Err() != nil
+		cancel()
+		if c.complete() {
+			for _, resp := range c.resps {
+				if resp.TxHash != "" {
+					return resp, c.result(nil)
+				}
+			}
+		}
+		if ctx.Err() != nil {
+			return &sdktypes.TxResponse{}, ctx.Err()
+		}
+		if err != nil && !timedOut {
+			return &sdktypes.TxResponse{}, err
+		}
+
+		if canBump {
+			height, err := s.latestHeight(ctx)
+			if err != nil {
+				return &sdktypes.TxResponse{}, err
+			}
+			if height >= bumpHeight {
+				var newFee uint64
+				newFee, canBump = policy.NextFee(fee)
+				if canBump {
+					hash, err := s.replaceTx(ctx, createTx, sequence, newFee)
+					if err != nil {
+						return &sdktypes.TxResponse{}, err
+					}
+					if hash != "" {
+						hashes = append(hashes, hash)
+						fee = newFee
+					}
+					bumpHeight = height + policy.Blocks
+				}
+			}
+		}
+	}
+}
+
+// replaceTx resubmits the transaction with the same sequence and the new fee.
+// It returns the hash of the replacement or an empty string if the original
+// transaction has already been included in a block. Unlike BroadcastTx, a
+// sequence mismatch does not correct the signer's sequence: the replacement
+// is signed with the fixed sequence of the original, so the mismatch only
+// tells whether that sequence has been used up.
+func (s *Signer) replaceTx(ctx context.Context, createTx func(sequence, fee uint64) ([]byte, error), sequence, fee uint64) (string, error) {
+	txBytes, err := createTx(sequence, fee)
+	if err != nil {
+		return "", err
+	}
+	resp, err := s.broadcastTx(ctx, txBytes)
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case resp.Code == 0:
+		return resp.TxHash, nil
+	case isSequenceMismatch(resp):
+		// a previous version of the tx was committed in the meantime
+		return "", nil
+	default:
+		return "", fmt.Errorf("replacement tx failed with code %d: %s", resp.Code, resp.RawLog)
+	}
+}
+
+// createTxWithSequence forms a transaction from the provided messages and
+// signs it with the provided sequence without incrementing the signer's
+// sequence.
+func (s *Signer) createTxWithSequence(msgs []sdktypes.Msg, sequence uint64, opts ...TxOption) ([]byte, error) {
+	txBuilder := s.txBuilder(opts...)
+	if err := txBuilder.SetMsgs(msgs...); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkSigners(txBuilder); err != nil {
+		return nil, err
+	}
+
+	if err := s.signTransactionWithSequence(txBuilder, sequence); err != nil {
+		return nil, err
+	}
+
+	return s.enc.TxEncoder()(txBuilder.GetTx())
+}
+
+// latestHeight returns the height of the latest block.
+func (s *Signer) latestHeight(ctx context.Context) (int64, error) {
+	if err := s.checkOnline(); err != nil {
+		return 0, err
+	}
+	resp, err := tmservice.NewServiceClient(s.grpc).GetLatestBlock(ctx, &tmservice.GetLatestBlockRequest{})
+	if err != nil {
+		return 0, err
+	}
+	return resp.SdkBlock.Header.Height, nil
+}
@@ -0,0 +1,191 @@
+package user_test
+
+import (
+	"context"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/celestiaorg/celestia-app/app"
+	"github.com/celestiaorg/celestia-app/app/encoding"
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	"github.com/celestiaorg/celestia-app/pkg/user"
+	"github.com/celestiaorg/celestia-app/test/util/testfactory"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	bank "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	coretypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+func TestFeeBumpPolicy(t *testing.T) {
+	testCases := []struct {
+		name     string
+		policy   user.FeeBumpPolicy
+		fee      uint64
+		wantFee  uint64
+		wantBump bool
+	}{
+		{"linear", user.NewLinearFeeBumpPolicy(1, 100, 1000), 200, 300, true},
+		{"linear capped at max fee", user.NewLinearFeeBumpPolicy(1, 100, 250), 200, 250, true},
+		{"linear at max fee", user.NewLinearFeeBumpPolicy(1, 100, 200), 200, 200, false},
+		{"linear overflow", user.NewLinearFeeBumpPolicy(1, 100, math.MaxUint64), math.MaxUint64 - 1, math.MaxUint64, true},
+		{"exponential", user.NewExponentialFeeBumpPolicy(1, 1.5, 1000), 200, 300, true},
+		{"exponential rounds up", user.NewExponentialFeeBumpPolicy(1, 1.1, 1000), 5, 6, true},
+		{"exponential capped at max fee", user.NewExponentialFeeBumpPolicy(1, 2, 300), 200, 300, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.NoError(t, tc.policy.ValidateBasic())
+			fee, ok := tc.policy.NextFee(tc.fee)
+			require.Equal(t, tc.wantBump, ok)
+			require.Equal(t, tc.wantFee, fee)
+		})
+	}
+}
+
+func TestFeeBumpPolicyValidateBasic(t *testing.T) {
+	require.Error(t, user.NewLinearFeeBumpPolicy(0, 100, 1000).ValidateBasic())
+	require.Error(t, user.NewLinearFeeBumpPolicy(1, 0, 1000).ValidateBasic())
+	require.Error(t, user.NewExponentialFeeBumpPolicy(1, 1, 1000).ValidateBasic())
+	require.Error(t, user.FeeBumpPolicy{Blocks: 1, Strategy: 2}.ValidateBasic())
+}
+
+func TestSubmitTxWithFeeBumpKeepsSequence(t *testing.T) {
+	encCfg := encoding.MakeConfig(app.ModuleEncodingRegisters...)
+	kr := testfactory.TestKeyring(encCfg.Codec, "signer")
+	address := testfactory.GetAddress(kr, "signer")
+	msg := bank.NewMsgSend(address, address, sdk.NewCoins(sdk.NewInt64Coin(appconsts.BondDenom, 10)))
+
+	var (
+		mtx        sync.Mutex
+		broadcasts int
+	)
+	service := &mockTxService{
+		broadcast: func([]byte) *sdk.TxResponse {
+			mtx.Lock()
+			defer mtx.Unlock()
+			broadcasts++
+			if broadcasts == 1 {
+				return &sdk.TxResponse{TxHash: "ORIGINAL"}
+			}
+			// the original and later txs signed elsewhere were committed
+			// before the original could be replaced
+			return sequenceMismatch(5, 0)
+		},
+		getTx: func(hash string) *sdk.TxResponse {
+			mtx.Lock()
+			defer mtx.Unlock()
+			if hash == "ORIGINAL" && broadcasts > 1 {
+				return &sdk.TxResponse{TxHash: hash, Height: 2}
+			}
+			return nil
+		},
+	}
+	conn := newMockTxServiceConn(t, encCfg, service)
+	signer, err := user.NewSigner(kr, conn, address, encCfg.TxConfig, "chain", 1, 0, appconsts.LatestVersion)
+	require.NoError(t, err)
+	signer.SetPollTime(10 * time.Millisecond)
+
+	policy := user.NewLinearFeeBumpPolicy(1, 1000, 10_000)
+	resp, err := signer.SubmitTxWithFeeBump(context.Background(), []sdk.Msg{msg}, policy, user.SetGasLimit(100_000), user.SetFee(1000))
+	require.NoError(t, err)
+	require.Equal(t, "ORIGINAL", resp.TxHash)
+	// the mismatch of the replacement does not move the sequence of the
+	// signer, which was incremented once for the original
+	require.Equal(t, uint64(1), signer.Sequence())
+}
+
+func TestSubmitTxWithFeeBumpRetriesSequenceMismatch(t *testing.T) {
+	encCfg := encoding.MakeConfig(app.ModuleEncodingRegisters...)
+	kr := testfactory.TestKeyring(encCfg.Codec, "signer")
+	address := testfactory.GetAddress(kr, "signer")
+	msg := bank.NewMsgSend(address, address, sdk.NewCoins(sdk.NewInt64Coin(appconsts.BondDenom, 10)))
+
+	var (
+		mtx       sync.Mutex
+		sequences []uint64
+	)
+	service := &mockTxService{
+		broadcast: func(txBytes []byte) *sdk.TxResponse {
+			sdkTx, err := encCfg.TxConfig.TxDecoder()(txBytes)
+			require.NoError(t, err)
+			sigs, err := sdkTx.(authsigning.SigVerifiableTx).GetSignaturesV2()
+			require.NoError(t, err)
+
+			mtx.Lock()
+			defer mtx.Unlock()
+			sequences = append(sequences, sigs[0].Sequence)
+			if len(sequences) == 1 {
+				// txs signed elsewhere were committed in the meantime
+				return sequenceMismatch(3, 0)
+			}
+			return &sdk.TxResponse{TxHash: "RETRIED"}
+		},
+		getTx: func(hash string) *sdk.TxResponse {
+			if hash == "RETRIED" {
+				return &sdk.TxResponse{TxHash: hash, Height: 2}
+			}
+			return nil
+		},
+	}
+	conn := newMockTxServiceConn(t, encCfg, service)
+	signer, err := user.NewSigner(kr, conn, address, encCfg.TxConfig, "chain", 1, 0, appconsts.LatestVersion)
+	require.NoError(t, err)
+	signer.SetPollTime(10 * time.Millisecond)
+
+	policy := user.NewLinearFeeBumpPolicy(1, 1000, 10_000)
+	resp, err := signer.SubmitTxWithFeeBump(context.Background(), []sdk.Msg{msg}, policy, user.SetGasLimit(100_000), user.SetFee(1000))
+	require.NoError(t, err)
+	require.Equal(t, "RETRIED", resp.TxHash)
+	// the tx is re-signed with the expected sequence
+	require.Equal(t, []uint64{0, 3}, sequences)
+	require.Equal(t, uint64(4), signer.Sequence())
+}
+
+func TestSubmitTxWithFeeBumpConfirmsWithEvents(t *testing.T) {
+	encCfg := encoding.MakeConfig(app.ModuleEncodingRegisters...)
+	kr := testfactory.TestKeyring(encCfg.Codec, "signer")
+	address := testfactory.GetAddress(kr, "signer")
+	msg := bank.NewMsgSend(address, address, sdk.NewCoins(sdk.NewInt64Coin(appconsts.BondDenom, 10)))
+
+	// the node is queried for the tx once it is waited for
+	queried := make(chan struct{}, 1)
+	service := &mockTxService{
+		broadcast: func([]byte) *sdk.TxResponse { return &sdk.TxResponse{TxHash: "ORIGINAL"} },
+		getTx: func(string) *sdk.TxResponse {
+			select {
+			case queried <- struct{}{}:
+			default:
+			}
+			return nil
+		},
+	}
+	conn := newMockTxServiceConn(t, encCfg, service)
+	signer, err := user.NewSigner(kr, conn, address, encCfg.TxConfig, "chain", 1, 0, appconsts.LatestVersion)
+	require.NoError(t, err)
+	// the tx would not be polled before the test times out
+	signer.SetPollTime(time.Minute)
+	client := &channelEventsClient{events: make(chan coretypes.ResultEvent)}
+	signer.SetEventClient(client)
+
+	go func() {
+		<-queried
+		client.events <- coretypes.ResultEvent{
+			Data:   tmtypes.EventDataTx{TxResult: abci.TxResult{Height: 2}},
+			Events: map[string][]string{tmtypes.TxHashKey: {"ORIGINAL"}},
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	policy := user.NewLinearFeeBumpPolicy(100, 1000, 10_000)
+	resp, err := signer.SubmitTxWithFeeBump(ctx, []sdk.Msg{msg}, policy, user.SetGasLimit(100_000), user.SetFee(1000))
+	require.NoError(t, err)
+	require.Equal(t, "ORIGINAL", resp.TxHash)
+	require.EqualValues(t, 2, resp.Height)
+}
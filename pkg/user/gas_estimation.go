@@ -0,0 +1,153 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	blobtypes "github.com/celestiaorg/celestia-app/x/blob/types"
+	"github.com/celestiaorg/go-square/blob"
+	nodeservice "github.com/cosmos/cosmos-sdk/client/grpc/node"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultGasMultiplier is the default factor that estimated gas is multiplied
+// by to account for the difference between the estimate and the gas consumed
+// when the transaction is executed.
+const DefaultGasMultiplier = 1.1
+
+// EstimateGas simulates a transaction formed from the provided messages and
+// returns the gas it consumed. The transaction is signed with the signer's
+// current sequence, which is not incremented. If the options don't set a fee,
+// the transaction is simulated with a minimal fee so that the gas consumed by
+// deducting the fee is included in the estimate.
+func (s *Signer) EstimateGas(ctx context.Context, msgs []sdktypes.Msg, opts ...TxOption) (uint64, error) {
+	if err := s.checkOnline(); err != nil {
+		return 0, err
+	}
+	if s.txBuilder(opts...).GetTx().GetFee().IsZero() {
+		opts = append([]TxOption{SetFee(1)}, opts...)
+	}
+	txBytes, err := s.createTxWithSequence(msgs, s.Sequence(), opts...)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := tx.NewServiceClient(s.grpc).Simulate(ctx, &tx.SimulateRequest{TxBytes: txBytes})
+	if err != nil {
+		return 0, fmt.Errorf("error simulating tx: %w", err)
+	}
+	return resp.GasInfo.GasUsed, nil
+}
+
+// EstimatePayForBlobGas returns the gas that a PayForBlobs transaction for the
+// provided blobs consumes. PayForBlobs transactions can not be simulated
+// because the blobs are not part of the simulated transaction, so the gas is
+// estimated using the chain's gas per blob byte and tx size cost parameters.
+func (s *Signer) EstimatePayForBlobGas(ctx context.Context, blobs []*blob.Blob) (uint64, error) {
+	if err := s.checkOnline(); err != nil {
+		return 0, err
+	}
+	blobParams, err := blobtypes.NewQueryClient(s.grpc).Params(ctx, &blobtypes.QueryParamsRequest{})
+	if err != nil {
+		return 0, fmt.Errorf("error querying blob params: %w", err)
+	}
+	authParams, err := authtypes.NewQueryClient(s.grpc).Params(ctx, &authtypes.QueryParamsRequest{})
+	if err != nil {
+		return 0, fmt.Errorf("error querying auth params: %w", err)
+	}
+
+	blobSizes := make([]uint32, len(blobs))
+	for i, b := range blobs {
+		blobSizes[i] = uint32(len(b.Data))
+	}
+	return blobtypes.EstimateGas(blobSizes, blobParams.Params.GasPerBlobByte, authParams.Params.TxSizeCostPerByte), nil
+}
+
+// MinGasPrice returns the gas price in utia that a transaction must pay to be
+// accepted by the node. It is the larger of the node's locally configured
+// minimum gas price and the global minimum gas price.
+func (s *Signer) MinGasPrice(ctx context.Context) (float64, error) {
+	if err := s.checkOnline(); err != nil {
+		return 0, err
+	}
+	minGasPrice := appconsts.GlobalMinGasPrice(s.appVersion)
+
+	resp, err := nodeservice.NewServiceClient(s.grpc).Config(ctx, &nodeservice.ConfigRequest{})
+	if err != nil {
+		// older nodes may not serve their config in which case the global
+		// minimum gas price is used.
+		if status.Code(err) == codes.Unimplemented {
+			return minGasPrice, nil
+		}
+		return 0, fmt.Errorf("error querying node config: %w", err)
+	}
+	if resp.MinimumGasPrice == "" {
+		return minGasPrice, nil
+	}
+
+	prices, err := sdktypes.ParseDecCoins(resp.MinimumGasPrice)
+	if err != nil {
+		return 0, fmt.Errorf("error parsing node minimum gas price %q: %w", resp.MinimumGasPrice, err)
+	}
+	nodeMinGasPrice, err := prices.AmountOf(appconsts.BondDenom).Float64()
+	if err != nil {
+		return 0, err
+	}
+	return math.Max(minGasPrice, nodeMinGasPrice), nil
+}
+
+// SetGasMultiplier sets the factor that estimated gas is multiplied by. It must
+// be at least one.
+func (s *Signer) SetGasMultiplier(multiplier float64) error {
+	if multiplier < 1 {
+		return errors.New("gas multiplier must be at least one")
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.gasMultiplier = multiplier
+	return nil
+}
+
+// GasMultiplier returns the factor that estimated gas is multiplied by.
+func (s *Signer) GasMultiplier() float64 {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	return s.gasMultiplier
+}
+
+// withEstimatedGas returns the options with a gas limit and fee added if the
+// options don't set a gas limit. The gas limit is the gas returned by estimate
+// multiplied by the signer's gas multiplier. The fee is only added if the
+// options don't set one and is derived from the minimum gas price of the node.
+func (s *Signer) withEstimatedGas(ctx context.Context, opts []TxOption, estimate func() (uint64, error)) ([]TxOption, error) {
+	builder := s.txBuilder(opts...)
+	if builder.GetTx().GetGas() != 0 {
+		return opts, nil
+	}
+
+	gas, err := estimate()
+	if err != nil {
+		return nil, err
+	}
+	gasLimit := uint64(math.Ceil(float64(gas) * s.GasMultiplier()))
+
+	estimatedOpts := make([]TxOption, len(opts), len(opts)+2)
+	copy(estimatedOpts, opts)
+	estimatedOpts = append(estimatedOpts, SetGasLimit(gasLimit))
+	if !builder.GetTx().GetFee().IsZero() {
+		return estimatedOpts, nil
+	}
+
+	gasPrice, err := s.MinGasPrice(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return append(estimatedOpts, SetFee(uint64(math.Ceil(gasPrice*float64(gasLimit))))), nil
+}
@@ -0,0 +1,390 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/celestiaorg/go-square/blob"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/tx"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	coretypes "github.com/tendermint/tendermint/types"
+	dbm "github.com/tendermint/tm-db"
+)
+
+// JournalEntry is a transaction that was signed by the signer and broadcast but
+// has not been confirmed yet.
+type JournalEntry struct {
+	// TxHash is the upper case hex encoded hash of the transaction.
+	TxHash string `json:"tx_hash"`
+	// Sequence is the sequence the transaction was signed with.
+	Sequence uint64 `json:"sequence"`
+	// TxBytes are the encoded transaction, including the blobs of a
+	// PayForBlobs transaction, so that it can be resubmitted unchanged.
+	TxBytes []byte `json:"tx_bytes"`
+}
+
+// Journal persists the transactions of a signer that are not confirmed yet so
+// that they can be reconciled with the chain after a restart (see
+// Signer.ReconcileJournal). A journal must only be used by a single signer.
+type Journal interface {
+	// Put adds or replaces the entry with the hash of the transaction.
+	Put(entry JournalEntry) error
+	// Delete removes the entry with the hash. Deleting an entry that doesn't
+	// exist is not an error.
+	Delete(txHash string) error
+	// Entries returns all entries ordered by sequence.
+	Entries() ([]JournalEntry, error)
+}
+
+// DBJournal is a Journal that stores its entries in a tm-db database.
+type DBJournal struct {
+	db dbm.DB
+}
+
+var _ Journal = (*DBJournal)(nil)
+
+// journalKeyPrefix is the prefix of the keys of the entries in a DBJournal.
+var journalKeyPrefix = []byte("journal/")
+
+// NewDBJournal returns a journal that stores its entries in db.
+func NewDBJournal(db dbm.DB) *DBJournal {
+	return &DBJournal{db: db}
+}
+
+func (j *DBJournal) Put(entry JournalEntry) error {
+	bz, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return j.db.SetSync(journalKey(entry.TxHash), bz)
+}
+
+func (j *DBJournal) Delete(txHash string) error {
+	return j.db.DeleteSync(journalKey(txHash))
+}
+
+func (j *DBJournal) Entries() ([]JournalEntry, error) {
+	it, err := dbm.IteratePrefix(j.db, journalKeyPrefix)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	var entries []JournalEntry
+	for ; it.Valid(); it.Next() {
+		var entry JournalEntry
+		if err := json.Unmarshal(it.Value(), &entry); err != nil {
+			return nil, fmt.Errorf("decoding journal entry %s: %w", it.Key(), err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+	sortJournalEntries(entries)
+	return entries, nil
+}
+
+func journalKey(txHash string) []byte {
+	return append(append([]byte{}, journalKeyPrefix...), txHash...)
+}
+
+// FileJournal is a Journal that stores its entries as JSON in a single local
+// file. The file is rewritten atomically on every change.
+type FileJournal struct {
+	path string
+
+	mtx     sync.Mutex
+	entries map[string]JournalEntry
+}
+
+var _ Journal = (*FileJournal)(nil)
+
+// NewFileJournal returns a journal that stores its entries in the file at
+// path. If the file exists, the entries it contains are loaded.
+func NewFileJournal(path string) (*FileJournal, error) {
+	j := &FileJournal{
+		path:    path,
+		entries: make(map[string]JournalEntry),
+	}
+
+	bz, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return j, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []JournalEntry
+	if err := json.Unmarshal(bz, &entries); err != nil {
+		return nil, fmt.Errorf("decoding journal %s: %w", path, err)
+	}
+	for _, entry := range entries {
+		j.entries[entry.TxHash] = entry
+	}
+	return j, nil
+}
+
+func (j *FileJournal) Put(entry JournalEntry) error {
+	j.mtx.Lock()
+	defer j.mtx.Unlock()
+	prev, existed := j.entries[entry.TxHash]
+	j.entries[entry.TxHash] = entry
+	if err := j.write(); err != nil {
+		if existed {
+			j.entries[entry.TxHash] = prev
+		} else {
+			delete(j.entries, entry.TxHash)
+		}
+		return err
+	}
+	return nil
+}
+
+func (j *FileJournal) Delete(txHash string) error {
+	j.mtx.Lock()
+	defer j.mtx.Unlock()
+	prev, ok := j.entries[txHash]
+	if !ok {
+		return nil
+	}
+	delete(j.entries, txHash)
+	if err := j.write(); err != nil {
+		j.entries[txHash] = prev
+		return err
+	}
+	return nil
+}
+
+func (j *FileJournal) Entries() ([]JournalEntry, error) {
+	j.mtx.Lock()
+	defer j.mtx.Unlock()
+	return j.sortedEntries(), nil
+}
+
+func (j *FileJournal) sortedEntries() []JournalEntry {
+	entries := make([]JournalEntry, 0, len(j.entries))
+	for _, entry := range j.entries {
+		entries = append(entries, entry)
+	}
+	sortJournalEntries(entries)
+	return entries
+}
+
+// write writes the entries to a temporary file which then replaces the
+// journal file so that a crash never leaves a partially written journal.
+func (j *FileJournal) write() error {
+	bz, err := json.Marshal(j.sortedEntries())
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(j.path), filepath.Base(j.path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(bz); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), j.path)
+}
+
+// sortJournalEntries sorts the entries by sequence and then by hash.
+func sortJournalEntries(entries []JournalEntry) {
+	sort.Slice(entries, func(i, k int) bool {
+		if entries[i].Sequence != entries[k].Sequence {
+			return entries[i].Sequence < entries[k].Sequence
+		}
+		return entries[i].TxHash < entries[k].TxHash
+	})
+}
+
+// SetJournal sets the journal that the signer records its transactions in. A
+// transaction signed by the signer is added to the journal before it is
+// broadcast and removed once it has been confirmed or was rejected by the
+// node. After a restart, ReconcileJournal must be called before the signer
+// submits new transactions. Setting the journal to nil disables journaling.
+func (s *Signer) SetJournal(journal Journal) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.journal = journal
+}
+
+// JournalReconciliation is the result of ReconcileJournal.
+type JournalReconciliation struct {
+	// Confirmed are the responses of the journaled transactions that have
+	// been committed, including those that failed with a non-zero code.
+	Confirmed []*sdktypes.TxResponse
+	// Resubmitted are the journaled transactions that have not been committed
+	// and were broadcast again or are still in the mempool. They remain in
+	// the journal until they are confirmed with ConfirmTx or ConfirmTxs.
+	Resubmitted []JournalEntry
+	// Dropped are the journaled transactions that will never be committed,
+	// either because another transaction with the same sequence was committed
+	// or because the node rejected them when they were resubmitted. They are
+	// removed from the journal and must be submitted again if desired.
+	Dropped []JournalEntry
+}
+
+// ReconcileJournal compares the journaled transactions with the chain. Entries
+// of committed transactions and of transactions whose sequence has been used
+// by another transaction are removed. The remaining transactions are
+// resubmitted in order of their sequence and the signer's sequence is set to
+// follow the last of them.
+func (s *Signer) ReconcileJournal(ctx context.Context) (*JournalReconciliation, error) {
+	s.mtx.RLock()
+	journal := s.journal
+	s.mtx.RUnlock()
+	if journal == nil {
+		return nil, errors.New("signer has no journal")
+	}
+	if err := s.checkOnline(); err != nil {
+		return nil, err
+	}
+
+	entries, err := journal.Entries()
+	if err != nil {
+		return nil, err
+	}
+	_, sequence, err := queryAccount(ctx, s.grpc, s.registry, s.address.String())
+	if err != nil {
+		return nil, err
+	}
+
+	result := &JournalReconciliation{}
+	txClient := tx.NewServiceClient(s.grpc)
+	var uncommitted []JournalEntry
+	for _, entry := range entries {
+		resp, err := txClient.GetTx(ctx, &tx.GetTxRequest{Hash: entry.TxHash})
+		switch {
+		case err == nil:
+			if err := journal.Delete(entry.TxHash); err != nil {
+				return nil, err
+			}
+			result.Confirmed = append(result.Confirmed, resp.TxResponse)
+		case !strings.Contains(err.Error(), "not found"):
+			return nil, err
+		case entry.Sequence < sequence:
+			// another tx with the same sequence, e.g. a replacement
+			// with a higher fee, has been committed
+			if err := journal.Delete(entry.TxHash); err != nil {
+				return nil, err
+			}
+			result.Dropped = append(result.Dropped, entry)
+		default:
+			uncommitted = append(uncommitted, entry)
+		}
+	}
+
+	for _, entry := range uncommitted {
+		resp, err := txClient.BroadcastTx(ctx, &tx.BroadcastTxRequest{
+			Mode:    tx.BroadcastMode_BROADCAST_MODE_SYNC,
+			TxBytes: entry.TxBytes,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if resp.TxResponse.Code == 0 || isTxInMempool(resp.TxResponse) {
+			result.Resubmitted = append(result.Resubmitted, entry)
+			sequence = max(sequence, entry.Sequence+1)
+			continue
+		}
+		if err := journal.Delete(entry.TxHash); err != nil {
+			return nil, err
+		}
+		result.Dropped = append(result.Dropped, entry)
+	}
+
+	s.ForceSetSequence(sequence)
+	return result, nil
+}
+
+// journalTx adds the tx to the journal if one is set and the tx is signed by
+// the signer. It returns the hash of the journaled tx or an empty string.
+func (s *Signer) journalTx(txBytes []byte) (string, error) {
+	s.mtx.RLock()
+	journal := s.journal
+	s.mtx.RUnlock()
+	if journal == nil {
+		return "", nil
+	}
+
+	sequence, ok := s.txSequence(txBytes)
+	if !ok {
+		return "", nil
+	}
+	hash := fmt.Sprintf("%X", coretypes.Tx(txBytes).Hash())
+	err := journal.Put(JournalEntry{TxHash: hash, Sequence: sequence, TxBytes: txBytes})
+	if err != nil {
+		return "", fmt.Errorf("adding tx to journal: %w", err)
+	}
+	return hash, nil
+}
+
+// forgetTxs removes the txs from the journal if one is set. Failing to remove
+// an entry is not an error as stale entries are dropped by ReconcileJournal.
+func (s *Signer) forgetTxs(txHashes ...string) {
+	s.mtx.RLock()
+	journal := s.journal
+	s.mtx.RUnlock()
+	if journal == nil {
+		return
+	}
+	for _, hash := range txHashes {
+		_ = journal.Delete(strings.ToUpper(hash))
+	}
+}
+
+// txSequence returns the sequence of the signer's signature of the tx. It
+// returns false if the tx is not signed by the signer.
+func (s *Signer) txSequence(txBytes []byte) (uint64, bool) {
+	if blobTx, isBlobTx := blob.UnmarshalBlobTx(txBytes); isBlobTx {
+		txBytes = blobTx.Tx
+	}
+	sdkTx, err := s.enc.TxDecoder()(txBytes)
+	if err != nil {
+		return 0, false
+	}
+	sigTx, ok := sdkTx.(authsigning.SigVerifiableTx)
+	if !ok {
+		return 0, false
+	}
+	sigs, err := sigTx.GetSignaturesV2()
+	if err != nil {
+		return 0, false
+	}
+	for _, sig := range sigs {
+		if sig.PubKey != nil && sig.PubKey.Equals(s.pk) {
+			return sig.Sequence, true
+		}
+	}
+	return 0, false
+}
+
+// isTxInMempool returns true if the tx was rejected because it is already in
+// the mempool of the node.
+func isTxInMempool(resp *sdktypes.TxResponse) bool {
+	return resp != nil &&
+		resp.Codespace == sdkerrors.ErrTxInMempoolCache.Codespace() &&
+		resp.Code == sdkerrors.ErrTxInMempoolCache.ABCICode()
+}
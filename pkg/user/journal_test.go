@@ -0,0 +1,48 @@
+package user_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/celestiaorg/celestia-app/pkg/user"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+)
+
+func TestJournal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.json")
+	fileJournal, err := user.NewFileJournal(path)
+	require.NoError(t, err)
+
+	journals := map[string]user.Journal{
+		"file": fileJournal,
+		"db":   user.NewDBJournal(dbm.NewMemDB()),
+	}
+	for name, journal := range journals {
+		t.Run(name, func(t *testing.T) {
+			entries, err := journal.Entries()
+			require.NoError(t, err)
+			require.Empty(t, entries)
+
+			require.NoError(t, journal.Put(user.JournalEntry{TxHash: "B", Sequence: 2, TxBytes: []byte{2}}))
+			require.NoError(t, journal.Put(user.JournalEntry{TxHash: "A", Sequence: 1, TxBytes: []byte{1}}))
+			require.NoError(t, journal.Put(user.JournalEntry{TxHash: "C", Sequence: 3, TxBytes: []byte{3}}))
+			require.NoError(t, journal.Delete("C"))
+			require.NoError(t, journal.Delete("unknown"))
+
+			entries, err = journal.Entries()
+			require.NoError(t, err)
+			require.Equal(t, []user.JournalEntry{
+				{TxHash: "A", Sequence: 1, TxBytes: []byte{1}},
+				{TxHash: "B", Sequence: 2, TxBytes: []byte{2}},
+			}, entries)
+		})
+	}
+
+	// the entries of a file journal survive a restart
+	reopened, err := user.NewFileJournal(path)
+	require.NoError(t, err)
+	entries, err := reopened.Entries()
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+}
@@ -0,0 +1,280 @@
+package user
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	blobtypes "github.com/celestiaorg/celestia-app/x/blob/types"
+	"github.com/celestiaorg/go-square/blob"
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/codec/legacy"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/multisig"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	multisigtypes "github.com/cosmos/cosmos-sdk/crypto/types/multisig"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+)
+
+// multisigSignMode is the sign mode used by the members of a multisig account.
+// SIGN_MODE_DIRECT can't be used because the sign bytes would include the
+// signer info of the multisig, which depends on which members sign.
+const multisigSignMode = signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON
+
+// MultisigPayForBlob is a PayForBlobs transaction of a multisig account. It
+// holds everything the members of the multisig need to sign the transaction
+// offline. The partial signatures of the members are combined into a signed
+// BlobTx with Combine.
+type MultisigPayForBlob struct {
+	enc           client.TxConfig
+	builder       client.TxBuilder
+	pubKey        *multisig.LegacyAminoPubKey
+	chainID       string
+	accountNumber uint64
+	sequence      uint64
+	blobs         []*blob.Blob
+}
+
+// NewMultisigPayForBlob returns an unsigned PayForBlobs transaction for the
+// provided blobs paid for by the multisig account with the provided public
+// key. TxOptions may be provided to set the fee and gas limit.
+func NewMultisigPayForBlob(
+	enc client.TxConfig,
+	pubKey cryptotypes.PubKey,
+	chainID string,
+	accountNumber, sequence, appVersion uint64,
+	blobs []*blob.Blob,
+	opts ...TxOption,
+) (*MultisigPayForBlob, error) {
+	multisigPubKey, ok := pubKey.(*multisig.LegacyAminoPubKey)
+	if !ok {
+		return nil, fmt.Errorf("expected a multisig public key, got %T", pubKey)
+	}
+
+	address := sdktypes.AccAddress(pubKey.Address())
+	msg, err := blobtypes.NewMsgPayForBlobs(address.String(), appVersion, blobs...)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := enc.NewTxBuilder()
+	for _, opt := range opts {
+		builder = opt(builder)
+	}
+	if err := builder.SetMsgs(msg); err != nil {
+		return nil, err
+	}
+
+	return &MultisigPayForBlob{
+		enc:           enc,
+		builder:       builder,
+		pubKey:        multisigPubKey,
+		chainID:       chainID,
+		accountNumber: accountNumber,
+		sequence:      sequence,
+		blobs:         blobs,
+	}, nil
+}
+
+// Address returns the address of the multisig account.
+func (m *MultisigPayForBlob) Address() sdktypes.AccAddress {
+	return sdktypes.AccAddress(m.pubKey.Address())
+}
+
+// PubKey returns the public key of the multisig account.
+func (m *MultisigPayForBlob) PubKey() *multisig.LegacyAminoPubKey {
+	return m.pubKey
+}
+
+// Blobs returns the blobs that are paid for.
+func (m *MultisigPayForBlob) Blobs() []*blob.Blob {
+	return m.blobs
+}
+
+// Tx returns the unsigned transaction.
+func (m *MultisigPayForBlob) Tx() authsigning.Tx {
+	return m.builder.GetTx()
+}
+
+// Sign returns the partial signature of the member of the multisig with the
+// provided address. The signature is created by the backend holding the
+// member's key.
+func (m *MultisigPayForBlob) Sign(backend SigningBackend, member sdktypes.AccAddress) (signing.SignatureV2, error) {
+	pk, err := backend.PubKey(member)
+	if err != nil {
+		return signing.SignatureV2{}, err
+	}
+	if !m.isMember(pk) {
+		return signing.SignatureV2{}, fmt.Errorf("%s is not a member of multisig %s", member, m.Address())
+	}
+
+	signBytes, err := m.signBytes()
+	if err != nil {
+		return signing.SignatureV2{}, err
+	}
+	signature, err := backend.Sign(member, signBytes)
+	if err != nil {
+		return signing.SignatureV2{}, fmt.Errorf("error signing bytes: %w", err)
+	}
+
+	return signing.SignatureV2{
+		PubKey: pk,
+		Data: &signing.SingleSignatureData{
+			SignMode:  multisigSignMode,
+			Signature: signature,
+		},
+		Sequence: m.sequence,
+	}, nil
+}
+
+// Combine verifies the partial signatures of the members and combines them
+// into the signature of the multisig. It returns the signed BlobTx, which
+// requires at least the threshold number of signatures.
+func (m *MultisigPayForBlob) Combine(signatures ...signing.SignatureV2) ([]byte, error) {
+	signBytes, err := m.signBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	multisigData := multisigtypes.NewMultisig(len(m.pubKey.PubKeys))
+	signers := make(map[string]bool)
+	for _, sig := range signatures {
+		data, ok := sig.Data.(*signing.SingleSignatureData)
+		if !ok || data.SignMode != multisigSignMode {
+			return nil, fmt.Errorf("partial signatures must be single signatures with sign mode %s", multisigSignMode)
+		}
+		if !m.isMember(sig.PubKey) {
+			return nil, fmt.Errorf("%s is not a member of multisig %s", sdktypes.AccAddress(sig.PubKey.Address()), m.Address())
+		}
+		if sig.Sequence != m.sequence {
+			return nil, fmt.Errorf("signature of %s has sequence %d, expected %d", sdktypes.AccAddress(sig.PubKey.Address()), sig.Sequence, m.sequence)
+		}
+		if !sig.PubKey.VerifySignature(signBytes, data.Signature) {
+			return nil, fmt.Errorf("invalid signature of %s", sdktypes.AccAddress(sig.PubKey.Address()))
+		}
+		if signers[sig.PubKey.Address().String()] {
+			continue
+		}
+		signers[sig.PubKey.Address().String()] = true
+		if err := multisigtypes.AddSignatureV2(multisigData, sig, m.pubKey.GetPubKeys()); err != nil {
+			return nil, err
+		}
+	}
+	if len(signers) < int(m.pubKey.Threshold) {
+		return nil, fmt.Errorf("got %d signatures, multisig requires %d", len(signers), m.pubKey.Threshold)
+	}
+
+	err = m.builder.SetSignatures(signing.SignatureV2{
+		PubKey:   m.pubKey,
+		Data:     multisigData,
+		Sequence: m.sequence,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error setting signatures: %w", err)
+	}
+
+	txBytes, err := m.enc.TxEncoder()(m.builder.GetTx())
+	if err != nil {
+		return nil, err
+	}
+	return blob.MarshalBlobTx(txBytes, m.blobs...)
+}
+
+// signBytes returns the bytes signed by the members of the multisig.
+func (m *MultisigPayForBlob) signBytes() ([]byte, error) {
+	signerData := authsigning.SignerData{
+		Address:       m.Address().String(),
+		ChainID:       m.chainID,
+		AccountNumber: m.accountNumber,
+		Sequence:      m.sequence,
+		PubKey:        m.pubKey,
+	}
+	signBytes, err := m.enc.SignModeHandler().GetSignBytes(multisigSignMode, signerData, m.builder.GetTx())
+	if err != nil {
+		return nil, fmt.Errorf("error getting sign bytes: %w", err)
+	}
+	return signBytes, nil
+}
+
+// isMember returns true if the public key is one of the keys of the multisig.
+func (m *MultisigPayForBlob) isMember(pk cryptotypes.PubKey) bool {
+	for _, memberPK := range m.pubKey.GetPubKeys() {
+		if memberPK.Equals(pk) {
+			return true
+		}
+	}
+	return false
+}
+
+// multisigPayForBlobJSON is the JSON encoding of a MultisigPayForBlob.
+type multisigPayForBlobJSON struct {
+	// PubKey is the amino JSON encoding of the multisig public key.
+	PubKey        json.RawMessage `json:"pub_key"`
+	ChainID       string          `json:"chain_id"`
+	AccountNumber uint64          `json:"account_number,string"`
+	Sequence      uint64          `json:"sequence,string"`
+	Tx            json.RawMessage `json:"tx"`
+	Blobs         []*blob.Blob    `json:"blobs"`
+}
+
+// MarshalMultisigPayForBlob returns the JSON encoding of the unsigned
+// transaction, which can be passed to the members of the multisig.
+func MarshalMultisigPayForBlob(m *MultisigPayForBlob) ([]byte, error) {
+	pubKey, err := legacy.Cdc.MarshalJSON(m.pubKey)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := m.enc.TxJSONEncoder()(m.builder.GetTx())
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(multisigPayForBlobJSON{
+		PubKey:        pubKey,
+		ChainID:       m.chainID,
+		AccountNumber: m.accountNumber,
+		Sequence:      m.sequence,
+		Tx:            tx,
+		Blobs:         m.blobs,
+	}, "", "  ")
+}
+
+// UnmarshalMultisigPayForBlob decodes a transaction encoded with
+// MarshalMultisigPayForBlob.
+func UnmarshalMultisigPayForBlob(enc client.TxConfig, bz []byte) (*MultisigPayForBlob, error) {
+	var raw multisigPayForBlobJSON
+	if err := json.Unmarshal(bz, &raw); err != nil {
+		return nil, err
+	}
+
+	var pubKey cryptotypes.PubKey
+	if err := legacy.Cdc.UnmarshalJSON(raw.PubKey, &pubKey); err != nil {
+		return nil, fmt.Errorf("error decoding multisig public key: %w", err)
+	}
+	multisigPubKey, ok := pubKey.(*multisig.LegacyAminoPubKey)
+	if !ok {
+		return nil, fmt.Errorf("expected a multisig public key, got %T", pubKey)
+	}
+
+	tx, err := enc.TxJSONDecoder()(raw.Tx)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding tx: %w", err)
+	}
+	builder, err := enc.WrapTxBuilder(tx)
+	if err != nil {
+		return nil, err
+	}
+	if len(raw.Blobs) == 0 {
+		return nil, errors.New("multisig PayForBlobs contains no blobs")
+	}
+
+	return &MultisigPayForBlob{
+		enc:           enc,
+		builder:       builder,
+		pubKey:        multisigPubKey,
+		chainID:       raw.ChainID,
+		accountNumber: raw.AccountNumber,
+		sequence:      raw.Sequence,
+		blobs:         raw.Blobs,
+	}, nil
+}
@@ -0,0 +1,136 @@
+package user
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/celestiaorg/go-square/blob"
+	"github.com/cosmos/cosmos-sdk/client"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ErrOfflineSigner is returned when a signer without a gRPC connection is used
+// to query or submit to the chain.
+var ErrOfflineSigner = errors.New("signer is offline")
+
+// NewOfflineSigner returns a signer that signs transactions without a
+// connection to a node, e.g. on an air-gapped machine. The chain ID, account
+// number and sequence of the account must be provided. The signer can create
+// transactions with CreateTx and CreatePayForBlob but returns ErrOfflineSigner
+// from all methods that query or submit to the chain. Since gas can't be
+// estimated offline, the gas limit and fee must be set using TxOptions.
+func NewOfflineSigner(
+	backend SigningBackend,
+	address sdktypes.AccAddress,
+	enc client.TxConfig,
+	chainID string,
+	accountNumber, sequence,
+	appVersion uint64,
+) (*Signer, error) {
+	return NewSignerWithBackend(backend, nil, address, enc, chainID, accountNumber, sequence, appVersion)
+}
+
+// IsOffline returns true if the signer has no connection to a node.
+func (s *Signer) IsOffline() bool {
+	return s.grpc == nil
+}
+
+// checkOnline returns ErrOfflineSigner if the signer has no connection to a
+// node.
+func (s *Signer) checkOnline() error {
+	if s.IsOffline() {
+		return ErrOfflineSigner
+	}
+	return nil
+}
+
+// BlobTxFormat is the encoding of a BlobTx written to a file.
+type BlobTxFormat int
+
+const (
+	// BlobTxFormatBinary is the protobuf encoding of the BlobTx as it is
+	// broadcast to the chain.
+	BlobTxFormatBinary BlobTxFormat = iota
+	// BlobTxFormatJSON is a human readable encoding that contains the JSON
+	// encoding of the signed tx and its blobs.
+	BlobTxFormatJSON
+)
+
+// blobTxJSON is the JSON encoding of a BlobTx.
+type blobTxJSON struct {
+	Tx    json.RawMessage `json:"tx"`
+	Blobs []*blob.Blob    `json:"blobs"`
+}
+
+// WriteBlobTxFile writes the BlobTx to the file at path in the provided
+// format.
+func WriteBlobTxFile(enc client.TxConfig, path string, blobTx []byte, format BlobTxFormat) error {
+	var bz []byte
+	switch format {
+	case BlobTxFormatBinary:
+		bz = blobTx
+	case BlobTxFormatJSON:
+		var err error
+		bz, err = MarshalBlobTxJSON(enc, blobTx)
+		if err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown BlobTx format %d", format)
+	}
+	return os.WriteFile(path, bz, 0o600)
+}
+
+// ReadBlobTxFile reads a BlobTx written by WriteBlobTxFile in either format
+// and returns it in the binary format.
+func ReadBlobTxFile(enc client.TxConfig, path string) ([]byte, error) {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if trimmed := bytes.TrimSpace(bz); len(trimmed) > 0 && trimmed[0] == '{' {
+		return UnmarshalBlobTxJSON(enc, trimmed)
+	}
+	if _, isBlobTx := blob.UnmarshalBlobTx(bz); !isBlobTx {
+		return nil, fmt.Errorf("%s does not contain a BlobTx", path)
+	}
+	return bz, nil
+}
+
+// MarshalBlobTxJSON returns the JSON encoding of a BlobTx.
+func MarshalBlobTxJSON(enc client.TxConfig, blobTx []byte) ([]byte, error) {
+	btx, isBlobTx := blob.UnmarshalBlobTx(blobTx)
+	if !isBlobTx {
+		return nil, errors.New("not a BlobTx")
+	}
+	sdkTx, err := enc.TxDecoder()(btx.Tx)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := enc.TxJSONEncoder()(sdkTx)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(blobTxJSON{Tx: tx, Blobs: btx.Blobs}, "", "  ")
+}
+
+// UnmarshalBlobTxJSON decodes a BlobTx encoded with MarshalBlobTxJSON and
+// returns it in the binary format.
+func UnmarshalBlobTxJSON(enc client.TxConfig, bz []byte) ([]byte, error) {
+	var raw blobTxJSON
+	if err := json.Unmarshal(bz, &raw); err != nil {
+		return nil, err
+	}
+	sdkTx, err := enc.TxJSONDecoder()(raw.Tx)
+	if err != nil {
+		return nil, err
+	}
+	txBytes, err := enc.TxEncoder()(sdkTx)
+	if err != nil {
+		return nil, err
+	}
+	return blob.MarshalBlobTx(txBytes, raw.Blobs...)
+}
@@ -0,0 +1,56 @@
+package user_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/celestiaorg/celestia-app/app"
+	"github.com/celestiaorg/celestia-app/app/encoding"
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	"github.com/celestiaorg/celestia-app/pkg/user"
+	"github.com/celestiaorg/celestia-app/test/util/blobfactory"
+	"github.com/celestiaorg/celestia-app/test/util/testfactory"
+	"github.com/celestiaorg/go-square/blob"
+	"github.com/stretchr/testify/require"
+	tmrand "github.com/tendermint/tendermint/libs/rand"
+)
+
+func TestOfflineSigner(t *testing.T) {
+	encCfg := encoding.MakeConfig(app.ModuleEncodingRegisters...)
+	kr := testfactory.TestKeyring(encCfg.Codec, "signer")
+	address := testfactory.GetAddress(kr, "signer")
+
+	signer, err := user.NewOfflineSigner(user.NewKeyringBackend(kr), address, encCfg.TxConfig, "chain", 1, 5, appconsts.LatestVersion)
+	require.NoError(t, err)
+	require.True(t, signer.IsOffline())
+
+	blobs := blobfactory.ManyRandBlobs(tmrand.NewRand(), 100, 200)
+	blobTx, err := signer.CreatePayForBlob(blobs, user.SetGasLimit(200_000), user.SetFee(2000))
+	require.NoError(t, err)
+	_, isBlobTx := blob.UnmarshalBlobTx(blobTx)
+	require.True(t, isBlobTx)
+
+	dir := t.TempDir()
+	for name, format := range map[string]user.BlobTxFormat{
+		"blobtx.json": user.BlobTxFormatJSON,
+		"blobtx.bin":  user.BlobTxFormatBinary,
+	} {
+		path := filepath.Join(dir, name)
+		require.NoError(t, user.WriteBlobTxFile(encCfg.TxConfig, path, blobTx, format))
+		got, err := user.ReadBlobTxFile(encCfg.TxConfig, path)
+		require.NoError(t, err)
+		require.Equal(t, blobTx, got)
+	}
+
+	// everything that requires a connection to a node fails
+	ctx := context.Background()
+	_, err = signer.SubmitPayForBlob(ctx, blobs, user.SetGasLimit(200_000), user.SetFee(2000))
+	require.ErrorIs(t, err, user.ErrOfflineSigner)
+	_, err = signer.BroadcastTx(ctx, blobTx)
+	require.ErrorIs(t, err, user.ErrOfflineSigner)
+	_, err = signer.EstimatePayForBlobGas(ctx, blobs)
+	require.ErrorIs(t, err, user.ErrOfflineSigner)
+	_, err = signer.MinGasPrice(ctx)
+	require.ErrorIs(t, err, user.ErrOfflineSigner)
+}
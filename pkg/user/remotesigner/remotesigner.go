@@ -0,0 +1,143 @@
+// Package remotesigner implements a user.SigningBackend that delegates signing
+// to a remote signing service over gRPC, so that the keys of an account don't
+// need to be stored on the host that submits its transactions. The protocol is
+// defined in proto/celestia/signer/v1/signer.proto.
+package remotesigner
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/celestiaorg/celestia-app/pkg/user"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultTimeout is the default time the Backend waits for a response of the
+// remote signer.
+const DefaultTimeout = 10 * time.Second
+
+// secp256k1KeyType is the type of the secp256k1 keys used by Celestia accounts.
+var secp256k1KeyType = (&secp256k1.PubKey{}).Type()
+
+// Backend is a user.SigningBackend that signs with the keys held by a remote
+// signer.
+type Backend struct {
+	client  SignerClient
+	timeout time.Duration
+	// pubKeys caches the public keys returned by the remote signer by the
+	// address of their account. They are used to verify the signatures.
+	pubKeys sync.Map
+}
+
+var _ user.SigningBackend = &Backend{}
+
+// NewBackend returns a Backend that signs using the remote signer served over
+// the provided connection.
+func NewBackend(conn grpc.ClientConnInterface) *Backend {
+	return &Backend{
+		client:  NewSignerClient(conn),
+		timeout: DefaultTimeout,
+	}
+}
+
+// SetTimeout sets the time the backend waits for a response of the remote
+// signer.
+func (b *Backend) SetTimeout(timeout time.Duration) {
+	b.timeout = timeout
+}
+
+// PubKey implements user.SigningBackend.
+func (b *Backend) PubKey(address sdktypes.AccAddress) (cryptotypes.PubKey, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel()
+
+	resp, err := b.client.PubKey(ctx, &PubKeyRequest{Address: address.String()})
+	if err != nil {
+		return nil, fmt.Errorf("error querying public key of %s from remote signer: %w", address, err)
+	}
+	if resp.Type != secp256k1KeyType {
+		return nil, fmt.Errorf("unsupported key type %q", resp.Type)
+	}
+	if len(resp.Key) != secp256k1.PubKeySize {
+		return nil, fmt.Errorf("invalid public key length %d", len(resp.Key))
+	}
+	pk := &secp256k1.PubKey{Key: resp.Key}
+	if !sdktypes.AccAddress(pk.Address()).Equals(address) {
+		return nil, fmt.Errorf("remote signer returned the public key of %s for %s", sdktypes.AccAddress(pk.Address()), address)
+	}
+	b.pubKeys.Store(address.String(), pk)
+	return pk, nil
+}
+
+// Sign implements user.SigningBackend. The signature is verified against the
+// public key of the account, which is queried from the remote signer the first
+// time, so that a faulty signer can't produce transactions that fail on chain.
+func (b *Backend) Sign(address sdktypes.AccAddress, signBytes []byte) ([]byte, error) {
+	var pk cryptotypes.PubKey
+	if cached, ok := b.pubKeys.Load(address.String()); ok {
+		pk = cached.(cryptotypes.PubKey)
+	} else {
+		var err error
+		if pk, err = b.PubKey(address); err != nil {
+			return nil, err
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel()
+
+	resp, err := b.client.Sign(ctx, &SignRequest{Address: address.String(), SignBytes: signBytes})
+	if err != nil {
+		return nil, fmt.Errorf("error signing with remote signer: %w", err)
+	}
+	if !pk.VerifySignature(signBytes, resp.Signature) {
+		return nil, fmt.Errorf("remote signer returned a signature for %s that doesn't match its public key", address)
+	}
+	return resp.Signature, nil
+}
+
+// Server serves the keys of a user.SigningBackend, e.g. a keyring, as a remote
+// signer.
+type Server struct {
+	backend user.SigningBackend
+}
+
+var _ SignerServer = &Server{}
+
+// NewServer returns a Server that signs with the provided backend.
+func NewServer(backend user.SigningBackend) *Server {
+	return &Server{backend: backend}
+}
+
+// PubKey implements SignerServer.
+func (s *Server) PubKey(_ context.Context, req *PubKeyRequest) (*PubKeyResponse, error) {
+	address, err := sdktypes.AccAddressFromBech32(req.Address)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	pk, err := s.backend.PubKey(address)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	return &PubKeyResponse{Type: pk.Type(), Key: pk.Bytes()}, nil
+}
+
+// Sign implements SignerServer.
+func (s *Server) Sign(_ context.Context, req *SignRequest) (*SignResponse, error) {
+	address, err := sdktypes.AccAddressFromBech32(req.Address)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	signature, err := s.backend.Sign(address, req.SignBytes)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &SignResponse{Signature: signature}, nil
+}
@@ -0,0 +1,88 @@
+package remotesigner_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/celestiaorg/celestia-app/app"
+	"github.com/celestiaorg/celestia-app/app/encoding"
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	"github.com/celestiaorg/celestia-app/pkg/user"
+	"github.com/celestiaorg/celestia-app/pkg/user/remotesigner"
+	"github.com/celestiaorg/celestia-app/test/util/testfactory"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	bank "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestRemoteSigner(t *testing.T) {
+	encCfg := encoding.MakeConfig(app.ModuleEncodingRegisters...)
+	kr := testfactory.TestKeyring(encCfg.Codec, "signer")
+	address := testfactory.GetAddress(kr, "signer")
+
+	// serve the keyring as a remote signer in process
+	backend := remotesigner.NewBackend(serve(t, remotesigner.NewServer(user.NewKeyringBackend(kr))))
+	remoteSigner, err := user.NewSignerWithBackend(backend, nil, address, encCfg.TxConfig, "chain", 1, 0, appconsts.LatestVersion)
+	require.NoError(t, err)
+	require.Nil(t, remoteSigner.Keyring())
+	localSigner, err := user.NewSigner(kr, nil, address, encCfg.TxConfig, "chain", 1, 0, appconsts.LatestVersion)
+	require.NoError(t, err)
+	require.True(t, localSigner.PubKey().Equals(remoteSigner.PubKey()))
+
+	// secp256k1 signatures are deterministic so the remote and the local
+	// signer produce the same tx
+	msg := bank.NewMsgSend(address, address, sdk.NewCoins(sdk.NewInt64Coin(appconsts.BondDenom, 10)))
+	remoteTx, err := remoteSigner.CreateTx([]sdk.Msg{msg}, user.SetGasLimit(100_000), user.SetFee(1000))
+	require.NoError(t, err)
+	localTx, err := localSigner.CreateTx([]sdk.Msg{msg}, user.SetGasLimit(100_000), user.SetFee(1000))
+	require.NoError(t, err)
+	require.Equal(t, localTx, remoteTx)
+
+	// the remote signer only signs for the keys it holds
+	unknown := sdk.AccAddress(make([]byte, 20))
+	_, err = backend.PubKey(unknown)
+	require.Error(t, err)
+	_, err = user.NewSignerWithBackend(backend, nil, unknown, encCfg.TxConfig, "chain", 1, 0, appconsts.LatestVersion)
+	require.Error(t, err)
+}
+
+// tamperingServer returns signatures that don't match the signed bytes.
+type tamperingServer struct {
+	*remotesigner.Server
+}
+
+func (s tamperingServer) Sign(ctx context.Context, req *remotesigner.SignRequest) (*remotesigner.SignResponse, error) {
+	req.SignBytes = append(req.SignBytes, 0)
+	return s.Server.Sign(ctx, req)
+}
+
+func TestRemoteSignerVerifiesSignature(t *testing.T) {
+	encCfg := encoding.MakeConfig(app.ModuleEncodingRegisters...)
+	kr := testfactory.TestKeyring(encCfg.Codec, "signer")
+	address := testfactory.GetAddress(kr, "signer")
+
+	backend := remotesigner.NewBackend(serve(t, tamperingServer{remotesigner.NewServer(user.NewKeyringBackend(kr))}))
+	_, err := backend.Sign(address, []byte("sign bytes"))
+	require.ErrorContains(t, err, "doesn't match its public key")
+}
+
+// serve serves the remote signer in process and returns a connection to it.
+func serve(t *testing.T, signer remotesigner.SignerServer) *grpc.ClientConn {
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	remotesigner.RegisterSignerServer(server, signer)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return listener.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
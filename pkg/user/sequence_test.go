@@ -0,0 +1,178 @@
+package user_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/celestiaorg/celestia-app/app"
+	"github.com/celestiaorg/celestia-app/app/encoding"
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	"github.com/celestiaorg/celestia-app/pkg/user"
+	"github.com/celestiaorg/celestia-app/test/util/testfactory"
+	blobtypes "github.com/celestiaorg/celestia-app/x/blob/types"
+	"github.com/cosmos/cosmos-sdk/client/grpc/tmservice"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/tx"
+	bank "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// mockTxService responds to broadcasts with the response returned by
+// broadcast. Txs are looked up with getTx which, if nil, finds no tx.
+type mockTxService struct {
+	tx.UnimplementedServiceServer
+	broadcast func(txBytes []byte) *sdk.TxResponse
+	getTx     func(hash string) *sdk.TxResponse
+}
+
+func (m *mockTxService) BroadcastTx(_ context.Context, req *tx.BroadcastTxRequest) (*tx.BroadcastTxResponse, error) {
+	return &tx.BroadcastTxResponse{TxResponse: m.broadcast(req.TxBytes)}, nil
+}
+
+func (m *mockTxService) GetTx(_ context.Context, req *tx.GetTxRequest) (*tx.GetTxResponse, error) {
+	if m.getTx != nil {
+		if resp := m.getTx(req.Hash); resp != nil {
+			return &tx.GetTxResponse{TxResponse: resp}, nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "tx not found: %s", req.Hash)
+}
+
+// mockBlobQueryServer returns the default blob params.
+type mockBlobQueryServer struct {
+	blobtypes.UnimplementedQueryServer
+}
+
+func (mockBlobQueryServer) Params(context.Context, *blobtypes.QueryParamsRequest) (*blobtypes.QueryParamsResponse, error) {
+	return &blobtypes.QueryParamsResponse{Params: blobtypes.DefaultParams()}, nil
+}
+
+// mockTmService returns a new latest block on every request.
+type mockTmService struct {
+	tmservice.UnimplementedServiceServer
+	height atomic.Int64
+}
+
+func (m *mockTmService) GetLatestBlock(context.Context, *tmservice.GetLatestBlockRequest) (*tmservice.GetLatestBlockResponse, error) {
+	return &tmservice.GetLatestBlockResponse{
+		SdkBlock: &tmservice.Block{Header: tmservice.Header{Height: m.height.Add(1)}},
+	}, nil
+}
+
+func newMockTxServiceConn(t *testing.T, encCfg encoding.Config, service *mockTxService) *grpc.ClientConn {
+	grpcCodec := codec.NewProtoCodec(encCfg.InterfaceRegistry).GRPCCodec()
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer(grpc.ForceServerCodec(grpcCodec))
+	tx.RegisterServiceServer(server, service)
+	tmservice.RegisterServiceServer(server, &mockTmService{})
+	blobtypes.RegisterQueryServer(server, mockBlobQueryServer{})
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return listener.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.ForceCodec(grpcCodec)),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func sequenceMismatch(expected, got uint64) *sdk.TxResponse {
+	return &sdk.TxResponse{
+		Codespace: sdkerrors.ErrWrongSequence.Codespace(),
+		Code:      sdkerrors.ErrWrongSequence.ABCICode(),
+		RawLog:    fmt.Sprintf("account sequence mismatch, expected %d, got %d: incorrect account sequence", expected, got),
+	}
+}
+
+func TestBroadcastTxSequenceRollback(t *testing.T) {
+	encCfg := encoding.MakeConfig(app.ModuleEncodingRegisters...)
+	kr := testfactory.TestKeyring(encCfg.Codec, "signer")
+	address := testfactory.GetAddress(kr, "signer")
+	msg := bank.NewMsgSend(address, address, sdk.NewCoins(sdk.NewInt64Coin(appconsts.BondDenom, 10)))
+
+	t.Run("rolls back the sequence of evicted txs", func(t *testing.T) {
+		service := &mockTxService{broadcast: func([]byte) *sdk.TxResponse { return &sdk.TxResponse{} }}
+		conn := newMockTxServiceConn(t, encCfg, service)
+		signer, err := user.NewSigner(kr, conn, address, encCfg.TxConfig, "chain", 1, 0, appconsts.LatestVersion)
+		require.NoError(t, err)
+
+		// the txs with sequence zero and one are accepted into the mempool
+		for i := 0; i < 2; i++ {
+			txBytes, err := signer.CreateTx([]sdk.Msg{msg})
+			require.NoError(t, err)
+			_, err = signer.BroadcastTx(context.Background(), txBytes)
+			require.NoError(t, err)
+		}
+
+		// the tx with sequence one is evicted so the node expects it again
+		service.broadcast = func([]byte) *sdk.TxResponse { return sequenceMismatch(1, 2) }
+		txBytes, err := signer.CreateTx([]sdk.Msg{msg})
+		require.NoError(t, err)
+		_, err = signer.BroadcastTx(context.Background(), txBytes)
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), signer.Sequence())
+	})
+
+	t.Run("does not roll back while other txs are in flight", func(t *testing.T) {
+		inFlight := make(chan struct{})
+		release := make(chan struct{})
+		service := &mockTxService{broadcast: func([]byte) *sdk.TxResponse {
+			close(inFlight)
+			<-release
+			return &sdk.TxResponse{}
+		}}
+		conn := newMockTxServiceConn(t, encCfg, service)
+		signer, err := user.NewSigner(kr, conn, address, encCfg.TxConfig, "chain", 1, 0, appconsts.LatestVersion)
+		require.NoError(t, err)
+
+		// the tx with sequence zero is being broadcast
+		first, err := signer.CreateTx([]sdk.Msg{msg})
+		require.NoError(t, err)
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := signer.BroadcastTx(context.Background(), first)
+			require.NoError(t, err)
+		}()
+		<-inFlight
+
+		// the tx with sequence one reaches the node first
+		service.broadcast = func([]byte) *sdk.TxResponse { return sequenceMismatch(0, 1) }
+		second, err := signer.CreateTx([]sdk.Msg{msg})
+		require.NoError(t, err)
+		_, err = signer.BroadcastTx(context.Background(), second)
+		require.NoError(t, err)
+		require.Equal(t, uint64(2), signer.Sequence())
+
+		close(release)
+		wg.Wait()
+	})
+
+	t.Run("moves the sequence forward", func(t *testing.T) {
+		service := &mockTxService{broadcast: func([]byte) *sdk.TxResponse { return sequenceMismatch(5, 0) }}
+		conn := newMockTxServiceConn(t, encCfg, service)
+		signer, err := user.NewSigner(kr, conn, address, encCfg.TxConfig, "chain", 1, 0, appconsts.LatestVersion)
+		require.NoError(t, err)
+
+		txBytes, err := signer.CreateTx([]sdk.Msg{msg})
+		require.NoError(t, err)
+		_, err = signer.BroadcastTx(context.Background(), txBytes)
+		require.NoError(t, err)
+		require.Equal(t, uint64(5), signer.Sequence())
+	})
+}
@@ -9,25 +9,40 @@ import (
 	"time"
 
 	"github.com/celestiaorg/celestia-app/app/encoding"
+	apperrors "github.com/celestiaorg/celestia-app/app/errors"
 	blobtypes "github.com/celestiaorg/celestia-app/x/blob/types"
 	"github.com/celestiaorg/go-square/blob"
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/grpc/tmservice"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
 	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
 	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/cosmos/cosmos-sdk/types/tx"
 	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	"github.com/cosmos/cosmos-sdk/x/auth"
 	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/cosmos/cosmos-sdk/x/auth/vesting"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
 	"google.golang.org/grpc"
 )
 
-const DefaultPollTime = 3 * time.Second
+const (
+	DefaultPollTime = 3 * time.Second
+	// DefaultMaxSequenceRetries is the default number of times a tx is
+	// re-signed and resubmitted after being rejected for having the wrong
+	// sequence.
+	DefaultMaxSequenceRetries = 3
+)
 
 // Signer is an abstraction for building, signing, and broadcasting Celestia transactions
 type Signer struct {
+	// keys is the keyring of the signer. It is nil if the signer uses a
+	// SigningBackend other than a keyring.
 	keys          keyring.Keyring
+	backend       SigningBackend
 	address       sdktypes.AccAddress
 	enc           client.TxConfig
 	grpc          *grpc.ClientConn
@@ -36,12 +51,44 @@ type Signer struct {
 	accountNumber uint64
 	appVersion    uint64
 	pollTime      time.Duration
+	// maxSequenceRetries is the number of times SubmitTx and
+	// SubmitPayForBlob retry after a sequence mismatch
+	maxSequenceRetries int
+	// gasMultiplier is the factor that estimated gas is multiplied by
+	gasMultiplier float64
+	// eventClient is used to subscribe to tx events when confirming txs. If
+	// it is nil, txs are confirmed by polling.
+	eventClient rpcclient.EventsClient
+	// eventSub is the subscription of the event client to the events of the
+	// signer's txs. It is shared by all ConfirmTxs calls and guarded by
+	// eventMtx.
+	eventSub *txEventSubscription
+	eventMtx sync.Mutex
+	// journal records the txs that have been broadcast but not confirmed. It
+	// is nil if journaling is disabled.
+	journal Journal
+	// proofClient is used to prove the inclusion of blobs. It is nil if no
+	// proofs are requested.
+	proofClient ProofClient
+	// registry is used to decode the account of the signer when querying its
+	// sequence.
+	registry codectypes.InterfaceRegistry
 
 	mtx                   sync.RWMutex
 	lastSignedSequence    uint64
 	lastConfirmedSequence uint64
+	// broadcasting is the number of BroadcastTx calls waiting for a response
+	// from the node.
+	broadcasting int
+	// accepted maps the sequence of each tx of the signer that was accepted
+	// into the mempool and has not been confirmed yet to the hash of the tx.
+	accepted map[uint64]string
 }
 
+// accountRegistry is used to decode the accounts of signers that are not set
+// up with an encoding config.
+var accountRegistry = encoding.MakeConfig(auth.AppModuleBasic{}, vesting.AppModuleBasic{}).InterfaceRegistry
+
 // NewSigner returns a new signer using the provided keyring
 func NewSigner(
 	keys keyring.Keyring,
@@ -52,19 +99,34 @@ func NewSigner(
 	accountNumber, sequence,
 	appVersion uint64,
 ) (*Signer, error) {
-	// check that the address exists
-	record, err := keys.KeyByAddress(address)
+	return NewSignerWithBackend(NewKeyringBackend(keys), conn, address, enc, chainID, accountNumber, sequence, appVersion)
+}
+
+// NewSignerWithBackend returns a new signer that signs transactions using the
+// provided SigningBackend.
+func NewSignerWithBackend(
+	backend SigningBackend,
+	conn *grpc.ClientConn,
+	address sdktypes.AccAddress,
+	enc client.TxConfig,
+	chainID string,
+	accountNumber, sequence,
+	appVersion uint64,
+) (*Signer, error) {
+	// check that the backend holds the key of the address
+	pk, err := backend.PubKey(address)
 	if err != nil {
 		return nil, err
 	}
 
-	pk, err := record.GetPubKey()
-	if err != nil {
-		return nil, err
+	var keys keyring.Keyring
+	if kb, ok := backend.(*KeyringBackend); ok {
+		keys = kb.Keyring()
 	}
 
 	return &Signer{
 		keys:                  keys,
+		backend:               backend,
 		address:               address,
 		grpc:                  conn,
 		enc:                   enc,
@@ -75,6 +137,10 @@ func NewSigner(
 		lastSignedSequence:    sequence,
 		lastConfirmedSequence: sequence,
 		pollTime:              DefaultPollTime,
+		maxSequenceRetries:    DefaultMaxSequenceRetries,
+		gasMultiplier:         DefaultGasMultiplier,
+		registry:              accountRegistry,
+		accepted:              make(map[uint64]string),
 	}, nil
 }
 
@@ -108,6 +174,18 @@ func SetupSigner(
 	conn *grpc.ClientConn,
 	address sdktypes.AccAddress,
 	encCfg encoding.Config,
+) (*Signer, error) {
+	return SetupSignerWithBackend(ctx, NewKeyringBackend(keys), conn, address, encCfg)
+}
+
+// SetupSignerWithBackend is the same as SetupSigner but signs transactions
+// using the provided SigningBackend.
+func SetupSignerWithBackend(
+	ctx context.Context,
+	backend SigningBackend,
+	conn *grpc.ClientConn,
+	address sdktypes.AccAddress,
+	encCfg encoding.Config,
 ) (*Signer, error) {
 	resp, err := tmservice.NewServiceClient(conn).GetLatestBlock(ctx, &tmservice.GetLatestBlockRequest{})
 	if err != nil {
@@ -121,47 +199,87 @@ func SetupSigner(
 		return nil, err
 	}
 
-	return NewSigner(keys, conn, address, encCfg.TxConfig, chainID, accNum, seqNum, appVersion)
+	signer, err := NewSignerWithBackend(backend, conn, address, encCfg.TxConfig, chainID, accNum, seqNum, appVersion)
+	if err != nil {
+		return nil, err
+	}
+	signer.registry = encCfg.InterfaceRegistry
+	return signer, nil
 }
 
 // SubmitTx forms a transaction from the provided messages, signs it, and submits it to the chain. TxOptions
-// may be provided to set the fee and gas limit.
+// may be provided to set the fee and gas limit. If no gas limit is set, the gas is estimated by simulating
+// the transaction (see EstimateGas) and, if no fee is set either, the fee is derived from the node's minimum
+// gas price. If the transaction is rejected due to a sequence mismatch, it is re-signed with the expected
+// sequence and resubmitted up to a bounded number of times.
 func (s *Signer) SubmitTx(ctx context.Context, msgs []sdktypes.Msg, opts ...TxOption) (*sdktypes.TxResponse, error) {
-	txBytes, err := s.CreateTx(msgs, opts...)
+	opts, err := s.withEstimatedGas(ctx, opts, func() (uint64, error) {
+		return s.EstimateGas(ctx, msgs, opts...)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := s.BroadcastTx(ctx, txBytes)
+	resp, err := s.broadcastWithRetry(ctx, func() ([]byte, error) {
+		return s.CreateTx(msgs, opts...)
+	})
 	if err != nil {
-		return nil, err
-	}
-	if resp.Code != 0 {
-		return resp, fmt.Errorf("tx failed with code %d: %s", resp.Code, resp.RawLog)
+		return resp, err
 	}
 
 	return s.ConfirmTx(ctx, resp.TxHash)
 }
 
 // SubmitPayForBlob forms a transaction from the provided blobs, signs it, and submits it to the chain.
-// TxOptions may be provided to set the fee and gas limit.
+// TxOptions may be provided to set the fee and gas limit. If no gas limit is set, the gas is estimated
+// from the size of the blobs (see EstimatePayForBlobGas). Missing fees and sequence mismatches are handled
+// the same way as in SubmitTx.
 func (s *Signer) SubmitPayForBlob(ctx context.Context, blobs []*blob.Blob, opts ...TxOption) (*sdktypes.TxResponse, error) {
-	txBytes, err := s.CreatePayForBlob(blobs, opts...)
+	opts, err := s.withEstimatedGas(ctx, opts, func() (uint64, error) {
+		return s.EstimatePayForBlobGas(ctx, blobs)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := s.BroadcastTx(ctx, txBytes)
+	resp, err := s.broadcastWithRetry(ctx, func() ([]byte, error) {
+		return s.CreatePayForBlob(blobs, opts...)
+	})
 	if err != nil {
-		return nil, err
-	}
-	if resp.Code != 0 {
-		return resp, fmt.Errorf("tx failed with code %d: %s", resp.Code, resp.RawLog)
+		return resp, err
 	}
 
 	return s.ConfirmTx(ctx, resp.TxHash)
 }
 
+// broadcastWithRetry creates and broadcasts a transaction. If the transaction
+// is rejected because of a sequence mismatch, BroadcastTx will have corrected
+// the signer's sequence so the transaction is created again, which re-signs it
+// with the new sequence, and resubmitted.
+func (s *Signer) broadcastWithRetry(ctx context.Context, createTx func() ([]byte, error)) (*sdktypes.TxResponse, error) {
+	s.mtx.RLock()
+	maxRetries := s.maxSequenceRetries
+	s.mtx.RUnlock()
+
+	for attempt := 0; ; attempt++ {
+		txBytes, err := createTx()
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := s.BroadcastTx(ctx, txBytes)
+		if err != nil {
+			return nil, err
+		}
+		if resp.Code == 0 {
+			return resp, nil
+		}
+		if !isSequenceMismatch(resp) || attempt >= maxRetries {
+			return resp, fmt.Errorf("tx failed with code %d: %s", resp.Code, resp.RawLog)
+		}
+	}
+}
+
 // CreateTx forms a transaction from the provided messages and signs it. TxOptions may be optionally
 // used to set the gas limit and fee.
 func (s *Signer) CreateTx(msgs []sdktypes.Msg, opts ...TxOption) ([]byte, error) {
@@ -192,10 +310,41 @@ func (s *Signer) CreatePayForBlob(blobs []*blob.Blob, opts ...TxOption) ([]byte,
 }
 
 // BroadcastTx submits the provided transaction bytes to the chain and returns the response.
+// If the transaction is rejected because of a sequence mismatch, the signer's sequence is
+// corrected to the sequence expected by the node (see recoverSequence). This also rolls back
+// the sequence if previously signed transactions were evicted from the mempool. The
+// transaction itself is not resubmitted. If a journal is set, the transaction is added to it
+// before it is broadcast.
 func (s *Signer) BroadcastTx(ctx context.Context, txBytes []byte) (*sdktypes.TxResponse, error) {
+	resp, err := s.broadcastTx(ctx, txBytes)
+	if err != nil {
+		return resp, err
+	}
+
+	if isSequenceMismatch(resp) {
+		if err := s.recoverSequence(ctx, resp); err != nil {
+			return resp, fmt.Errorf("recovering from sequence mismatch: %w", err)
+		}
+	}
+	return resp, nil
+}
+
+// broadcastTx submits the transaction to the node without correcting the
+// signer's sequence. Txs of the signer accepted into the mempool are tracked
+// until they are confirmed.
+func (s *Signer) broadcastTx(ctx context.Context, txBytes []byte) (*sdktypes.TxResponse, error) {
+	if err := s.checkOnline(); err != nil {
+		return nil, err
+	}
+	journaled, err := s.journalTx(txBytes)
+	if err != nil {
+		return nil, err
+	}
 	txClient := tx.NewServiceClient(s.grpc)
 
-	// TODO (@cmwaters): handle nonce mismatch errors
+	s.mtx.Lock()
+	s.broadcasting++
+	s.mtx.Unlock()
 	resp, err := txClient.BroadcastTx(
 		ctx,
 		&tx.BroadcastTxRequest{
@@ -203,46 +352,97 @@ func (s *Signer) BroadcastTx(ctx context.Context, txBytes []byte) (*sdktypes.TxR
 			TxBytes: txBytes,
 		},
 	)
+	s.mtx.Lock()
+	s.broadcasting--
+	if err == nil && resp.TxResponse.Code == 0 {
+		if sequence, ok := s.txSequence(txBytes); ok {
+			s.accepted[sequence] = strings.ToUpper(resp.TxResponse.TxHash)
+		}
+	}
+	s.mtx.Unlock()
 	if err != nil {
+		// the tx stays in the journal as it may have reached the node
 		return nil, err
 	}
+	if journaled != "" && resp.TxResponse.Code != 0 && !isTxInMempool(resp.TxResponse) {
+		s.forgetTxs(journaled)
+	}
 	return resp.TxResponse, nil
 }
 
-// ConfirmTx periodically pings the provided node for the commitment of a transaction by its
-// hash. It will continually loop until the context is cancelled, the tx is found or an error
-// is encountered.
-func (s *Signer) ConfirmTx(ctx context.Context, txHash string) (*sdktypes.TxResponse, error) {
-	txClient := tx.NewServiceClient(s.grpc)
+// recoverSequence corrects the signer's sequence to the one expected by the
+// node. The expected sequence is parsed from the error in the response and, if
+// that fails, is queried from the node.
+//
+// The sequence is always moved forward. It is only moved back if no other
+// broadcasts are in flight, as their txs would otherwise be signed with a
+// sequence that is reused. The node's sequence accounts for all txs of the
+// signer in its mempool, so the accepted txs with a sequence that is not below
+// the expected one have been evicted from the mempool and are forgotten.
+func (s *Signer) recoverSequence(ctx context.Context, resp *sdktypes.TxResponse) error {
+	expected, err := apperrors.ParseNonceMismatch(sdkerrors.ABCIError(resp.Codespace, resp.Code, resp.RawLog))
+	if err != nil {
+		_, expected, err = queryAccount(ctx, s.grpc, s.registry, s.address.String())
+		if err != nil {
+			return err
+		}
+	}
 
-	s.mtx.RLock()
-	pollTime := s.pollTime
-	s.mtx.RUnlock()
+	s.mtx.Lock()
+	if expected >= s.lastSignedSequence {
+		s.lastSignedSequence = expected
+		s.mtx.Unlock()
+		return nil
+	}
+	if s.broadcasting > 0 {
+		s.mtx.Unlock()
+		return nil
+	}
+	evicted := make([]string, 0)
+	for sequence, hash := range s.accepted {
+		if sequence >= expected {
+			evicted = append(evicted, hash)
+			delete(s.accepted, sequence)
+		}
+	}
+	s.lastSignedSequence = expected
+	s.mtx.Unlock()
 
-	pollTicker := time.NewTicker(pollTime)
-	defer pollTicker.Stop()
+	s.forgetTxs(evicted...)
+	return nil
+}
 
-	for {
-		resp, err := txClient.GetTx(ctx, &tx.GetTxRequest{Hash: txHash})
-		if err == nil {
-			if resp.TxResponse.Code != 0 {
-				return resp.TxResponse, fmt.Errorf("tx failed with code %d: %s", resp.TxResponse.Code, resp.TxResponse.RawLog)
+// forgetAccepted stops tracking the accepted txs with the provided hashes.
+func (s *Signer) forgetAccepted(txHashes ...string) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	for _, hash := range txHashes {
+		hash = strings.ToUpper(hash)
+		for sequence, acceptedHash := range s.accepted {
+			if acceptedHash == hash {
+				delete(s.accepted, sequence)
 			}
-			return resp.TxResponse, nil
-		}
-		if !strings.Contains(err.Error(), "not found") {
-			return &sdktypes.TxResponse{}, err
-		}
-
-		// Wait for the next round.
-		select {
-		case <-ctx.Done():
-			return &sdktypes.TxResponse{}, ctx.Err()
-		case <-pollTicker.C:
 		}
 	}
 }
 
+// isSequenceMismatch returns true if the tx was rejected because it was signed
+// with the wrong sequence.
+func isSequenceMismatch(resp *sdktypes.TxResponse) bool {
+	return resp != nil &&
+		resp.Codespace == sdkerrors.ErrWrongSequence.Codespace() &&
+		resp.Code == sdkerrors.ErrWrongSequence.ABCICode()
+}
+
+// ConfirmTx waits for the commitment of a transaction by its hash. It either
+// periodically pings the provided node or, if an event client is set, waits for
+// the event of the transaction (see ConfirmTxs). It will continue until the
+// context is cancelled, the tx is found or an error is encountered.
+func (s *Signer) ConfirmTx(ctx context.Context, txHash string) (*sdktypes.TxResponse, error) {
+	resps, err := s.ConfirmTxs(ctx, txHash)
+	return resps[0], err
+}
+
 // ChainID returns the chain ID of the signer.
 func (s *Signer) ChainID() string {
 	return s.chainID
@@ -265,6 +465,15 @@ func (s *Signer) SetPollTime(pollTime time.Duration) {
 	s.pollTime = pollTime
 }
 
+// SetMaxSequenceRetries sets how many times SubmitTx and SubmitPayForBlob
+// re-sign and resubmit a transaction that was rejected for having the wrong
+// sequence.
+func (s *Signer) SetMaxSequenceRetries(retries int) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.maxSequenceRetries = retries
+}
+
 // PubKey returns the public key of the signer
 func (s *Signer) PubKey() cryptotypes.PubKey {
 	return s.pk
@@ -303,12 +512,22 @@ func (s *Signer) ForceSetSequence(seq uint64) {
 	s.lastSignedSequence = seq
 }
 
-// Keyring exposes the signers underlying keyring
+// Keyring exposes the signers underlying keyring. It returns nil if the signer
+// uses a SigningBackend other than a keyring.
 func (s *Signer) Keyring() keyring.Keyring {
 	return s.keys
 }
 
 func (s *Signer) signTransaction(builder client.TxBuilder) error {
+	if err := s.checkSigners(builder); err != nil {
+		return err
+	}
+
+	return s.signTransactionWithSequence(builder, s.getAndIncrementSequence())
+}
+
+// checkSigners ensures that the signer is the only signer of the transaction.
+func (s *Signer) checkSigners(builder client.TxBuilder) error {
 	signers := builder.GetTx().GetSigners()
 	if len(signers) != 1 {
 		return fmt.Errorf("expected 1 signer, got %d", len(signers))
@@ -318,8 +537,12 @@ func (s *Signer) signTransaction(builder client.TxBuilder) error {
 		return fmt.Errorf("expected signer %s, got %s", s.address.String(), signers[0].String())
 	}
 
-	sequence := s.getAndIncrementSequence()
+	return nil
+}
 
+// signTransactionWithSequence signs the transaction using the provided sequence
+// without modifying the signer's sequence.
+func (s *Signer) signTransactionWithSequence(builder client.TxBuilder, sequence uint64) error {
 	// To ensure we have the correct bytes to sign over we produce
 	// a dry run of the signing data
 	draftsigV2 := signing.SignatureV2{
@@ -376,7 +599,7 @@ func (s *Signer) createSignature(builder client.TxBuilder, sequence uint64) ([]b
 		return nil, fmt.Errorf("error getting sign bytes: %w", err)
 	}
 
-	signature, _, err := s.keys.SignByAddress(s.address, bytesToSign)
+	signature, err := s.backend.Sign(s.address, bytesToSign)
 	if err != nil {
 		return nil, fmt.Errorf("error signing bytes: %w", err)
 	}
@@ -395,6 +618,10 @@ func (s *Signer) txBuilder(opts ...TxOption) client.TxBuilder {
 
 // QueryAccount fetches the account number and sequence number from the celestia-app node.
 func QueryAccount(ctx context.Context, conn *grpc.ClientConn, encCfg encoding.Config, address string) (accNum uint64, seqNum uint64, err error) {
+	return queryAccount(ctx, conn, encCfg.InterfaceRegistry, address)
+}
+
+func queryAccount(ctx context.Context, conn *grpc.ClientConn, registry codectypes.InterfaceRegistry, address string) (accNum uint64, seqNum uint64, err error) {
 	qclient := authtypes.NewQueryClient(conn)
 	resp, err := qclient.Account(
 		ctx,
@@ -405,7 +632,7 @@ func QueryAccount(ctx context.Context, conn *grpc.ClientConn, encCfg encoding.Co
 	}
 
 	var acc authtypes.AccountI
-	err = encCfg.InterfaceRegistry.UnpackAny(resp.Account, &acc)
+	err = registry.UnpackAny(resp.Account, &acc)
 	if err != nil {
 		return accNum, seqNum, err
 	}
@@ -0,0 +1,243 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/celestiaorg/celestia-app/app/encoding"
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	"github.com/celestiaorg/go-square/blob"
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	bank "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/cosmos/cosmos-sdk/x/feegrant"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// poolAccountFunding is the amount of utia sent to each sub-account of a
+	// SignerPool so that the account is created on chain. Fees are paid by
+	// the primary account through a fee grant.
+	poolAccountFunding = 1
+	// poolSendGasLimit and poolFeegrantGasLimit are the gas limits used for
+	// each message when setting up the sub-accounts of a SignerPool.
+	poolSendGasLimit     = 100_000
+	poolFeegrantGasLimit = 800_000
+)
+
+// SignerPool manages a set of sub-accounts, each with their own Signer, so that
+// transactions can be submitted concurrently without waiting on a single
+// account's sequence. The sub-accounts are created and funded by a primary
+// account which also pays for all of their fees through a fee grant.
+//
+// Each sub-account is only handed out to a single caller at a time and is
+// returned to the pool once its transaction has been confirmed. This means the
+// sequence and confirmations of each sub-account are tracked independently.
+type SignerPool struct {
+	primary *Signer
+	signers []*Signer
+	// available holds the sub-accounts that are not currently in use
+	available chan *Signer
+}
+
+// SetupSignerPool creates a SignerPool with n sub-accounts that are funded by
+// the account with the provided primary address. The sub-account keys are
+// derived in the keyring under the name "<primary name>-pool-<index>". Keys and
+// accounts that already exist are reused so that a pool can be set up again
+// after a restart.
+func SetupSignerPool(
+	ctx context.Context,
+	keys keyring.Keyring,
+	conn *grpc.ClientConn,
+	primaryAddress sdktypes.AccAddress,
+	encCfg encoding.Config,
+	n int,
+) (*SignerPool, error) {
+	if n < 1 {
+		return nil, errors.New("signer pool must have at least one sub-account")
+	}
+
+	primaryRecord, err := keys.KeyByAddress(primaryAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	primary, err := SetupSigner(ctx, keys, conn, primaryAddress, encCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	addresses := make([]sdktypes.AccAddress, n)
+	for i := 0; i < n; i++ {
+		addresses[i], err = poolAccountAddress(keys, fmt.Sprintf("%s-pool-%d", primaryRecord.Name, i))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := setupPoolAccounts(ctx, primary, conn, encCfg, addresses); err != nil {
+		return nil, fmt.Errorf("error setting up sub-accounts: %w", err)
+	}
+
+	pool := &SignerPool{
+		primary:   primary,
+		signers:   make([]*Signer, n),
+		available: make(chan *Signer, n),
+	}
+	for i, address := range addresses {
+		pool.signers[i], err = SetupSigner(ctx, keys, conn, address, encCfg)
+		if err != nil {
+			return nil, err
+		}
+		pool.available <- pool.signers[i]
+	}
+
+	return pool, nil
+}
+
+// poolAccountAddress returns the address of the key with the provided name,
+// creating the key if it does not exist.
+func poolAccountAddress(keys keyring.Keyring, name string) (sdktypes.AccAddress, error) {
+	record, err := keys.Key(name)
+	if err != nil {
+		path := hd.CreateHDPath(sdktypes.CoinType, 0, 0).String()
+		record, _, err = keys.NewMnemonic(name, keyring.English, path, keyring.DefaultBIP39Passphrase, hd.Secp256k1)
+		if err != nil {
+			return nil, fmt.Errorf("error creating key %s: %w", name, err)
+		}
+	}
+	return record.GetAddress()
+}
+
+// setupPoolAccounts funds the sub-accounts that don't yet exist on chain and
+// grants a fee allowance from the primary account to the sub-accounts that
+// don't yet have one. All messages are submitted in a single tx.
+func setupPoolAccounts(ctx context.Context, primary *Signer, conn *grpc.ClientConn, encCfg encoding.Config, addresses []sdktypes.AccAddress) error {
+	feegrantClient := feegrant.NewQueryClient(conn)
+	msgs := make([]sdktypes.Msg, 0)
+	gasLimit := uint64(0)
+	for _, address := range addresses {
+		if _, _, err := QueryAccount(ctx, conn, encCfg, address.String()); err != nil {
+			if status.Code(err) != codes.NotFound {
+				return err
+			}
+			msgs = append(msgs, bank.NewMsgSend(
+				primary.Address(),
+				address,
+				sdktypes.NewCoins(sdktypes.NewInt64Coin(appconsts.BondDenom, poolAccountFunding)),
+			))
+			gasLimit += poolSendGasLimit
+		}
+
+		granted, err := hasAllowance(ctx, feegrantClient, primary.Address(), address)
+		if err != nil {
+			return err
+		}
+		if granted {
+			continue
+		}
+		msg, err := feegrant.NewMsgGrantAllowance(&feegrant.BasicAllowance{}, primary.Address(), address)
+		if err != nil {
+			return err
+		}
+		msgs = append(msgs, msg)
+		gasLimit += poolFeegrantGasLimit
+	}
+
+	if len(msgs) == 0 {
+		return nil
+	}
+
+	fee := uint64(math.Ceil(float64(gasLimit) * appconsts.DefaultMinGasPrice))
+	_, err := primary.SubmitTx(ctx, msgs, SetGasLimit(gasLimit), SetFee(fee))
+	return err
+}
+
+// hasAllowance returns true if the granter has granted a fee allowance to the
+// grantee.
+func hasAllowance(ctx context.Context, client feegrant.QueryClient, granter, grantee sdktypes.AccAddress) (bool, error) {
+	resp, err := client.Allowances(ctx, &feegrant.QueryAllowancesRequest{Grantee: grantee.String()})
+	if err != nil {
+		return false, err
+	}
+	for _, grant := range resp.Allowances {
+		if grant.Granter == granter.String() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Acquire returns a sub-account signer that is not currently in use, blocking
+// until one becomes available or the context is cancelled. The signer must be
+// returned to the pool with Release once the caller is done with it.
+// Transactions signed by the returned signer should set the primary account as
+// the fee granter (see SetFeeGranter and Primary).
+func (p *SignerPool) Acquire(ctx context.Context) (*Signer, error) {
+	select {
+	case signer := <-p.available:
+		return signer, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Release returns a signer acquired through Acquire back to the pool.
+func (p *SignerPool) Release(signer *Signer) {
+	p.available <- signer
+}
+
+// SubmitPayForBlob forms a transaction from the provided blobs, signs it with
+// the first available sub-account, and submits it to the chain. The primary
+// account pays the fees. It is safe to call concurrently: up to Size calls are
+// processed in parallel. TxOptions may be provided to set the fee and gas
+// limit.
+func (p *SignerPool) SubmitPayForBlob(ctx context.Context, blobs []*blob.Blob, opts ...TxOption) (*sdktypes.TxResponse, error) {
+	signer, err := p.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer p.Release(signer)
+
+	return signer.SubmitPayForBlob(ctx, blobs, append(slices.Clip(opts), SetFeeGranter(p.primary.Address()))...)
+}
+
+// SubmitTx forms a transaction using the messages returned by newMsgs for the
+// address of the first available sub-account, signs it, and submits it to the
+// chain. The primary account pays the fees. It is safe to call concurrently.
+func (p *SignerPool) SubmitTx(
+	ctx context.Context,
+	newMsgs func(signer sdktypes.AccAddress) []sdktypes.Msg,
+	opts ...TxOption,
+) (*sdktypes.TxResponse, error) {
+	signer, err := p.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer p.Release(signer)
+
+	return signer.SubmitTx(ctx, newMsgs(signer.Address()), append(slices.Clip(opts), SetFeeGranter(p.primary.Address()))...)
+}
+
+// Primary returns the signer of the primary account which funds the
+// sub-accounts and pays for their fees.
+func (p *SignerPool) Primary() *Signer {
+	return p.primary
+}
+
+// Signers returns the signers of all sub-accounts in the pool, including the
+// ones that are currently in use.
+func (p *SignerPool) Signers() []*Signer {
+	return p.signers
+}
+
+// Size returns the number of sub-accounts in the pool.
+func (p *SignerPool) Size() int {
+	return len(p.signers)
+}
@@ -0,0 +1,79 @@
+package user_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/celestiaorg/celestia-app/app"
+	"github.com/celestiaorg/celestia-app/app/encoding"
+	"github.com/celestiaorg/celestia-app/pkg/user"
+	"github.com/celestiaorg/celestia-app/test/util/blobfactory"
+	"github.com/celestiaorg/celestia-app/test/util/testnode"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/rand"
+)
+
+func TestSignerPool(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode.")
+	}
+
+	encCfg := encoding.MakeConfig(app.ModuleEncodingRegisters...)
+	ctx, _, _ := testnode.NewNetwork(t, testnode.DefaultConfig().WithFundedAccounts("primary"))
+	_, err := ctx.WaitForHeight(1)
+	require.NoError(t, err)
+	rec, err := ctx.Keyring.Key("primary")
+	require.NoError(t, err)
+	addr, err := rec.GetAddress()
+	require.NoError(t, err)
+
+	numAccounts := 3
+	pool, err := user.SetupSignerPool(ctx.GoContext(), ctx.Keyring, ctx.GRPCClient, addr, encCfg, numAccounts)
+	require.NoError(t, err)
+	require.Equal(t, numAccounts, pool.Size())
+
+	subCtx, cancel := context.WithTimeout(ctx.GoContext(), time.Minute)
+	defer cancel()
+
+	// submit more PFBs than there are sub-accounts so that sub-accounts are
+	// reused once they have been released
+	numTxs := 2 * numAccounts
+	var wg sync.WaitGroup
+	errCh := make(chan error, numTxs)
+	for i := 0; i < numTxs; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			blobs := blobfactory.ManyRandBlobs(rand.NewRand(), 1e3)
+			resp, err := pool.SubmitPayForBlob(subCtx, blobs, user.SetGasLimit(1e6), user.SetFee(1e6))
+			if err == nil && resp.Code != abci.CodeTypeOK {
+				err = fmt.Errorf("tx failed with code %d", resp.Code)
+			}
+			errCh <- err
+		}()
+	}
+	wg.Wait()
+	close(errCh)
+	for err := range errCh {
+		require.NoError(t, err)
+	}
+
+	sequences := make(map[string]uint64)
+	totalSequence := uint64(0)
+	for _, signer := range pool.Signers() {
+		sequences[signer.Address().String()] = signer.Sequence()
+		totalSequence += signer.Sequence()
+	}
+	require.EqualValues(t, numTxs, totalSequence)
+
+	// setting up the pool again reuses the existing sub-accounts
+	pool, err = user.SetupSignerPool(ctx.GoContext(), ctx.Keyring, ctx.GRPCClient, addr, encCfg, numAccounts)
+	require.NoError(t, err)
+	for _, signer := range pool.Signers() {
+		require.Equal(t, sequences[signer.Address().String()], signer.Sequence())
+	}
+}
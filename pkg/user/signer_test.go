@@ -3,14 +3,20 @@ package user_test
 import (
 	"context"
 	"fmt"
+	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/celestiaorg/celestia-app/app"
 	"github.com/celestiaorg/celestia-app/app/encoding"
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
 	"github.com/celestiaorg/celestia-app/pkg/user"
 	"github.com/celestiaorg/celestia-app/test/util/blobfactory"
+	"github.com/celestiaorg/celestia-app/test/util/testfactory"
 	"github.com/celestiaorg/celestia-app/test/util/testnode"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/multisig"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	bank "github.com/cosmos/cosmos-sdk/x/bank/types"
 	"github.com/stretchr/testify/assert"
@@ -18,6 +24,8 @@ import (
 	"github.com/stretchr/testify/suite"
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/libs/rand"
+	coretypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
 )
 
 func TestSignerTestSuite(t *testing.T) {
@@ -114,6 +122,226 @@ func (s *SignerTestSuite) TestConfirmTx() {
 	})
 }
 
+func (s *SignerTestSuite) TestConfirmTxWithEvents() {
+	t := s.T()
+	fee := user.SetFee(1e6)
+	gas := user.SetGasLimit(1e6)
+	newMsg := func() sdk.Msg {
+		return bank.NewMsgSend(s.signer.Address(), testnode.RandomAddress().(sdk.AccAddress), sdk.NewCoins(sdk.NewInt64Coin(app.BondDenom, 10)))
+	}
+
+	// a poll time longer than the timeout ensures that the txs are confirmed
+	// through their events
+	s.signer.SetPollTime(time.Minute)
+	defer s.signer.SetPollTime(user.DefaultPollTime)
+
+	t.Run("confirms a tx from its event", func(t *testing.T) {
+		s.signer.SetEventClient(s.ctx.Client)
+		defer s.signer.SetEventClient(nil)
+
+		ctx, cancel := context.WithTimeout(s.ctx.GoContext(), 30*time.Second)
+		defer cancel()
+		resp, err := s.submitTxWithoutConfirm([]sdk.Msg{newMsg()}, fee, gas)
+		require.NoError(t, err)
+		confirmed, err := s.signer.ConfirmTx(ctx, resp.TxHash)
+		require.NoError(t, err)
+		require.Equal(t, resp.TxHash, confirmed.TxHash)
+		require.EqualValues(t, abci.CodeTypeOK, confirmed.Code)
+		require.Greater(t, confirmed.Height, int64(0))
+	})
+
+	t.Run("confirms many txs at once", func(t *testing.T) {
+		s.signer.SetEventClient(s.ctx.Client)
+		defer s.signer.SetEventClient(nil)
+
+		ctx, cancel := context.WithTimeout(s.ctx.GoContext(), 30*time.Second)
+		defer cancel()
+		hashes := make([]string, 3)
+		for i := range hashes {
+			resp, err := s.submitTxWithoutConfirm([]sdk.Msg{newMsg()}, fee, gas)
+			require.NoError(t, err)
+			hashes[i] = resp.TxHash
+		}
+		resps, err := s.signer.ConfirmTxs(ctx, hashes...)
+		require.NoError(t, err)
+		require.Len(t, resps, len(hashes))
+		for i, resp := range resps {
+			require.Equal(t, hashes[i], resp.TxHash)
+			require.EqualValues(t, abci.CodeTypeOK, resp.Code)
+		}
+	})
+
+	t.Run("falls back to polling when the subscription is dropped", func(t *testing.T) {
+		s.signer.SetPollTime(time.Second)
+		s.signer.SetEventClient(droppedEventsClient{})
+		defer s.signer.SetEventClient(nil)
+
+		ctx, cancel := context.WithTimeout(s.ctx.GoContext(), 30*time.Second)
+		defer cancel()
+		resp, err := s.submitTxWithoutConfirm([]sdk.Msg{newMsg()}, fee, gas)
+		require.NoError(t, err)
+		confirmed, err := s.signer.ConfirmTx(ctx, resp.TxHash)
+		require.NoError(t, err)
+		require.EqualValues(t, abci.CodeTypeOK, confirmed.Code)
+	})
+}
+
+// droppedEventsClient is an events client whose subscriptions are closed
+// immediately.
+type droppedEventsClient struct{}
+
+func (droppedEventsClient) Subscribe(context.Context, string, string, ...int) (<-chan coretypes.ResultEvent, error) {
+	out := make(chan coretypes.ResultEvent)
+	close(out)
+	return out, nil
+}
+
+func (droppedEventsClient) Unsubscribe(context.Context, string, string) error { return nil }
+
+func (droppedEventsClient) UnsubscribeAll(context.Context, string) error { return nil }
+
+// channelEventsClient is an events client that delivers the events sent on
+// events to every subscription and records the subscribed queries.
+type channelEventsClient struct {
+	mtx     sync.Mutex
+	queries []string
+	events  chan coretypes.ResultEvent
+}
+
+func (c *channelEventsClient) Subscribe(_ context.Context, _, query string, _ ...int) (<-chan coretypes.ResultEvent, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.queries = append(c.queries, query)
+	return c.events, nil
+}
+
+func (*channelEventsClient) Unsubscribe(context.Context, string, string) error { return nil }
+
+func (*channelEventsClient) UnsubscribeAll(context.Context, string) error { return nil }
+
+func TestConfirmTxsSharesEventSubscription(t *testing.T) {
+	encCfg := encoding.MakeConfig(app.ModuleEncodingRegisters...)
+	kr := testfactory.TestKeyring(encCfg.Codec, "signer")
+	address := testfactory.GetAddress(kr, "signer")
+
+	// the node is queried once for each tx after its call waits for events
+	queried := make(chan string, 2)
+	service := &mockTxService{getTx: func(hash string) *sdk.TxResponse {
+		queried <- hash
+		return nil
+	}}
+	conn := newMockTxServiceConn(t, encCfg, service)
+	signer, err := user.NewSigner(kr, conn, address, encCfg.TxConfig, "chain", 1, 0, appconsts.LatestVersion)
+	require.NoError(t, err)
+	signer.SetPollTime(time.Minute)
+	client := &channelEventsClient{events: make(chan coretypes.ResultEvent)}
+	signer.SetEventClient(client)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	hashes := []string{"AAAA", "BBBB"}
+	var wg sync.WaitGroup
+	for _, hash := range hashes {
+		wg.Add(1)
+		go func(hash string) {
+			defer wg.Done()
+			resp, err := signer.ConfirmTx(ctx, hash)
+			require.NoError(t, err)
+			require.Equal(t, hash, resp.TxHash)
+			require.EqualValues(t, 2, resp.Height)
+		}(hash)
+	}
+	<-queried
+	<-queried
+
+	for _, hash := range []string{"CCCC", "BBBB", "AAAA"} {
+		client.events <- coretypes.ResultEvent{
+			Data:   tmtypes.EventDataTx{TxResult: abci.TxResult{Height: 2}},
+			Events: map[string][]string{tmtypes.TxHashKey: {hash}},
+		}
+	}
+	wg.Wait()
+
+	require.Equal(t, []string{fmt.Sprintf("tm.event='Tx' AND tx.acc_seq CONTAINS '%s/'", address)}, client.queries)
+}
+
+func (s *SignerTestSuite) TestSequenceMismatchRecovery() {
+	t := s.T()
+	fee := user.SetFee(1e6)
+	gas := user.SetGasLimit(1e6)
+	msg := bank.NewMsgSend(s.signer.Address(), testnode.RandomAddress().(sdk.AccAddress), sdk.NewCoins(sdk.NewInt64Coin(app.BondDenom, 10)))
+
+	t.Run("recovers when the local sequence is ahead", func(t *testing.T) {
+		s.signer.ForceSetSequence(s.signer.Sequence() + 5)
+		resp, err := s.signer.SubmitTx(s.ctx.GoContext(), []sdk.Msg{msg}, fee, gas)
+		require.NoError(t, err)
+		require.EqualValues(t, abci.CodeTypeOK, resp.Code)
+	})
+
+	t.Run("recovers when the local sequence is behind", func(t *testing.T) {
+		s.signer.ForceSetSequence(0)
+		resp, err := s.signer.SubmitTx(s.ctx.GoContext(), []sdk.Msg{msg}, fee, gas)
+		require.NoError(t, err)
+		require.EqualValues(t, abci.CodeTypeOK, resp.Code)
+	})
+
+	t.Run("broadcast corrects the sequence without resubmitting", func(t *testing.T) {
+		expectedSequence := s.signer.Sequence()
+		s.signer.ForceSetSequence(expectedSequence + 5)
+		txBytes, err := s.signer.CreateTx([]sdk.Msg{msg}, fee, gas)
+		require.NoError(t, err)
+		resp, err := s.signer.BroadcastTx(s.ctx.GoContext(), txBytes)
+		require.NoError(t, err)
+		require.NotEqualValues(t, abci.CodeTypeOK, resp.Code)
+		require.Equal(t, expectedSequence, s.signer.Sequence())
+	})
+
+	t.Run("fails when retries are disabled", func(t *testing.T) {
+		s.signer.SetMaxSequenceRetries(0)
+		defer s.signer.SetMaxSequenceRetries(user.DefaultMaxSequenceRetries)
+		s.signer.ForceSetSequence(s.signer.Sequence() + 5)
+		_, err := s.signer.SubmitTx(s.ctx.GoContext(), []sdk.Msg{msg}, fee, gas)
+		require.Error(t, err)
+	})
+}
+
+func (s *SignerTestSuite) TestGasEstimation() {
+	t := s.T()
+	msg := bank.NewMsgSend(s.signer.Address(), testnode.RandomAddress().(sdk.AccAddress), sdk.NewCoins(sdk.NewInt64Coin(app.BondDenom, 10)))
+
+	t.Run("estimates gas and fee of a tx", func(t *testing.T) {
+		gas, err := s.signer.EstimateGas(s.ctx.GoContext(), []sdk.Msg{msg})
+		require.NoError(t, err)
+		require.Greater(t, gas, uint64(0))
+
+		resp, err := s.signer.SubmitTx(s.ctx.GoContext(), []sdk.Msg{msg})
+		require.NoError(t, err)
+		require.EqualValues(t, abci.CodeTypeOK, resp.Code)
+		require.LessOrEqual(t, resp.GasUsed, resp.GasWanted)
+	})
+
+	t.Run("estimates gas and fee of a PFB", func(t *testing.T) {
+		blobs := blobfactory.ManyRandBlobs(rand.NewRand(), 1e3, 1e4)
+		resp, err := s.signer.SubmitPayForBlob(s.ctx.GoContext(), blobs)
+		require.NoError(t, err)
+		require.EqualValues(t, abci.CodeTypeOK, resp.Code)
+		require.LessOrEqual(t, resp.GasUsed, resp.GasWanted)
+	})
+
+	t.Run("keeps the fee provided in the options", func(t *testing.T) {
+		balanceBefore := s.queryCurrentBalance(t)
+		resp, err := s.signer.SubmitTx(s.ctx.GoContext(), []sdk.Msg{msg}, user.SetFee(1e5))
+		require.NoError(t, err)
+		require.EqualValues(t, abci.CodeTypeOK, resp.Code)
+		require.Equal(t, int64(1e5+10), balanceBefore-s.queryCurrentBalance(t))
+	})
+
+	t.Run("rejects a gas multiplier below one", func(t *testing.T) {
+		require.Error(t, s.signer.SetGasMultiplier(0.5))
+		require.Equal(t, user.DefaultGasMultiplier, s.signer.GasMultiplier())
+	})
+}
+
 // TestGasConsumption verifies that the amount deducted from a user's balance is
 // based on the fee provided in the tx instead of the gas used by the tx. This
 // behavior leads to poor UX because tx submitters must over-estimate the amount
@@ -171,3 +399,189 @@ func (s *SignerTestSuite) submitTxWithoutConfirm(msgs []sdk.Msg, opts ...user.Tx
 	}
 	return resp, nil
 }
+
+func (s *SignerTestSuite) TestMultisigPayForBlob() {
+	t := s.T()
+	ctx := s.ctx.GoContext()
+
+	// each member of the 2 of 3 multisig keeps its key in a separate keyring
+	members := make([]sdk.AccAddress, 3)
+	backends := make([]user.SigningBackend, 3)
+	pubKeys := make([]cryptotypes.PubKey, 3)
+	for i := range members {
+		name := fmt.Sprintf("member-%d", i)
+		kr := testfactory.TestKeyring(s.encCfg.Codec, name)
+		members[i] = testfactory.GetAddress(kr, name)
+		backends[i] = user.NewKeyringBackend(kr)
+		pk, err := backends[i].PubKey(members[i])
+		require.NoError(t, err)
+		pubKeys[i] = pk
+	}
+	multisigPubKey := multisig.NewLegacyAminoPubKey(2, pubKeys)
+	multisigAddress := sdk.AccAddress(multisigPubKey.Address())
+
+	msg := bank.NewMsgSend(s.signer.Address(), multisigAddress, sdk.NewCoins(sdk.NewInt64Coin(app.BondDenom, 1e9)))
+	_, err := s.signer.SubmitTx(ctx, []sdk.Msg{msg}, user.SetGasLimit(1e6), user.SetFee(1e6))
+	require.NoError(t, err)
+	accNum, seq, err := user.QueryAccount(ctx, s.ctx.GRPCClient, s.encCfg, multisigAddress.String())
+	require.NoError(t, err)
+
+	blobs := blobfactory.ManyRandBlobs(rand.NewRand(), 1e3)
+	pfb, err := user.NewMultisigPayForBlob(s.encCfg.TxConfig, multisigPubKey, s.signer.ChainID(), accNum, seq, appconsts.LatestVersion, blobs, user.SetGasLimit(1e6), user.SetFee(1e6))
+	require.NoError(t, err)
+
+	// the unsigned tx is passed to the members as JSON
+	bz, err := user.MarshalMultisigPayForBlob(pfb)
+	require.NoError(t, err)
+	pfb, err = user.UnmarshalMultisigPayForBlob(s.encCfg.TxConfig, bz)
+	require.NoError(t, err)
+	require.Equal(t, multisigAddress, pfb.Address())
+
+	// a key that is not part of the multisig can't sign
+	_, err = pfb.Sign(user.NewKeyringBackend(s.ctx.Keyring), s.signer.Address())
+	require.Error(t, err)
+
+	sig0, err := pfb.Sign(backends[0], members[0])
+	require.NoError(t, err)
+	sig2, err := pfb.Sign(backends[2], members[2])
+	require.NoError(t, err)
+
+	// the threshold must be met
+	_, err = pfb.Combine(sig0)
+	require.Error(t, err)
+	_, err = pfb.Combine(sig0, sig0)
+	require.Error(t, err)
+
+	blobTx, err := pfb.Combine(sig0, sig2)
+	require.NoError(t, err)
+	resp, err := s.signer.BroadcastTx(ctx, blobTx)
+	require.NoError(t, err)
+	require.EqualValues(t, abci.CodeTypeOK, resp.Code, resp.RawLog)
+	resp, err = s.signer.ConfirmTx(ctx, resp.TxHash)
+	require.NoError(t, err)
+	require.EqualValues(t, abci.CodeTypeOK, resp.Code)
+}
+
+func (s *SignerTestSuite) TestBlobClient() {
+	t := s.T()
+	ctx := s.ctx.GoContext()
+	client := user.NewBlobClient(s.signer)
+
+	maxSquareSize, err := client.MaxSquareSize(ctx)
+	require.NoError(t, err)
+	require.Positive(t, maxSquareSize)
+
+	blobs := blobfactory.ManyRandBlobs(rand.NewRand(), 1e3, 1e4, 100)
+	submitted, err := client.Submit(ctx, blobs)
+	require.NoError(t, err)
+	require.Len(t, submitted, len(blobs))
+	for i, b := range submitted {
+		require.Equal(t, blobs[i].Namespace(), b.Namespace)
+		require.False(t, b.Split())
+		require.Len(t, b.Heights, 1)
+		require.Positive(t, b.Heights[0])
+		require.NotEmpty(t, b.Commitments[0])
+		require.NotEmpty(t, b.TxHashes[0])
+	}
+}
+
+func TestBlobClientSubmitCancelled(t *testing.T) {
+	encCfg := encoding.MakeConfig(app.ModuleEncodingRegisters...)
+	kr := testfactory.TestKeyring(encCfg.Codec, "signer")
+	address := testfactory.GetAddress(kr, "signer")
+
+	// the tx is accepted but the context is cancelled while it is confirmed
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	service := &mockTxService{
+		broadcast: func([]byte) *sdk.TxResponse { return &sdk.TxResponse{TxHash: "AAAA"} },
+		getTx: func(string) *sdk.TxResponse {
+			cancel()
+			return nil
+		},
+	}
+	conn := newMockTxServiceConn(t, encCfg, service)
+	signer, err := user.NewSigner(kr, conn, address, encCfg.TxConfig, "chain", 1, 0, appconsts.LatestVersion)
+	require.NoError(t, err)
+
+	blobs := blobfactory.ManyRandBlobs(rand.NewRand(), 1e3)
+	submitted, err := user.NewBlobClient(signer).Submit(ctx, blobs, user.SetGasLimit(1e6), user.SetFee(1e6))
+	require.ErrorContains(t, err, context.Canceled.Error())
+	require.Len(t, submitted, 1)
+	require.Equal(t, "AAAA", submitted[0].TxHashes[0])
+	require.Zero(t, submitted[0].Heights[0])
+}
+
+func (s *SignerTestSuite) TestJournal() {
+	t := s.T()
+	ctx := s.ctx.GoContext()
+	journal, err := user.NewFileJournal(filepath.Join(t.TempDir(), "journal.json"))
+	require.NoError(t, err)
+	s.signer.SetJournal(journal)
+	defer s.signer.SetJournal(nil)
+
+	// a confirmed tx is removed from the journal
+	blobs := blobfactory.ManyRandBlobs(rand.NewRand(), 1e3)
+	_, err = s.signer.SubmitPayForBlob(ctx, blobs, user.SetGasLimit(1e6), user.SetFee(1e6))
+	require.NoError(t, err)
+	entries, err := journal.Entries()
+	require.NoError(t, err)
+	require.Empty(t, entries)
+
+	// simulate a crash after broadcasting a tx
+	blobTx, err := s.signer.CreatePayForBlob(blobs, user.SetGasLimit(1e6), user.SetFee(1e6))
+	require.NoError(t, err)
+	resp, err := s.signer.BroadcastTx(ctx, blobTx)
+	require.NoError(t, err)
+	require.EqualValues(t, abci.CodeTypeOK, resp.Code, resp.RawLog)
+	entries, err = journal.Entries()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, resp.TxHash, entries[0].TxHash)
+	require.Equal(t, s.signer.Sequence()-1, entries[0].Sequence)
+
+	// an uncommitted tx whose sequence has been used is dropped
+	stale := user.JournalEntry{TxHash: "00", Sequence: 0, TxBytes: []byte{1}}
+	require.NoError(t, journal.Put(stale))
+
+	reconciliation, err := s.signer.ReconcileJournal(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []user.JournalEntry{stale}, reconciliation.Dropped)
+	// the broadcast tx is either still in the mempool or already committed
+	require.Equal(t, 1, len(reconciliation.Resubmitted)+len(reconciliation.Confirmed))
+
+	_, err = s.signer.ConfirmTx(ctx, resp.TxHash)
+	require.NoError(t, err)
+	entries, err = journal.Entries()
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+func (s *SignerTestSuite) TestSubmitPayForBlobWithProofs() {
+	t := s.T()
+	ctx := s.ctx.GoContext()
+	s.signer.SetProofClient(s.ctx.Client)
+	defer s.signer.SetProofClient(nil)
+
+	blobs := blobfactory.ManyRandBlobs(rand.NewRand(), 1e3, 1e4)
+	resp, inclusions, err := s.signer.SubmitPayForBlobWithProofs(ctx, blobs, user.SetGasLimit(1e6), user.SetFee(1e6))
+	require.NoError(t, err)
+	require.Len(t, inclusions, len(blobs))
+
+	blockRes, err := s.ctx.Client.Block(ctx, &resp.Height)
+	require.NoError(t, err)
+	for i, inclusion := range inclusions {
+		require.Equal(t, blobs[i].Namespace(), inclusion.Namespace)
+		require.Equal(t, resp.Height, inclusion.Height)
+		require.NotEmpty(t, inclusion.Commitment)
+		require.EqualValues(t, inclusion.ShareRange.End-inclusion.ShareRange.Start, len(inclusion.Proof.Data))
+		require.NoError(t, inclusion.Proof.Validate(blockRes.Block.DataHash))
+	}
+
+	// a tx that is not a PayForBlobs can't be proven
+	msg := bank.NewMsgSend(s.signer.Address(), testnode.RandomAddress().(sdk.AccAddress), sdk.NewCoins(sdk.NewInt64Coin(app.BondDenom, 10)))
+	resp, err = s.signer.SubmitTx(ctx, []sdk.Msg{msg}, user.SetGasLimit(1e6), user.SetFee(1e6))
+	require.NoError(t, err)
+	_, err = s.signer.ProveBlobs(ctx, resp)
+	require.Error(t, err)
+}
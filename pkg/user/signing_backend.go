@@ -0,0 +1,52 @@
+package user
+
+import (
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+)
+
+// SigningBackend holds the keys of the accounts that a Signer signs for. It
+// allows the keys to be kept outside of the process that builds and submits
+// transactions, for example in a separate signing service.
+type SigningBackend interface {
+	// PubKey returns the public key of the account with the provided address.
+	PubKey(address sdktypes.AccAddress) (cryptotypes.PubKey, error)
+	// Sign returns the signature of the account with the provided address over
+	// signBytes.
+	Sign(address sdktypes.AccAddress, signBytes []byte) ([]byte, error)
+}
+
+// KeyringBackend is the default SigningBackend which signs with the keys of a
+// local keyring.
+type KeyringBackend struct {
+	keys keyring.Keyring
+}
+
+var _ SigningBackend = &KeyringBackend{}
+
+// NewKeyringBackend returns a SigningBackend that signs with the keys of the
+// provided keyring.
+func NewKeyringBackend(keys keyring.Keyring) *KeyringBackend {
+	return &KeyringBackend{keys: keys}
+}
+
+// PubKey implements SigningBackend.
+func (b *KeyringBackend) PubKey(address sdktypes.AccAddress) (cryptotypes.PubKey, error) {
+	record, err := b.keys.KeyByAddress(address)
+	if err != nil {
+		return nil, err
+	}
+	return record.GetPubKey()
+}
+
+// Sign implements SigningBackend.
+func (b *KeyringBackend) Sign(address sdktypes.AccAddress, signBytes []byte) ([]byte, error) {
+	signature, _, err := b.keys.SignByAddress(address, signBytes)
+	return signature, err
+}
+
+// Keyring returns the underlying keyring.
+func (b *KeyringBackend) Keyring() keyring.Keyring {
+	return b.keys
+}
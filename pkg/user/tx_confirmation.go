@@ -0,0 +1,395 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx"
+	abci "github.com/tendermint/tendermint/abci/types"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	coretypes "github.com/tendermint/tendermint/types"
+)
+
+// txEventQuery returns the query used to subscribe to the events of the
+// committed txs signed by address. Every tx emits the address and sequence of
+// each of its signers under tx.acc_seq and its hash under coretypes.TxHashKey.
+func txEventQuery(address sdktypes.AccAddress) string {
+	return fmt.Sprintf("%s='%s' AND %s.%s CONTAINS '%s/'",
+		coretypes.EventTypeKey, coretypes.EventTx, sdktypes.EventTypeTx, sdktypes.AttributeKeyAccountSequence, address)
+}
+
+// txEventBufferSize is the number of events of the signer's txs that can be
+// buffered by the node before the subscription is dropped.
+const txEventBufferSize = 100
+
+// subscriberCount is used to give each subscription a unique subscriber name.
+var subscriberCount atomic.Uint64
+
+// SetEventClient sets the Tendermint RPC client that is used to subscribe to tx
+// events. Once set, ConfirmTx and ConfirmTxs resolve as soon as the node emits
+// the event of the tx instead of polling the node every poll time. The signer
+// holds a single subscription to the events of its own txs, which is shared by
+// all calls and ended when the client is replaced. A remote client must be
+// started before it is used, e.g.:
+//
+//	client, err := http.New("tcp://localhost:26657", "/websocket")
+//	err = client.Start()
+//	signer.SetEventClient(client)
+//
+// Setting the client to nil restores polling.
+func (s *Signer) SetEventClient(client rpcclient.EventsClient) {
+	s.eventMtx.Lock()
+	defer s.eventMtx.Unlock()
+	s.mtx.Lock()
+	s.eventClient = client
+	s.mtx.Unlock()
+	if s.eventSub != nil {
+		s.eventSub.close()
+		s.eventSub = nil
+	}
+}
+
+// ConfirmTxs waits for all of the provided txs to be committed and returns
+// their responses in the same order. If an event client is set, it waits for
+// the events of the txs and falls back to polling if the subscription
+// is dropped. Otherwise, it periodically pings the node for the txs. It returns
+// once all txs have been found, the context is cancelled or an error is
+// encountered. Txs that were committed with a non-zero code are returned
+// together with an error describing each failure. Txs that have been found are
+// removed from the journal if one is set.
+func (s *Signer) ConfirmTxs(ctx context.Context, txHashes ...string) ([]*sdktypes.TxResponse, error) {
+	c := newTxConfirmations(txHashes)
+	if c.complete() {
+		return c.resps, nil
+	}
+	if err := s.checkOnline(); err != nil {
+		return c.resps, err
+	}
+	defer s.forgetConfirmed(c)
+
+	return c.resps, c.result(s.confirm(ctx, c))
+}
+
+// confirm waits for the events of the pending txs if an event client is set
+// and polls the node for them otherwise or once the subscription is dropped.
+func (s *Signer) confirm(ctx context.Context, c *txConfirmations) error {
+	s.mtx.RLock()
+	eventClient := s.eventClient
+	pollTime := s.pollTime
+	s.mtx.RUnlock()
+
+	if eventClient != nil {
+		err := s.waitForTxEvents(ctx, c)
+		if err != nil || c.complete() {
+			return err
+		}
+		// the subscription was dropped so the remaining txs are polled
+	}
+
+	return s.pollTxs(ctx, c, pollTime)
+}
+
+// txConfirmations tracks the responses of the txs passed to ConfirmTxs.
+type txConfirmations struct {
+	resps []*sdktypes.TxResponse
+	// pending maps the upper case hash of each tx that has not been found yet
+	// to its indices in resps.
+	pending map[string][]int
+	// failed contains an error for each tx that was committed with a
+	// non-zero code.
+	failed []error
+	// anyTx completes the confirmation once any of the txs has been found,
+	// which is the case for the versions of a tx replaced with a higher fee
+	// of which at most one can be committed.
+	anyTx bool
+}
+
+// newTxConfirmations returns the confirmations of the txs with the provided
+// hashes, which have not been found yet.
+func newTxConfirmations(txHashes []string) *txConfirmations {
+	c := &txConfirmations{
+		resps:   make([]*sdktypes.TxResponse, len(txHashes)),
+		pending: make(map[string][]int, len(txHashes)),
+	}
+	for i, hash := range txHashes {
+		c.resps[i] = &sdktypes.TxResponse{}
+		key := strings.ToUpper(hash)
+		c.pending[key] = append(c.pending[key], i)
+	}
+	return c
+}
+
+// complete returns true once all txs or, if anyTx is set, one of them have
+// been found.
+func (c *txConfirmations) complete() bool {
+	if c.anyTx {
+		for _, resp := range c.resps {
+			if resp.TxHash != "" {
+				return true
+			}
+		}
+	}
+	return len(c.pending) == 0
+}
+
+// found records the response of a committed tx.
+func (c *txConfirmations) found(hash string, resp *sdktypes.TxResponse) {
+	for _, i := range c.pending[hash] {
+		c.resps[i] = resp
+	}
+	delete(c.pending, hash)
+	if resp.Code != 0 {
+		c.failed = append(c.failed, fmt.Errorf("tx failed with code %d: %s", resp.Code, resp.RawLog))
+	}
+}
+
+// forgetConfirmed stops tracking the txs that have been found and removes
+// them from the journal.
+func (s *Signer) forgetConfirmed(c *txConfirmations) {
+	for _, resp := range c.resps {
+		if resp.TxHash != "" {
+			s.forgetAccepted(resp.TxHash)
+			s.forgetTxs(resp.TxHash)
+		}
+	}
+}
+
+// result returns err if it is not nil and the errors of all failed txs
+// otherwise.
+func (c *txConfirmations) result(err error) error {
+	if err != nil {
+		return err
+	}
+	return errors.Join(c.failed...)
+}
+
+// queryPending queries the node for each pending tx and records the ones that
+// have been committed.
+func (s *Signer) queryPending(ctx context.Context, c *txConfirmations) error {
+	txClient := tx.NewServiceClient(s.grpc)
+	for hash := range c.pending {
+		resp, err := txClient.GetTx(ctx, &tx.GetTxRequest{Hash: hash})
+		if err != nil {
+			if strings.Contains(err.Error(), "not found") {
+				continue
+			}
+			return err
+		}
+		c.found(hash, resp.TxResponse)
+	}
+	return nil
+}
+
+// pollTxs queries the node for the pending txs every pollTime until the
+// confirmation is complete.
+func (s *Signer) pollTxs(ctx context.Context, c *txConfirmations, pollTime time.Duration) error {
+	pollTicker := time.NewTicker(pollTime)
+	defer pollTicker.Stop()
+
+	for {
+		if err := s.queryPending(ctx, c); err != nil {
+			return err
+		}
+		if c.complete() {
+			return nil
+		}
+
+		// Wait for the next round.
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-pollTicker.C:
+		}
+	}
+}
+
+// waitForTxEvents waits for the events of the pending txs on the signer's
+// subscription and records the txs as their events arrive. It returns without
+// an error once the confirmation is complete or if the subscription fails or
+// is dropped, in which case some txs remain pending.
+func (s *Signer) waitForTxEvents(ctx context.Context, c *txConfirmations) error {
+	sub := s.subscribeTxEvents(ctx)
+	if sub == nil {
+		// the txs are polled instead
+		return nil
+	}
+	hashes := make([]string, 0, len(c.pending))
+	for hash := range c.pending {
+		hashes = append(hashes, hash)
+	}
+	resps := make(chan *sdktypes.TxResponse, len(hashes))
+	sub.wait(resps, hashes)
+	defer sub.cancel(resps, hashes)
+
+	// txs that were committed before they were waited for won't emit an event
+	// anymore, so the node is queried for them once.
+	if err := s.queryPending(ctx, c); err != nil {
+		return err
+	}
+
+	for !c.complete() {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-sub.done:
+			return nil
+		case resp := <-resps:
+			if _, pending := c.pending[resp.TxHash]; pending {
+				c.found(resp.TxHash, resp)
+			}
+		}
+	}
+	return nil
+}
+
+// subscribeTxEvents returns the subscription to the events of the signer's
+// txs and subscribes with the event client if there is none or the previous
+// subscription was dropped. It returns nil if no event client is set or the
+// subscription fails.
+func (s *Signer) subscribeTxEvents(ctx context.Context) *txEventSubscription {
+	s.eventMtx.Lock()
+	defer s.eventMtx.Unlock()
+	if s.eventSub != nil {
+		if !s.eventSub.dropped() {
+			return s.eventSub
+		}
+		s.eventSub.close()
+		s.eventSub = nil
+	}
+
+	s.mtx.RLock()
+	client := s.eventClient
+	s.mtx.RUnlock()
+	if client == nil {
+		return nil
+	}
+
+	sub := &txEventSubscription{
+		client:     client,
+		subscriber: fmt.Sprintf("signer-%s-%d", s.address, subscriberCount.Add(1)),
+		query:      txEventQuery(s.address),
+		stop:       make(chan struct{}),
+		done:       make(chan struct{}),
+		waiting:    make(map[string][]chan<- *sdktypes.TxResponse),
+	}
+	events, err := client.Subscribe(ctx, sub.subscriber, sub.query, txEventBufferSize)
+	if err != nil {
+		return nil
+	}
+	go sub.run(events)
+	s.eventSub = sub
+	return sub
+}
+
+// txEventSubscription dispatches the events of the committed txs of a signer
+// to the ConfirmTxs calls waiting for them.
+type txEventSubscription struct {
+	client     rpcclient.EventsClient
+	subscriber string
+	query      string
+	// stop is closed to end the subscription and done is closed once events
+	// are no longer dispatched.
+	stop chan struct{}
+	done chan struct{}
+
+	mtx sync.Mutex
+	// waiting maps the upper case hash of each tx that is waited for to the
+	// channels its response is sent on.
+	waiting map[string][]chan<- *sdktypes.TxResponse
+}
+
+// run dispatches the events until the subscription is dropped or stopped.
+func (sub *txEventSubscription) run(events <-chan ctypes.ResultEvent) {
+	defer close(sub.done)
+	for {
+		select {
+		case <-sub.stop:
+			return
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			data, ok := event.Data.(coretypes.EventDataTx)
+			if !ok {
+				continue
+			}
+			for _, hash := range event.Events[coretypes.TxHashKey] {
+				sub.dispatch(newTxResponse(strings.ToUpper(hash), data.TxResult))
+			}
+		}
+	}
+}
+
+// dispatch sends the response of a tx to every channel waiting for it. Each
+// channel receives at most one response per hash it waits for so, as long as
+// it can buffer a response for each of them, sending never blocks.
+func (sub *txEventSubscription) dispatch(resp *sdktypes.TxResponse) {
+	sub.mtx.Lock()
+	defer sub.mtx.Unlock()
+	for _, ch := range sub.waiting[resp.TxHash] {
+		ch <- resp
+	}
+	delete(sub.waiting, resp.TxHash)
+}
+
+// wait registers ch to receive the responses of the txs with the provided
+// upper case hashes. ch must be able to buffer a response for each hash.
+func (sub *txEventSubscription) wait(ch chan<- *sdktypes.TxResponse, hashes []string) {
+	sub.mtx.Lock()
+	defer sub.mtx.Unlock()
+	for _, hash := range hashes {
+		sub.waiting[hash] = append(sub.waiting[hash], ch)
+	}
+}
+
+// cancel stops ch from receiving the responses of the txs with the provided
+// hashes.
+func (sub *txEventSubscription) cancel(ch chan<- *sdktypes.TxResponse, hashes []string) {
+	sub.mtx.Lock()
+	defer sub.mtx.Unlock()
+	for _, hash := range hashes {
+		waiting := slices.DeleteFunc(sub.waiting[hash], func(c chan<- *sdktypes.TxResponse) bool { return c == ch })
+		if len(waiting) == 0 {
+			delete(sub.waiting, hash)
+		} else {
+			sub.waiting[hash] = waiting
+		}
+	}
+}
+
+// dropped returns true if events are no longer dispatched.
+func (sub *txEventSubscription) dropped() bool {
+	select {
+	case <-sub.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// close ends the subscription.
+func (sub *txEventSubscription) close() {
+	close(sub.stop)
+	_ = sub.client.Unsubscribe(context.Background(), sub.subscriber, sub.query)
+}
+
+// newTxResponse returns the response of a tx from its event. The tx is not
+// yet indexed by the node when the event is emitted so, unlike the response
+// returned by the node, it does not contain the tx or the block timestamp.
+func newTxResponse(hash string, result abci.TxResult) *sdktypes.TxResponse {
+	resp := sdktypes.NewResponseResultTx(&ctypes.ResultTx{
+		Height:   result.Height,
+		Index:    result.Index,
+		TxResult: result.Result,
+	}, nil, "")
+	resp.TxHash = hash
+	return resp
+}
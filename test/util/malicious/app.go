@@ -19,6 +19,10 @@ const (
 	// OutOfOrderHandlerKey is the key used to set the out of order prepare
 	// proposal handler.
 	OutOfOrderHandlerKey = "out_of_order"
+
+	// BadEncodingHandlerKey is the key used to set the bad encoding prepare
+	// proposal handler.
+	BadEncodingHandlerKey = "bad_encoding"
 )
 
 // BehaviorConfig defines the malicious behavior for the application. It
@@ -37,7 +41,8 @@ type PrepareProposalHandler func(req abci.RequestPrepareProposal) abci.ResponseP
 // PrepareProposalHandlerMap is a map of all the known prepare proposal handlers.
 func (a *App) PrepareProposalHandlerMap() map[string]PrepareProposalHandler {
 	return map[string]PrepareProposalHandler{
-		OutOfOrderHandlerKey: a.OutOfOrderPrepareProposal,
+		OutOfOrderHandlerKey:  a.OutOfOrderPrepareProposal,
+		BadEncodingHandlerKey: a.BadEncodingPrepareProposal,
 	}
 }
 
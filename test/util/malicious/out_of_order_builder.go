@@ -56,6 +56,13 @@ func Construct(txs [][]byte, appVersion uint64, maxSquareSize int, efn ExportFn)
 	return efn(builder)
 }
 
+var _ ExportFn = HonestExport
+
+// HonestExport constructs the square like the honest square builder.
+func HonestExport(b *square.Builder) (square.Square, error) {
+	return b.Export()
+}
+
 var _ ExportFn = OutOfOrderExport
 
 // OutOfOrderExport constructs the square in a malicious and deterministic way
@@ -5,6 +5,7 @@ import (
 	"github.com/celestiaorg/celestia-app/app/ante"
 	"github.com/celestiaorg/celestia-app/pkg/da"
 	"github.com/celestiaorg/go-square/shares"
+	"github.com/celestiaorg/rsmt2d"
 	abci "github.com/tendermint/tendermint/abci/types"
 	core "github.com/tendermint/tendermint/proto/tendermint/types"
 )
@@ -15,6 +16,26 @@ import (
 // for. It will swap the order of two blobs in the square and then use the
 // modified nmt to create a commitment over the modified square.
 func (a *App) OutOfOrderPrepareProposal(req abci.RequestPrepareProposal) abci.ResponsePrepareProposal {
+	return a.prepareMaliciousProposal(req, OutOfOrderExport, ExtendShares)
+}
+
+// BadEncodingPrepareProposal fulfills the celestia-core version of the ABCI
+// interface by preparing the proposal block data. This version of the method is
+// used to create malicious block proposals that bad encoding fraud proofs can
+// be created for. It builds the square correctly but corrupts a parity share of
+// the extended data square before committing to it.
+func (a *App) BadEncodingPrepareProposal(req abci.RequestPrepareProposal) abci.ResponsePrepareProposal {
+	return a.prepareMaliciousProposal(req, HonestExport, ExtendSharesWithBadEncoding)
+}
+
+// prepareMaliciousProposal prepares the proposal block data by building the
+// square with the export function and committing to the square extended with
+// the extend function.
+func (a *App) prepareMaliciousProposal(
+	req abci.RequestPrepareProposal,
+	efn ExportFn,
+	extend func(s [][]byte) (*rsmt2d.ExtendedDataSquare, error),
+) abci.ResponsePrepareProposal {
 	// create a context using a branch of the state and loaded using the
 	// proposal height and chain-id
 	sdkCtx := a.NewProposalContext(core.Header{ChainID: a.GetChainID(), Height: a.LastBlockHeight() + 1})
@@ -36,15 +57,15 @@ func (a *App) OutOfOrderPrepareProposal(req abci.RequestPrepareProposal) abci.Re
 
 	// build the square from the set of valid and prioritised transactions.
 	// The txs returned are the ones used in the square and block
-	dataSquare, txs, err := Build(txs, a.GetBaseApp().AppVersion(sdkCtx), a.GovSquareSizeUpperBound(sdkCtx), OutOfOrderExport)
+	dataSquare, txs, err := Build(txs, a.GetBaseApp().AppVersion(sdkCtx), a.GovSquareSizeUpperBound(sdkCtx), efn)
 	if err != nil {
 		panic(err)
 	}
 
-	// erasure the data square which we use to create the data root. Note: this
-	// is using a modified version of nmt where the order of the namepspaces is
-	// not enforced.
-	eds, err := ExtendShares(shares.ToBytes(dataSquare))
+	// erasure the data square which we use to create the data root. Note: the
+	// extend function is malicious, e.g. it uses a modified version of nmt
+	// where the order of the namepspaces is not enforced.
+	eds, err := extend(shares.ToBytes(dataSquare))
 	if err != nil {
 		a.Logger().Error(
 			"failure to erasure the data square while creating a proposal block",
@@ -32,6 +32,13 @@ func OutOfOrderNamespaceConfig(startHeight int64) *testnode.Config {
 	return TestNodeConfig(bcfg)
 }
 
+// BadEncodingConfig returns a testnode config that will start producing blocks
+// with a badly encoded extended data square at the provided height.
+func BadEncodingConfig(startHeight int64) *testnode.Config {
+	bcfg := BehaviorConfig{StartHeight: startHeight, HandlerName: BadEncodingHandlerKey}
+	return TestNodeConfig(bcfg)
+}
+
 // TestNodeConfig returns a testnode config with the malicious application and
 // provided behavior set in the app options.
 func TestNodeConfig(behavior BehaviorConfig) *testnode.Config {
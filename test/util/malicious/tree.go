@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	"github.com/celestiaorg/celestia-app/pkg/da"
 	"github.com/celestiaorg/celestia-app/pkg/wrapper"
 	"github.com/celestiaorg/go-square/shares"
 	"github.com/celestiaorg/go-square/square"
@@ -69,3 +70,22 @@ func ExtendShares(s [][]byte) (*rsmt2d.ExtendedDataSquare, error) {
 	// Note: uses the nmt wrapper to construct the tree.
 	return rsmt2d.ComputeExtendedDataSquare(s, appconsts.DefaultCodec(), NewConstructor(uint64(squareSize)))
 }
+
+// ExtendSharesWithBadEncoding extends the shares like da.ExtendShares but
+// corrupts the last share of the first row, which is a parity share, before
+// computing the roots. The first row and the last column of the returned
+// extended data square are therefore badly encoded.
+func ExtendSharesWithBadEncoding(s [][]byte) (*rsmt2d.ExtendedDataSquare, error) {
+	eds, err := da.ExtendShares(s)
+	if err != nil {
+		return nil, err
+	}
+	corrupted := eds.Flattened()
+	width := int(eds.Width())
+	badShare := append([]byte{}, corrupted[width-1]...)
+	for i := appconsts.NamespaceSize; i < len(badShare); i++ {
+		badShare[i] ^= 0xFF
+	}
+	corrupted[width-1] = badShare
+	return rsmt2d.ImportExtendedDataSquare(corrupted, appconsts.DefaultCodec(), wrapper.NewConstructor(uint64(width/2)))
+}
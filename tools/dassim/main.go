@@ -0,0 +1,198 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/celestiaorg/celestia-app/app"
+	"github.com/celestiaorg/celestia-app/cmd/celestia-appd/cmd"
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	"github.com/celestiaorg/celestia-app/pkg/da"
+	"github.com/celestiaorg/celestia-app/pkg/das"
+	appns "github.com/celestiaorg/go-square/namespace"
+	"github.com/celestiaorg/rsmt2d"
+	dbm "github.com/cometbft/cometbft-db"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	coretypes "github.com/tendermint/tendermint/rpc/core/types"
+	"github.com/tendermint/tendermint/store"
+)
+
+func main() {
+	if err := Run(); err != nil {
+		fmt.Printf("ERROR: %s\n", err.Error())
+		os.Exit(1)
+	}
+}
+
+func Run() error {
+	squareSize := flag.Int("square-size", appconsts.DefaultSquareSizeUpperBound, "width of the original data square, must be a power of 2")
+	clients := flag.Int("clients", 100, "number of light clients")
+	samples := flag.Int("samples", 16, "number of distinct shares each light client samples")
+	strategy := flag.String("strategy", "minimal", "withholding strategy: none, minimal or random")
+	probability := flag.Float64("probability", 0.25, "probability that a share is withheld by the random strategy")
+	seed := flag.Int64("seed", 1, "seed of the randomness of the simulation")
+	blockFile := flag.String("block", "", "JSON file of a block, as returned by the block RPC endpoint, whose extended data square is sampled instead of random shares")
+	maxSquareSize := flag.Int("max-square-size", 0, "square size upper bound the block of the -block file was proposed with, defaults to the upper bound of its app version")
+	home := flag.String("home", "", "home directory of a stopped node whose block at -height is sampled instead of random shares")
+	height := flag.Int64("height", 0, "height of the block in the block store of the -home node")
+	dbBackend := flag.String("db-backend", string(dbm.GoLevelDBBackend), "db_backend in the config.toml of the -home node, which the block store is opened with")
+	appDBBackend := flag.String("app-db-backend", "", "app-db-backend in the app.toml of the -home node, which the square size index is opened with, defaults to -db-backend")
+	flag.Parse()
+
+	var withhold das.Strategy
+	switch *strategy {
+	case "none":
+		withhold = das.WithholdNone
+	case "minimal":
+		withhold = das.WithholdMinimal
+	case "random":
+		withhold = das.WithholdRandom(*probability)
+	default:
+		return fmt.Errorf("unknown strategy %q", *strategy)
+	}
+
+	var (
+		eds *rsmt2d.ExtendedDataSquare
+		err error
+	)
+	switch {
+	case *blockFile != "" && *home != "":
+		return errors.New("only one of -block and -home can be set")
+	case *blockFile != "":
+		eds, err = blockFileEDS(*blockFile, *maxSquareSize)
+	case *home != "":
+		if *appDBBackend == "" {
+			*appDBBackend = *dbBackend
+		}
+		eds, err = blockStoreEDS(*home, *height, dbm.BackendType(*dbBackend), dbm.BackendType(*appDBBackend))
+	default:
+		rng := rand.New(rand.NewSource(*seed)) //nolint:gosec
+		eds, err = da.ExtendShares(randomShares(rng, *squareSize**squareSize))
+	}
+	if err != nil {
+		return err
+	}
+	result, err := das.Simulate(eds, das.Config{
+		Clients:  *clients,
+		Samples:  *samples,
+		Strategy: withhold,
+		Seed:     *seed,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf(`
+Extended square width: %d
+Withheld shares: %d of %d (%s strategy)
+Recoverable: %t
+Light clients: %d sampling %d shares each
+Detection:
+	Simulated: %d clients (%.4f)
+	Expected: %.6f
+Bandwidth:
+	Total: %d bytes
+	Per client: %.0f bytes
+
+`, result.Width,
+		result.Withheld,
+		result.Width*result.Width,
+		*strategy,
+		result.Recoverable,
+		*clients,
+		*samples,
+		result.Detected,
+		result.DetectionProbability,
+		result.ExpectedDetectionProbability,
+		result.Bytes,
+		result.BytesPerClient,
+	)
+	return nil
+}
+
+// randomShares returns the number of shares of random namespaces and data in
+// namespace order.
+func randomShares(rng *rand.Rand, count int) [][]byte {
+	shares := make([][]byte, count)
+	for i := range shares {
+		id := make([]byte, appns.NamespaceVersionZeroIDSize)
+		rng.Read(id)
+		share := append(appns.MustNewV0(id).Bytes(), make([]byte, appconsts.ShareSize-appconsts.NamespaceSize)...)
+		rng.Read(share[appconsts.NamespaceSize:])
+		shares[i] = share
+	}
+	sort.Slice(shares, func(i, j int) bool {
+		return bytes.Compare(shares[i], shares[j]) < 0
+	})
+	return shares
+}
+
+// blockFileEDS extends the data square of the block in the JSON file, which is
+// either the response of the block RPC endpoint or its result. The square is
+// constructed with the square size upper bound, or the one of the app version
+// of the block if it is zero.
+func blockFileEDS(path string, maxSquareSize int) (*rsmt2d.ExtendedDataSquare, error) {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var resp struct {
+		Result json.RawMessage `json:"result"`
+	}
+	if err := json.Unmarshal(bz, &resp); err != nil {
+		return nil, fmt.Errorf("error decoding %s: %w", path, err)
+	}
+	if len(resp.Result) != 0 {
+		bz = resp.Result
+	}
+	var res coretypes.ResultBlock
+	if err := tmjson.Unmarshal(bz, &res); err != nil {
+		return nil, fmt.Errorf("error decoding the block in %s: %w", path, err)
+	}
+	if res.Block == nil {
+		return nil, fmt.Errorf("no block in %s", path)
+	}
+
+	appVersion := res.Block.Header.Version.App
+	if maxSquareSize == 0 {
+		maxSquareSize = appconsts.SquareSizeUpperBound(appVersion)
+	}
+	return app.ExtendBlockWithSquareSize(res.Block.Data, maxSquareSize, appVersion)
+}
+
+// blockStoreEDS extends the data square of the block at the height of the block
+// store of the node in the home directory, with the square size upper bound
+// indexed by the node. The block store and the square size index are opened
+// with the backends of the node, see cmd.OpenNodeDB, so the node must be
+// stopped.
+func blockStoreEDS(home string, height int64, backend, appBackend dbm.BackendType) (*rsmt2d.ExtendedDataSquare, error) {
+	dir := filepath.Join(home, "data")
+	blockStoreDB, err := cmd.OpenNodeDB("blockstore", backend, dir)
+	if err != nil {
+		return nil, err
+	}
+	defer blockStoreDB.Close()
+
+	blockStore := store.NewBlockStore(blockStoreDB)
+	block := blockStore.LoadBlock(height)
+	if block == nil {
+		return nil, fmt.Errorf("block %d is not in the block store, which has the blocks %d to %d", height, blockStore.Base(), blockStore.Height())
+	}
+
+	indexDB, err := cmd.OpenNodeDB(app.SquareSizeIndexDBName, appBackend, dir)
+	if err != nil {
+		return nil, err
+	}
+	defer indexDB.Close()
+
+	appVersion := block.Header.Version.App
+	maxSquareSize := app.SquareSizeUpperBoundFromDB(indexDB, height, appVersion)
+	return app.ExtendBlockWithSquareSize(block.Data, maxSquareSize, appVersion)
+}
@@ -0,0 +1,43 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/celestiaorg/celestia-app/pkg/user"
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+)
+
+// CmdBroadcastBlobTx returns the command that broadcasts a signed BlobTx read
+// from a file.
+func CmdBroadcastBlobTx() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "broadcast-blob-tx [file]",
+		Example: "celestia-appd tx blob broadcast-blob-tx blobtx.json --node tcp://localhost:26657",
+		Short:   "Broadcast a signed BlobTx read from a file.",
+		Long: `Broadcast a signed BlobTx written by pay-for-blob with --output-document or by
+combine-multisig-pay-for-blob. Both the JSON and the binary format are accepted.
+		`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			blobTx, err := user.ReadBlobTxFile(clientCtx.TxConfig, args[0])
+			if err != nil {
+				return err
+			}
+
+			res, err := clientCtx.BroadcastTx(blobTx)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
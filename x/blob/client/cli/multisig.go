@@ -0,0 +1,240 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	"github.com/celestiaorg/celestia-app/pkg/user"
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	sdktx "github.com/cosmos/cosmos-sdk/client/tx"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+)
+
+// CmdMultisigPayForBlob returns the command that generates an unsigned
+// PayForBlobs transaction of a multisig account.
+func CmdMultisigPayForBlob() *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "multisig-pay-for-blob [namespaceID blob]",
+		Example: "celestia-appd tx blob multisig-pay-for-blob 0x00010203040506070809 0x48656c6c6f2c20576f726c6421 \\\n" +
+			"\t--from multisig \\\n" +
+			"\t--chain-id private \\\n" +
+			"\t--gas 100000 \\\n" +
+			"\t--fees 21000utia \\\n" +
+			"\t--output-document pfb.json \n",
+		Short: "Generate an unsigned PayForBlobs transaction of a multisig account.",
+		Long: `Generate an unsigned PayForBlobs transaction of the multisig account passed with --from.
+The blobs are provided in the same way as for pay-for-blob. The generated document
+contains the transaction together with its blobs, chain ID, account number and
+sequence so that the members of the multisig can sign it offline with
+sign-multisig-pay-for-blob. The signatures are combined with
+combine-multisig-pay-for-blob. Pass --offline together with --account-number and
+--sequence to generate the document without connecting to a node.
+		`,
+		Args: validateBlobArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			blobs, err := getBlobs(cmd, args)
+			if err != nil {
+				return err
+			}
+
+			record, err := clientCtx.Keyring.KeyByAddress(clientCtx.FromAddress)
+			if err != nil {
+				return err
+			}
+			pubKey, err := record.GetPubKey()
+			if err != nil {
+				return err
+			}
+
+			txf := sdktx.NewFactoryCLI(clientCtx, cmd.Flags())
+			if !clientCtx.Offline {
+				txf, err = txf.Prepare(clientCtx)
+				if err != nil {
+					return err
+				}
+			}
+
+			pfb, err := user.NewMultisigPayForBlob(
+				clientCtx.TxConfig,
+				pubKey,
+				clientCtx.ChainID,
+				txf.AccountNumber(),
+				txf.Sequence(),
+				appconsts.LatestVersion,
+				blobs,
+				user.SetGasLimit(txf.Gas()),
+				user.SetFeeAmount(factoryFees(txf)),
+				user.SetMemo(txf.Memo()),
+				user.SetTimeoutHeight(txf.TimeoutHeight()),
+			)
+			if err != nil {
+				return err
+			}
+
+			bz, err := user.MarshalMultisigPayForBlob(pfb)
+			if err != nil {
+				return err
+			}
+			return writeOutput(cmd, clientCtx, bz)
+		},
+	}
+
+	flags.AddTxFlagsToCmd(cmd)
+	cmd.PersistentFlags().Uint8(FlagNamespaceVersion, 0, "Specify the namespace version (default 0)")
+	cmd.PersistentFlags().Uint8(FlagShareVersion, 0, "Specify the share version (default 0)")
+	cmd.PersistentFlags().String(FlagFileInput, "", "Specify the file input")
+	cmd.Flags().String(flags.FlagOutputDocument, "", "The document is written to the given file instead of STDOUT")
+	_ = cmd.MarkFlagRequired(flags.FlagFrom)
+	return cmd
+}
+
+// CmdSignMultisigPayForBlob returns the command that signs a PayForBlobs
+// transaction generated by multisig-pay-for-blob with the key of one member
+// of the multisig.
+func CmdSignMultisigPayForBlob() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sign-multisig-pay-for-blob [file]",
+		Short: "Sign a PayForBlobs transaction of a multisig account as one of its members.",
+		Long: `Sign a PayForBlobs transaction generated by multisig-pay-for-blob with the key of
+the member passed with --from. The partial signature is printed or written to
+--output-document. Signing doesn't require a connection to a node.
+		`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			pfb, err := readMultisigPayForBlob(clientCtx, args[0])
+			if err != nil {
+				return err
+			}
+
+			sig, err := pfb.Sign(user.NewKeyringBackend(clientCtx.Keyring), clientCtx.FromAddress)
+			if err != nil {
+				return err
+			}
+
+			bz, err := clientCtx.TxConfig.MarshalSignatureJSON([]signing.SignatureV2{sig})
+			if err != nil {
+				return err
+			}
+			return writeOutput(cmd, clientCtx, bz)
+		},
+	}
+
+	flags.AddTxFlagsToCmd(cmd)
+	cmd.Flags().String(flags.FlagOutputDocument, "", "The document is written to the given file instead of STDOUT")
+	_ = cmd.MarkFlagRequired(flags.FlagFrom)
+	return cmd
+}
+
+// CmdCombineMultisigPayForBlob returns the command that combines the partial
+// signatures of the members of a multisig into a signed BlobTx.
+func CmdCombineMultisigPayForBlob() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "combine-multisig-pay-for-blob [file] [signature files...]",
+		Short: "Combine the signatures of a multisig PayForBlobs transaction and broadcast it.",
+		Long: `Combine the partial signatures created by sign-multisig-pay-for-blob into the
+signature of the multisig and broadcast the resulting BlobTx. At least the threshold
+number of signatures is required. If --output-document is set, the BlobTx is written
+to the file instead of being broadcast.
+		`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			pfb, err := readMultisigPayForBlob(clientCtx, args[0])
+			if err != nil {
+				return err
+			}
+
+			var sigs []signing.SignatureV2
+			for _, path := range args[1:] {
+				bz, err := os.ReadFile(path)
+				if err != nil {
+					return err
+				}
+				fileSigs, err := clientCtx.TxConfig.UnmarshalSignatureJSON(bz)
+				if err != nil {
+					return fmt.Errorf("error decoding signatures in %s: %w", path, err)
+				}
+				sigs = append(sigs, fileSigs...)
+			}
+
+			blobTx, err := pfb.Combine(sigs...)
+			if err != nil {
+				return err
+			}
+
+			output, err := cmd.Flags().GetString(flags.FlagOutputDocument)
+			if err != nil {
+				return err
+			}
+			if output != "" {
+				return os.WriteFile(output, blobTx, 0o600)
+			}
+
+			res, err := clientCtx.BroadcastTx(blobTx)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddTxFlagsToCmd(cmd)
+	cmd.Flags().String(flags.FlagOutputDocument, "", "The BlobTx is written to the given file instead of being broadcast")
+	return cmd
+}
+
+// readMultisigPayForBlob reads a PayForBlobs transaction generated by
+// multisig-pay-for-blob from the file.
+func readMultisigPayForBlob(clientCtx client.Context, path string) (*user.MultisigPayForBlob, error) {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return user.UnmarshalMultisigPayForBlob(clientCtx.TxConfig, bz)
+}
+
+// factoryFees returns the fees set in the factory. If only gas prices are set,
+// the fees are derived from the gas prices and the gas limit.
+func factoryFees(txf sdktx.Factory) sdk.Coins {
+	if !txf.Fees().IsZero() || txf.GasPrices().IsZero() {
+		return txf.Fees()
+	}
+	gasLimit := sdk.NewDec(int64(txf.Gas()))
+	fees := make(sdk.Coins, len(txf.GasPrices()))
+	for i, gasPrice := range txf.GasPrices() {
+		fees[i] = sdk.NewCoin(gasPrice.Denom, gasPrice.Amount.Mul(gasLimit).Ceil().RoundInt())
+	}
+	return fees
+}
+
+// writeOutput writes bz to the file passed with FlagOutputDocument or prints
+// it if the flag is not set.
+func writeOutput(cmd *cobra.Command, clientCtx client.Context, bz []byte) error {
+	output, err := cmd.Flags().GetString(flags.FlagOutputDocument)
+	if err != nil {
+		return err
+	}
+	if output == "" {
+		return clientCtx.PrintBytes(bz)
+	}
+	return os.WriteFile(output, bz, 0o600)
+}
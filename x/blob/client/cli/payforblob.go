@@ -12,6 +12,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	"github.com/celestiaorg/celestia-app/pkg/user"
 	"github.com/celestiaorg/celestia-app/x/blob/types"
 	"github.com/celestiaorg/go-square/blob"
 	appns "github.com/celestiaorg/go-square/namespace"
@@ -38,6 +39,15 @@ const (
 	// FileInputExtension is the only file extension supported for
 	// FlagFileInput.
 	FileInputExtension = ".json"
+
+	// FlagOutputFormat allows the user to choose the encoding of the BlobTx
+	// written to the file passed with the output-document flag.
+	FlagOutputFormat = "output-format"
+
+	// OutputFormatJSON and OutputFormatBinary are the values supported for
+	// FlagOutputFormat.
+	OutputFormatJSON   = "json"
+	OutputFormatBinary = "binary"
 )
 
 func CmdPayForBlob() *cobra.Command {
@@ -77,78 +87,100 @@ The JSON should look like:
 The namespaceID is the user-specifiable portion of a version 0 namespace.
 The namespaceID must be a hex encoded string of 10 bytes.
 The blob must be a hex encoded string of non-zero length.
+
+To sign the PayForBlobs without broadcasting it, use --output-document to write the
+signed BlobTx to a file in the format set by --output-format. The file can later
+be submitted with broadcast-blob-tx. Together with --offline, --account-number and
+--sequence, the BlobTx is created without connecting to a node.
 		`,
 		Aliases: []string{"pay-for-blobs", "PayForBlobs", "PayForBlob"},
-		Args: func(cmd *cobra.Command, args []string) error {
-			path, err := cmd.Flags().GetString(FlagFileInput)
+		Args:    validateBlobArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			blobs, err := getBlobs(cmd, args)
 			if err != nil {
 				return err
 			}
 
-			if path != "" {
-				if filepath.Ext(path) != FileInputExtension {
-					return fmt.Errorf("invalid file extension %v. The only supported extension is %s", filepath.Ext(path), FileInputExtension)
-				}
+			return broadcastPFB(cmd, blobs...)
+		},
+	}
 
-				return nil
-			}
+	flags.AddTxFlagsToCmd(cmd)
+	cmd.PersistentFlags().Uint8(FlagNamespaceVersion, 0, "Specify the namespace version (default 0)")
+	cmd.PersistentFlags().Uint8(FlagShareVersion, 0, "Specify the share version (default 0)")
+	cmd.PersistentFlags().String(FlagFileInput, "", "Specify the file input")
+	cmd.Flags().String(flags.FlagOutputDocument, "", "The signed BlobTx is written to the given file instead of being broadcast")
+	cmd.Flags().String(FlagOutputFormat, OutputFormatJSON, fmt.Sprintf("The encoding of the output document (%s|%s)", OutputFormatJSON, OutputFormatBinary))
+	_ = cmd.MarkFlagRequired(flags.FlagFrom)
+	return cmd
+}
 
-			if len(args) < 2 {
-				return fmt.Errorf("pay-for-blob requires two arguments if %s isn't provided: namespaceID and blob", FlagFileInput)
-			}
+// validateBlobArgs checks that either the namespaceID and blob arguments or
+// the path to a JSON file with the FlagFileInput flag are provided.
+func validateBlobArgs(cmd *cobra.Command, args []string) error {
+	path, err := cmd.Flags().GetString(FlagFileInput)
+	if err != nil {
+		return err
+	}
 
-			return nil
-		},
-		RunE: func(cmd *cobra.Command, args []string) error {
-			namespaceVersion, err := cmd.Flags().GetUint8(FlagNamespaceVersion)
-			if err != nil {
-				return err
-			}
+	if path != "" {
+		if filepath.Ext(path) != FileInputExtension {
+			return fmt.Errorf("invalid file extension %v. The only supported extension is %s", filepath.Ext(path), FileInputExtension)
+		}
 
-			shareVersion, err := cmd.Flags().GetUint8(FlagShareVersion)
-			if err != nil {
-				return err
-			}
+		return nil
+	}
 
-			path, err := cmd.Flags().GetString(FlagFileInput)
-			if err != nil {
-				return err
-			}
+	if len(args) < 2 {
+		return fmt.Errorf("%s requires two arguments if %s isn't provided: namespaceID and blob", cmd.Name(), FlagFileInput)
+	}
 
-			// In case of no file input, get the namespaceID and blob from the arguments
-			if path == "" {
-				blob, err := getBlobFromArguments(args[0], args[1], namespaceVersion, shareVersion)
-				if err != nil {
-					return err
-				}
+	return nil
+}
 
-				return broadcastPFB(cmd, blob)
-			}
+// getBlobs returns the blobs provided either as the namespaceID and blob
+// arguments or in the JSON file passed with the FlagFileInput flag.
+func getBlobs(cmd *cobra.Command, args []string) ([]*blob.Blob, error) {
+	namespaceVersion, err := cmd.Flags().GetUint8(FlagNamespaceVersion)
+	if err != nil {
+		return nil, err
+	}
 
-			paresdBlobs, err := parseSubmitBlobs(path)
-			if err != nil {
-				return err
-			}
+	shareVersion, err := cmd.Flags().GetUint8(FlagShareVersion)
+	if err != nil {
+		return nil, err
+	}
 
-			var blobs []*blob.Blob
-			for _, paresdBlob := range paresdBlobs {
-				blob, err := getBlobFromArguments(paresdBlob.NamespaceID, paresdBlob.Blob, namespaceVersion, shareVersion)
-				if err != nil {
-					return err
-				}
-				blobs = append(blobs, blob)
-			}
+	path, err := cmd.Flags().GetString(FlagFileInput)
+	if err != nil {
+		return nil, err
+	}
 
-			return broadcastPFB(cmd, blobs...)
-		},
+	// In case of no file input, get the namespaceID and blob from the arguments
+	if path == "" {
+		b, err := getBlobFromArguments(args[0], args[1], namespaceVersion, shareVersion)
+		if err != nil {
+			return nil, err
+		}
+
+		return []*blob.Blob{b}, nil
 	}
 
-	flags.AddTxFlagsToCmd(cmd)
-	cmd.PersistentFlags().Uint8(FlagNamespaceVersion, 0, "Specify the namespace version (default 0)")
-	cmd.PersistentFlags().Uint8(FlagShareVersion, 0, "Specify the share version (default 0)")
-	cmd.PersistentFlags().String(FlagFileInput, "", "Specify the file input")
-	_ = cmd.MarkFlagRequired(flags.FlagFrom)
-	return cmd
+	paresdBlobs, err := parseSubmitBlobs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var blobs []*blob.Blob
+	for _, paresdBlob := range paresdBlobs {
+		b, err := getBlobFromArguments(paresdBlob.NamespaceID, paresdBlob.Blob, namespaceVersion, shareVersion)
+		if err != nil {
+			return nil, err
+		}
+		blobs = append(blobs, b)
+	}
+
+	return blobs, nil
 }
 
 func getBlobFromArguments(namespaceIDArg, blobArg string, namespaceVersion, shareVersion uint8) (*blob.Blob, error) {
@@ -214,11 +246,31 @@ func broadcastPFB(cmd *cobra.Command, b ...*blob.Blob) error {
 		return err
 	}
 
+	// the tx was only simulated, printed or cancelled
+	if txBytes == nil {
+		return nil
+	}
+
 	blobTx, err := blob.MarshalBlobTx(txBytes, b...)
 	if err != nil {
 		return err
 	}
 
+	output, err := cmd.Flags().GetString(flags.FlagOutputDocument)
+	if err != nil {
+		return err
+	}
+	if output != "" {
+		format, err := getOutputFormat(cmd)
+		if err != nil {
+			return err
+		}
+		return user.WriteBlobTxFile(clientCtx.TxConfig, output, blobTx, format)
+	}
+	if clientCtx.Offline {
+		return fmt.Errorf("--%s is required in offline mode", flags.FlagOutputDocument)
+	}
+
 	// broadcast to a Tendermint node
 	res, err := clientCtx.BroadcastTx(blobTx)
 	if err != nil {
@@ -239,9 +291,13 @@ func writeTx(clientCtx client.Context, txf sdktx.Factory, msgs ...sdk.Msg) ([]by
 		return nil, txf.PrintUnsignedTx(clientCtx, msgs...)
 	}
 
-	txf, err := txf.Prepare(clientCtx)
-	if err != nil {
-		return nil, err
+	// the account number and sequence are provided with flags in offline mode
+	if !clientCtx.Offline {
+		var err error
+		txf, err = txf.Prepare(clientCtx)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	if txf.SimulateAndExecute() || clientCtx.Simulate {
@@ -289,3 +345,19 @@ func writeTx(clientCtx client.Context, txf sdktx.Factory, msgs ...sdk.Msg) ([]by
 
 	return clientCtx.TxConfig.TxEncoder()(tx.GetTx())
 }
+
+// getOutputFormat returns the BlobTx format set with FlagOutputFormat.
+func getOutputFormat(cmd *cobra.Command) (user.BlobTxFormat, error) {
+	format, err := cmd.Flags().GetString(FlagOutputFormat)
+	if err != nil {
+		return 0, err
+	}
+	switch format {
+	case OutputFormatJSON:
+		return user.BlobTxFormatJSON, nil
+	case OutputFormatBinary:
+		return user.BlobTxFormatBinary, nil
+	default:
+		return 0, fmt.Errorf("unsupported output format %q, must be %s or %s", format, OutputFormatJSON, OutputFormatBinary)
+	}
+}
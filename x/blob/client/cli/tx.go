@@ -18,7 +18,13 @@ func GetTxCmd() *cobra.Command {
 		RunE:                       client.ValidateCmd,
 	}
 
-	cmd.AddCommand(CmdPayForBlob())
+	cmd.AddCommand(
+		CmdPayForBlob(),
+		CmdMultisigPayForBlob(),
+		CmdSignMultisigPayForBlob(),
+		CmdCombineMultisigPayForBlob(),
+		CmdBroadcastBlobTx(),
+	)
 
 	return cmd
 }
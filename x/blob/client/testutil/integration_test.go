@@ -4,11 +4,15 @@ import (
 	"encoding/hex"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strconv"
 	"testing"
 
 	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/multisig"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
 	"github.com/gogo/protobuf/proto"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
@@ -16,6 +20,7 @@ import (
 	clitestutil "github.com/cosmos/cosmos-sdk/testutil/cli"
 	cosmosnet "github.com/cosmos/cosmos-sdk/testutil/network"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	bankcli "github.com/cosmos/cosmos-sdk/x/bank/client/cli"
 
 	"github.com/celestiaorg/celestia-app/x/blob/types"
 
@@ -197,6 +202,126 @@ func (s *IntegrationTestSuite) TestSubmitPayForBlob() {
 	}
 }
 
+func (s *IntegrationTestSuite) TestMultisigPayForBlob() {
+	require := s.Require()
+	validator := s.network.Validators[0]
+	clientCtx := validator.ClientCtx
+	dir := s.T().TempDir()
+
+	// create a 2 of 3 multisig in the keyring of the validator
+	pubKeys := make([]cryptotypes.PubKey, 3)
+	for i := range pubKeys {
+		record, _, err := s.kr.NewMnemonic(fmt.Sprintf("multisig-member-%d", i), keyring.English, sdk.FullFundraiserPath, keyring.DefaultBIP39Passphrase, hd.Secp256k1)
+		require.NoError(err)
+		pubKeys[i], err = record.GetPubKey()
+		require.NoError(err)
+	}
+	record, err := s.kr.SaveMultisig("multisig", multisig.NewLegacyAminoPubKey(2, pubKeys))
+	require.NoError(err)
+	multisigAddress, err := record.GetAddress()
+	require.NoError(err)
+
+	fees := fmt.Sprintf("--%s=%s", flags.FlagFees, sdk.NewCoins(sdk.NewCoin(s.cfg.BondDenom, sdk.NewInt(1000))).String())
+	_, err = clitestutil.ExecTestCLICmd(clientCtx, bankcli.NewSendTxCmd(), []string{
+		username,
+		multisigAddress.String(),
+		sdk.NewCoins(sdk.NewCoin(s.cfg.BondDenom, sdk.NewInt(1e6))).String(),
+		fmt.Sprintf("--%s=%s", flags.FlagBroadcastMode, flags.BroadcastBlock),
+		fmt.Sprintf("--%s=true", flags.FlagSkipConfirmation),
+		fees,
+	})
+	require.NoError(err)
+	require.NoError(s.network.WaitForNextBlock())
+
+	pfbFile := filepath.Join(dir, "pfb.json")
+	out, err := clitestutil.ExecTestCLICmd(clientCtx, paycli.CmdMultisigPayForBlob(), []string{
+		hex.EncodeToString(appns.RandomBlobNamespaceID()),
+		"0204033704032c0b162109000908094d425837422c2116",
+		"--from=multisig",
+		fmt.Sprintf("--%s=200000", flags.FlagGas),
+		fees,
+		fmt.Sprintf("--%s=%s", flags.FlagOutputDocument, pfbFile),
+	})
+	require.NoError(err, out.String())
+
+	// two of the members sign the PFB
+	sigFiles := make([]string, 2)
+	for i := range sigFiles {
+		sigFiles[i] = filepath.Join(dir, fmt.Sprintf("signature-%d.json", i))
+		out, err := clitestutil.ExecTestCLICmd(clientCtx, paycli.CmdSignMultisigPayForBlob(), []string{
+			pfbFile,
+			fmt.Sprintf("--from=multisig-member-%d", i),
+			fmt.Sprintf("--%s=%s", flags.FlagOutputDocument, sigFiles[i]),
+		})
+		require.NoError(err, out.String())
+	}
+
+	// a single signature doesn't meet the threshold
+	_, err = clitestutil.ExecTestCLICmd(clientCtx, paycli.CmdCombineMultisigPayForBlob(), []string{pfbFile, sigFiles[0]})
+	require.Error(err)
+
+	out, err = clitestutil.ExecTestCLICmd(clientCtx, paycli.CmdCombineMultisigPayForBlob(), []string{
+		pfbFile,
+		sigFiles[0],
+		sigFiles[1],
+		fmt.Sprintf("--%s=%s", flags.FlagBroadcastMode, flags.BroadcastBlock),
+	})
+	require.NoError(err, out.String())
+	var txResp sdk.TxResponse
+	require.NoError(clientCtx.Codec.UnmarshalJSON(out.Bytes(), &txResp), out.String())
+	require.Equal(abci.CodeTypeOK, txResp.Code, txResp.RawLog)
+}
+
+func (s *IntegrationTestSuite) TestOfflinePayForBlob() {
+	require := s.Require()
+	validator := s.network.Validators[0]
+	clientCtx := validator.ClientCtx
+	dir := s.T().TempDir()
+
+	record, err := s.kr.Key(username)
+	require.NoError(err)
+	address, err := record.GetAddress()
+	require.NoError(err)
+
+	fees := fmt.Sprintf("--%s=%s", flags.FlagFees, sdk.NewCoins(sdk.NewCoin(s.cfg.BondDenom, sdk.NewInt(1000))).String())
+	for _, format := range []string{paycli.OutputFormatJSON, paycli.OutputFormatBinary} {
+		accNum, seq, err := clientCtx.AccountRetriever.GetAccountNumberSequence(clientCtx, address)
+		require.NoError(err)
+
+		blobTxFile := filepath.Join(dir, "blobtx."+format)
+		args := []string{
+			hex.EncodeToString(appns.RandomBlobNamespaceID()),
+			"0204033704032c0b162109000908094d425837422c2116",
+			fmt.Sprintf("--from=%s", username),
+			fmt.Sprintf("--%s=true", flags.FlagOffline),
+			fmt.Sprintf("--%s=%d", flags.FlagAccountNumber, accNum),
+			fmt.Sprintf("--%s=%d", flags.FlagSequence, seq),
+			fmt.Sprintf("--%s=200000", flags.FlagGas),
+			fmt.Sprintf("--%s=true", flags.FlagSkipConfirmation),
+			fees,
+		}
+
+		// the BlobTx can't be broadcast in offline mode
+		_, err = clitestutil.ExecTestCLICmd(clientCtx, paycli.CmdPayForBlob(), args)
+		require.Error(err)
+
+		out, err := clitestutil.ExecTestCLICmd(clientCtx, paycli.CmdPayForBlob(), append(args,
+			fmt.Sprintf("--%s=%s", flags.FlagOutputDocument, blobTxFile),
+			fmt.Sprintf("--%s=%s", paycli.FlagOutputFormat, format),
+		))
+		require.NoError(err, out.String())
+
+		out, err = clitestutil.ExecTestCLICmd(clientCtx, paycli.CmdBroadcastBlobTx(), []string{
+			blobTxFile,
+			fmt.Sprintf("--%s=%s", flags.FlagBroadcastMode, flags.BroadcastBlock),
+		})
+		require.NoError(err, out.String())
+		var txResp sdk.TxResponse
+		require.NoError(clientCtx.Codec.UnmarshalJSON(out.Bytes(), &txResp), out.String())
+		require.Equal(abci.CodeTypeOK, txResp.Code, txResp.RawLog)
+	}
+}
+
 func TestIntegrationTestSuite(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping integration test in short mode.")
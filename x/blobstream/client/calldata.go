@@ -0,0 +1,200 @@
+package client
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+	tmlog "github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/rpc/client/http"
+)
+
+const (
+	selectorFlag = "selector"
+	bridgeFlag   = "bridge"
+)
+
+func CalldataCmd() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "calldata",
+		Short: "Prints the ABI encoded proof that a range of shares or a blob was committed to by the Blobstream contract, as taken by DAVerifier.verifySharesToDataRootTupleRoot",
+	}
+	command.AddCommand(
+		calldataSharesCmd(),
+		calldataBlobCmd(),
+	)
+	return command
+}
+
+func calldataSharesCmd() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "shares <height> <start_share> <end_share>",
+		Args:  cobra.ExactArgs(3),
+		Short: "Prints the ABI encoded proof of a range of shares. The range should be end exclusive.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			height, err := strconv.ParseUint(args[0], 10, 0)
+			if err != nil {
+				return err
+			}
+			startShare, err := strconv.ParseUint(args[1], 10, 0)
+			if err != nil {
+				return err
+			}
+			endShare, err := strconv.ParseUint(args[2], 10, 0)
+			if err != nil {
+				return err
+			}
+
+			config, err := parseCalldataFlags(cmd)
+			if err != nil {
+				return err
+			}
+
+			logger := tmlog.NewTMLogger(os.Stderr)
+			calldata, err := SharesProofCalldata(cmd.Context(), logger, config, height, startShare, endShare)
+			if err != nil {
+				return err
+			}
+			return printCalldata(cmd, config, calldata)
+		},
+	}
+	return addCalldataFlags(command)
+}
+
+func calldataBlobCmd() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "blob <tx_hash> <blob_index>",
+		Args:  cobra.ExactArgs(2),
+		Short: "Prints the ABI encoded proof of a blob, referenced by its transaction hash, in hex format",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txHash, err := hex.DecodeString(args[0])
+			if err != nil {
+				return err
+			}
+			blobIndex, err := strconv.ParseUint(args[1], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			config, err := parseCalldataFlags(cmd)
+			if err != nil {
+				return err
+			}
+
+			logger := tmlog.NewTMLogger(os.Stderr)
+
+			trpc, err := http.New(config.TendermintRPC, "/websocket")
+			if err != nil {
+				return err
+			}
+			height, blobShareRange, err := queryBlobShareRange(cmd.Context(), trpc, txHash, blobIndex)
+			if err != nil {
+				return err
+			}
+
+			calldata, err := SharesProofCalldata(cmd.Context(), logger, config, uint64(height), uint64(blobShareRange.Start), uint64(blobShareRange.End))
+			if err != nil {
+				return err
+			}
+			return printCalldata(cmd, config, calldata)
+		},
+	}
+	return addCalldataFlags(command)
+}
+
+func addCalldataFlags(cmd *cobra.Command) *cobra.Command {
+	cmd.Flags().StringP(flags.FlagNode, "t", "http://localhost:26657", "<host>:<port> to Tendermint RPC interface for this chain")
+	cmd.Flags().StringP(celesGRPCFlag, "c", "localhost:9090", "<host>:<port> To Celestia GRPC address")
+	cmd.Flags().StringP(selectorFlag, "s", "", "The hex encoded 4 byte selector of the contract function to prefix the calldata with. Requires the bridge address")
+	cmd.Flags().StringP(bridgeFlag, "b", "", "The hex encoded address of the Blobstream contract to prepend to the encoded arguments, as taken by DAVerifier.verifySharesToDataRootTupleRoot")
+	return cmd
+}
+
+type CalldataConfig struct {
+	CelesGRPC, TendermintRPC string
+	Selector                 []byte
+	// Bridge is the address of the Blobstream contract that the calldata is
+	// verified against. If nil, the calldata starts with the proof.
+	Bridge *ethcmn.Address
+}
+
+func parseCalldataFlags(cmd *cobra.Command) (CalldataConfig, error) {
+	tendermintRPC, err := cmd.Flags().GetString(flags.FlagNode)
+	if err != nil {
+		return CalldataConfig{}, err
+	}
+	celesGRPC, err := cmd.Flags().GetString(celesGRPCFlag)
+	if err != nil {
+		return CalldataConfig{}, err
+	}
+	rawSelector, err := cmd.Flags().GetString(selectorFlag)
+	if err != nil {
+		return CalldataConfig{}, err
+	}
+	var selector []byte
+	if rawSelector != "" {
+		selector, err = hex.DecodeString(strings.TrimPrefix(rawSelector, "0x"))
+		if err != nil {
+			return CalldataConfig{}, err
+		}
+		if len(selector) != 4 {
+			return CalldataConfig{}, fmt.Errorf("selector must be 4 bytes: %s", selectorFlag)
+		}
+	}
+	rawBridge, err := cmd.Flags().GetString(bridgeFlag)
+	if err != nil {
+		return CalldataConfig{}, err
+	}
+	var bridge *ethcmn.Address
+	if rawBridge != "" {
+		if !ethcmn.IsHexAddress(rawBridge) {
+			return CalldataConfig{}, fmt.Errorf("invalid bridge address: %s", rawBridge)
+		}
+		address := ethcmn.HexToAddress(rawBridge)
+		bridge = &address
+	}
+	if selector != nil && bridge == nil {
+		return CalldataConfig{}, fmt.Errorf("the %s flag requires the %s flag", selectorFlag, bridgeFlag)
+	}
+	return CalldataConfig{
+		CelesGRPC:     celesGRPC,
+		TendermintRPC: tendermintRPC,
+		Selector:      selector,
+		Bridge:        bridge,
+	}, nil
+}
+
+func printCalldata(cmd *cobra.Command, config CalldataConfig, calldata []byte) error {
+	_, err := fmt.Fprintf(cmd.OutOrStdout(), "0x%x\n", append(config.Selector, calldata...))
+	return err
+}
+
+// SharesProofCalldata returns the ABI encoding of the arguments of
+// DAVerifier.verifySharesToDataRootTupleRoot for the range of shares at the
+// height: the Blobstream contract, if config.Bridge is set, followed by the
+// SharesProof and the data root of the block.
+func SharesProofCalldata(ctx context.Context, logger tmlog.Logger, config CalldataConfig, height uint64, startShare uint64, endShare uint64) ([]byte, error) {
+	trpc, err := http.New(config.TendermintRPC, "/websocket")
+	if err != nil {
+		return nil, err
+	}
+
+	sharesProof, err := querySharesProof(ctx, logger, trpc, config.CelesGRPC, height, startShare, endShare)
+	if err != nil {
+		return nil, err
+	}
+	abiProof, err := sharesProof.ABI()
+	if err != nil {
+		return nil, err
+	}
+	if config.Bridge != nil {
+		return abiProof.EncodeWithBridge(*config.Bridge)
+	}
+	return abiProof.EncodeWithRoot()
+}
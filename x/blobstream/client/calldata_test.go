@@ -0,0 +1,104 @@
+package client_test
+
+import (
+	"context"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/celestiaorg/celestia-app/x/blobstream/client"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/rpc/client/http"
+)
+
+func (s *CLITestSuite) TestCalldataShares() {
+	_, err := s.network.WaitForHeight(402)
+	s.Require().NoError(err)
+	val := s.network.Validators[0]
+
+	const height = 10
+	bridge := ethcmn.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3")
+	selector := []byte{0xd6, 0x1f, 0x3d, 0x8b}
+
+	trpc, err := http.New(val.RPCAddress, "/websocket")
+	s.Require().NoError(err)
+	heightI := int64(height)
+	block, err := trpc.Block(context.Background(), &heightI)
+	s.Require().NoError(err)
+
+	testCases := []struct {
+		name      string
+		endShare  string
+		args      []string
+		expectErr bool
+		// check verifies the decoded calldata.
+		check func(t *testing.T, calldata []byte)
+	}{
+		{
+			name:     "proof and data root",
+			endShare: "1",
+			args:     []string{},
+			check: func(t *testing.T, calldata []byte) {
+				// the head holds the offset of the proof and the data root
+				require.Equal(t, ethcmn.LeftPadBytes([]byte{64}, 32), calldata[:32])
+				require.Equal(t, []byte(block.Block.DataHash), calldata[32:64])
+			},
+		},
+		{
+			name:     "selector and bridge",
+			endShare: "1",
+			args:     []string{"--selector", hex.EncodeToString(selector), "--bridge", bridge.Hex()},
+			check: func(t *testing.T, calldata []byte) {
+				require.Equal(t, selector, calldata[:4])
+				// the head holds the bridge, the offset of the proof and the
+				// data root
+				require.Equal(t, ethcmn.LeftPadBytes(bridge.Bytes(), 32), calldata[4:36])
+				require.Equal(t, ethcmn.LeftPadBytes([]byte{96}, 32), calldata[36:68])
+				require.Equal(t, []byte(block.Block.DataHash), calldata[68:100])
+			},
+		},
+		{
+			name:      "selector without bridge",
+			endShare:  "1",
+			args:      []string{"--selector", hex.EncodeToString(selector)},
+			expectErr: true,
+		},
+		{
+			name:      "invalid bridge",
+			endShare:  "1",
+			args:      []string{"--bridge", "0x1234"},
+			expectErr: true,
+		},
+		{
+			name:      "invalid share range",
+			endShare:  "100000",
+			args:      []string{},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			cmd := client.CalldataCmd()
+			var out strings.Builder
+			cmd.SetOut(&out)
+			cmd.SetArgs(append([]string{
+				"shares", strconv.Itoa(height), "0", tc.endShare,
+				"--node", val.RPCAddress,
+				"--celes-grpc", val.AppConfig.GRPC.Address,
+			}, tc.args...))
+
+			err := cmd.Execute()
+			if tc.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			calldata, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(out.String()), "0x"))
+			require.NoError(t, err)
+			tc.check(t, calldata)
+		})
+	}
+}
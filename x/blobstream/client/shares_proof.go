@@ -0,0 +1,175 @@
+package client
+
+import (
+	"context"
+	"errors"
+
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	"github.com/celestiaorg/celestia-app/pkg/proof"
+	"github.com/celestiaorg/celestia-app/x/blobstream/types"
+	"github.com/celestiaorg/go-square/merkle"
+	"github.com/celestiaorg/go-square/shares"
+	"github.com/celestiaorg/go-square/square"
+	tmlog "github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/rpc/client/http"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// errInvalidSharesProof is returned if the proof of the shares to the data
+// root returned by the node is invalid.
+var errInvalidSharesProof = errors.New("proofs from shares to data root are invalid")
+
+// sharesProof proves a range of shares to the data root tuple root committed
+// to by the Blobstream contract.
+type sharesProof struct {
+	height uint64
+	// shareProof proves the shares to the data root.
+	shareProof proof.ShareProof
+	// dataCommitment is the data commitment whose data root tuple root
+	// commits to the data root.
+	dataCommitment *types.DataCommitment
+	dataRoot       []byte
+	// dataRootProof proves the data root tuple of the height to the data root
+	// tuple root.
+	dataRootProof merkle.Proof
+}
+
+// querySharesProof queries the proof of the range of shares at the height to
+// the data root and the proof of the data root to the data root tuple root of
+// the data commitment that covers the height. It returns errInvalidSharesProof
+// if the proof of the shares is invalid.
+func querySharesProof(ctx context.Context, logger tmlog.Logger, trpc *http.HTTP, celesGRPC string, height, startShare, endShare uint64) (sharesProof, error) {
+	logger.Debug("getting shares proof from tendermint node", "height", height, "start_share", startShare, "end_share", endShare)
+	tmProof, err := trpc.ProveShares(ctx, height, startShare, endShare)
+	if err != nil {
+		return sharesProof{}, err
+	}
+
+	logger.Debug("verifying shares proofs")
+	// the shares proof is self verifiable because it also contains the row
+	// roots which the nmt shares proofs are verified against.
+	if !tmProof.VerifyProof() {
+		return sharesProof{}, errInvalidSharesProof
+	}
+
+	bsGRPC, err := grpc.Dial(celesGRPC, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return sharesProof{}, err
+	}
+	defer func(bsGRPC *grpc.ClientConn) {
+		err := bsGRPC.Close()
+		if err != nil {
+			logger.Debug("error closing connection", "err", err.Error())
+		}
+	}(bsGRPC)
+
+	resp, err := types.NewQueryClient(bsGRPC).DataCommitmentRangeForHeight(
+		ctx,
+		&types.QueryDataCommitmentRangeForHeightRequest{Height: height},
+	)
+	if err != nil {
+		return sharesProof{}, err
+	}
+
+	logger.Debug("getting the data root to commitment inclusion proof", "nonce", resp.DataCommitment.Nonce)
+	dcProof, err := trpc.DataRootInclusionProof(ctx, height, resp.DataCommitment.BeginBlock, resp.DataCommitment.EndBlock)
+	if err != nil {
+		return sharesProof{}, err
+	}
+
+	heightI := int64(height)
+	block, err := trpc.Block(ctx, &heightI)
+	if err != nil {
+		return sharesProof{}, err
+	}
+
+	// convert the tendermint share proof to its pkg/proof equivalent
+	rowProofs := make([]*proof.Proof, len(tmProof.RowProof.Proofs))
+	for i, p := range tmProof.RowProof.Proofs {
+		rowProofs[i] = &proof.Proof{
+			Total:    p.Total,
+			Index:    p.Index,
+			LeafHash: p.LeafHash,
+			Aunts:    p.Aunts,
+		}
+	}
+	rowRoots := make([][]byte, len(tmProof.RowProof.RowRoots))
+	for i, rowRoot := range tmProof.RowProof.RowRoots {
+		rowRoots[i] = rowRoot
+	}
+	nmtProofs := make([]*proof.NMTProof, len(tmProof.ShareProofs))
+	for i, p := range tmProof.ShareProofs {
+		nmtProofs[i] = &proof.NMTProof{
+			Start:    p.Start,
+			End:      p.End,
+			Nodes:    p.Nodes,
+			LeafHash: p.LeafHash,
+		}
+	}
+
+	return sharesProof{
+		height: height,
+		shareProof: proof.ShareProof{
+			Data:             tmProof.Data,
+			ShareProofs:      nmtProofs,
+			NamespaceId:      tmProof.NamespaceID,
+			NamespaceVersion: tmProof.NamespaceVersion,
+			RowProof: &proof.RowProof{
+				RowRoots: rowRoots,
+				Proofs:   rowProofs,
+				StartRow: tmProof.RowProof.StartRow,
+				EndRow:   tmProof.RowProof.EndRow,
+			},
+		},
+		dataCommitment: resp.DataCommitment,
+		dataRoot:       block.Block.DataHash,
+		dataRootProof: merkle.Proof{
+			Total:    dcProof.Proof.Total,
+			Index:    dcProof.Proof.Index,
+			LeafHash: dcProof.Proof.LeafHash,
+			Aunts:    dcProof.Proof.Aunts,
+		},
+	}, nil
+}
+
+// ABI returns the proof as the SharesProof taken by the DAVerifier contract.
+func (p sharesProof) ABI() (proof.ABISharesProof, error) {
+	attestationProof, err := proof.NewABIAttestationProof(
+		p.dataCommitment.Nonce,
+		p.height,
+		p.dataRoot,
+		proof.Proof{
+			Total:    p.dataRootProof.Total,
+			Index:    p.dataRootProof.Index,
+			LeafHash: p.dataRootProof.LeafHash,
+			Aunts:    p.dataRootProof.Aunts,
+		},
+	)
+	if err != nil {
+		return proof.ABISharesProof{}, err
+	}
+	return p.shareProof.ABI(attestationProof)
+}
+
+// queryBlobShareRange returns the height of the transaction with the hash and
+// the range of the shares of its blob at the index.
+func queryBlobShareRange(ctx context.Context, trpc *http.HTTP, txHash []byte, blobIndex uint64) (int64, shares.Range, error) {
+	tx, err := trpc.Tx(ctx, txHash, true)
+	if err != nil {
+		return 0, shares.Range{}, err
+	}
+	blockRes, err := trpc.Block(ctx, &tx.Height)
+	if err != nil {
+		return 0, shares.Range{}, err
+	}
+
+	version := blockRes.Block.Header.Version.App
+	maxSquareSize := appconsts.SquareSizeUpperBound(version)
+	subtreeRootThreshold := appconsts.SubtreeRootThreshold(version)
+	blobShareRange, err := square.BlobShareRange(blockRes.Block.Txs.ToSliceOfBytes(), int(tx.Index), int(blobIndex), maxSquareSize, subtreeRootThreshold)
+	if err != nil {
+		return 0, shares.Range{}, err
+	}
+	return tx.Height, blobShareRange, nil
+}
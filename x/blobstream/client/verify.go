@@ -3,6 +3,7 @@ package client
 import (
 	"context"
 	"encoding/hex"
+	"errors"
 	"math/big"
 	"os"
 	"strconv"
@@ -12,14 +13,11 @@ import (
 
 	wrapper "github.com/celestiaorg/blobstream-contracts/v3/wrappers/Blobstream.sol"
 	"github.com/celestiaorg/celestia-app/pkg/appconsts"
-	"github.com/celestiaorg/celestia-app/x/blobstream/types"
 	"github.com/celestiaorg/go-square/square"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/spf13/cobra"
 	tmlog "github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/rpc/client/http"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
 func VerifyCmd() *cobra.Command {
@@ -134,27 +132,14 @@ func blobCmd() *cobra.Command {
 				}
 			}(trpc)
 
-			tx, err := trpc.Tx(cmd.Context(), txHash, true)
-			if err != nil {
-				return err
-			}
-
-			logger.Info("verifying that the blob was committed to by the Blobstream", "tx_hash", args[0], "height", tx.Height)
-
-			blockRes, err := trpc.Block(cmd.Context(), &tx.Height)
+			height, blobShareRange, err := queryBlobShareRange(cmd.Context(), trpc, txHash, blobIndex)
 			if err != nil {
 				return err
 			}
 
-			version := blockRes.Block.Header.Version.App
-			maxSquareSize := appconsts.SquareSizeUpperBound(version)
-			subtreeRootThreshold := appconsts.SubtreeRootThreshold(version)
-			blobShareRange, err := square.BlobShareRange(blockRes.Block.Txs.ToSliceOfBytes(), int(tx.Index), int(blobIndex), maxSquareSize, subtreeRootThreshold)
-			if err != nil {
-				return err
-			}
+			logger.Info("verifying that the blob was committed to by the Blobstream", "tx_hash", args[0], "height", height)
 
-			_, err = VerifyShares(cmd.Context(), logger, config, uint64(tx.Height), uint64(blobShareRange.Start), uint64(blobShareRange.End))
+			_, err = VerifyShares(cmd.Context(), logger, config, uint64(height), uint64(blobShareRange.Start), uint64(blobShareRange.End))
 			return err
 		},
 	}
@@ -220,68 +205,29 @@ func VerifyShares(ctx context.Context, logger tmlog.Logger, config VerifyConfig,
 		endShare,
 	)
 
-	logger.Debug("getting shares proof from tendermint node")
-	sharesProofs, err := trpc.ProveShares(ctx, height, startShare, endShare)
-	if err != nil {
-		return false, err
-	}
-
-	logger.Debug("verifying shares proofs")
-	// checks if the shares proof is valid.
-	// the shares proof is self verifiable because it contains also the rows roots
-	// which the nmt shares proof is verified against.
-	if !sharesProofs.VerifyProof() {
-		logger.Info("proofs from shares to data root are invalid")
-		return false, err
+	sharesProof, err := querySharesProof(ctx, logger, trpc, config.CelesGRPC, height, startShare, endShare)
+	if errors.Is(err, errInvalidSharesProof) {
+		logger.Info(err.Error())
+		return false, nil
 	}
-
-	logger.Info("proofs from shares to data root are valid")
-
-	bsGRPC, err := grpc.Dial(config.CelesGRPC, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	if err != nil {
 		return false, err
 	}
-	defer func(bsGRPC *grpc.ClientConn) {
-		err := bsGRPC.Close()
-		if err != nil {
-			logger.Debug("error closing connection", "err", err.Error())
-		}
-	}(bsGRPC)
-
-	queryClient := types.NewQueryClient(bsGRPC)
 
-	resp, err := queryClient.DataCommitmentRangeForHeight(
-		ctx,
-		&types.QueryDataCommitmentRangeForHeightRequest{Height: height},
-	)
-	if err != nil {
-		return false, err
-	}
+	logger.Info("proofs from shares to data root are valid")
 
 	logger.Info(
 		"proving that the data root was committed to in the Blobstream contract",
 		"contract_address",
 		config.ContractAddr,
 		"fist_block",
-		resp.DataCommitment.BeginBlock,
+		sharesProof.dataCommitment.BeginBlock,
 		"last_block",
-		resp.DataCommitment.EndBlock,
+		sharesProof.dataCommitment.EndBlock,
 		"nonce",
-		resp.DataCommitment.Nonce,
+		sharesProof.dataCommitment.Nonce,
 	)
 
-	logger.Debug("getting the data root to commitment inclusion proof")
-	dcProof, err := trpc.DataRootInclusionProof(ctx, height, resp.DataCommitment.BeginBlock, resp.DataCommitment.EndBlock)
-	if err != nil {
-		return false, err
-	}
-
-	heightI := int64(height)
-	block, err := trpc.Block(ctx, &heightI)
-	if err != nil {
-		return false, err
-	}
-
 	ethClient, err := ethclient.Dial(config.EVMRPC)
 	if err != nil {
 		return false, err
@@ -297,15 +243,10 @@ func VerifyShares(ctx context.Context, logger tmlog.Logger, config VerifyConfig,
 	isCommittedTo, err = VerifyDataRootInclusion(
 		ctx,
 		bsWrapper,
-		resp.DataCommitment.Nonce,
+		sharesProof.dataCommitment.Nonce,
 		height,
-		block.Block.DataHash,
-		merkle.Proof{
-			Total:    dcProof.Proof.Total,
-			Index:    dcProof.Proof.Index,
-			LeafHash: dcProof.Proof.LeafHash,
-			Aunts:    dcProof.Proof.Aunts,
-		},
+		sharesProof.dataRoot,
+		sharesProof.dataRootProof,
 	)
 	if err != nil {
 		return false, err